@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the migrations are numbered <version>_<name>.up.sql and <version>_<name>.down.sql, sqlc reads the up files as
+// the schema and skips the down files
+//
+//go:embed sql/migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the postgres advisory lock held while migrating, so servers starting together apply each
+// migration once.
+const migrationLockKey int64 = 7_461_636_746_167_001
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);`
+
+// Migration is a numbered change to the schema, Up applies it and Down reverts it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState is a migration and when it was applied, AppliedOn is invalid while it is pending.
+type MigrationState struct {
+	Migration
+	AppliedOn pgtype.Timestamptz
+}
+
+// Migrations returns the embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	files, err := fs.Glob(migrationFiles, "sql/migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		base := path.Base(file)
+		stem, up := strings.CutSuffix(base, ".up.sql")
+		if !up {
+			var down bool
+			if stem, down = strings.CutSuffix(base, ".down.sql"); !down {
+				return nil, fmt.Errorf("migration %s is neither .up.sql nor .down.sql", base)
+			}
+		}
+		prefix, name, found := strings.Cut(stem, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !found || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s is not named <version>_<name>", base)
+		}
+
+		content, err := migrationFiles.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, name)
+		}
+		if up {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// MigrateUp applies the pending migrations in order and returns them. A database created before migrations
+// existed, which has the tables of the first migration, is recorded as being at the first migration instead.
+func MigrateUp(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		states, err := migrationStates(ctx, conn, migrations)
+		if err != nil {
+			return err
+		}
+
+		if len(states) > 0 && !anyApplied(states) {
+			var existing bool
+			err := conn.QueryRow(ctx, "SELECT to_regclass('player_accounts') IS NOT NULL").Scan(&existing)
+			if err != nil {
+				return err
+			}
+			if existing {
+				if err := recordMigration(ctx, conn, states[0].Migration); err != nil {
+					return err
+				}
+				states[0].AppliedOn = pgtype.Timestamptz{Valid: true}
+			}
+		}
+
+		for _, state := range states {
+			if state.AppliedOn.Valid {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, state.Up); err != nil {
+					return err
+				}
+				return recordMigration(ctx, tx, state.Migration)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", state.Version, state.Name, err)
+			}
+			applied = append(applied, state.Migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// MigrateDown reverts the last steps applied migrations, newest first, and returns them.
+func MigrateDown(ctx context.Context, pool *pgxpool.Pool, steps int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT $1", steps)
+		if err != nil {
+			return err
+		}
+		versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+
+		for _, version := range versions {
+			i := sort.Search(len(migrations), func(i int) bool {
+				return migrations[i].Version >= version
+			})
+			if i == len(migrations) || migrations[i].Version != version {
+				return fmt.Errorf("migration %d is applied but unknown to this build", version)
+			}
+			migration := migrations[i]
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// GetMigrationStates returns every embedded migration with when it was applied.
+func GetMigrationStates(ctx context.Context, pool *pgxpool.Pool) ([]MigrationState, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var states []MigrationState
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		states, err = migrationStates(ctx, conn, migrations)
+		return err
+	})
+	return states, err
+}
+
+// withMigrationLock runs fn on a connection holding the migration lock, with the schema_migrations table created.
+func withMigrationLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	defer func() {
+		// a lock left behind on a broken connection goes away with it
+		_, _ = conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	}()
+
+	if _, err = conn.Exec(ctx, createMigrationsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+func migrationStates(ctx context.Context, conn *pgxpool.Conn, migrations []Migration) ([]MigrationState, error) {
+	rows, err := conn.Query(ctx, "SELECT version, applied_on FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	appliedOn := map[int64]pgtype.Timestamptz{}
+	var version int64
+	var on pgtype.Timestamptz
+	_, err = pgx.ForEachRow(rows, []any{&version, &on}, func() error {
+		appliedOn[version] = on
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, len(migrations))
+	for i, migration := range migrations {
+		states[i] = MigrationState{Migration: migration, AppliedOn: appliedOn[migration.Version]}
+	}
+	return states, nil
+}
+
+func anyApplied(states []MigrationState) bool {
+	for _, state := range states {
+		if state.AppliedOn.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+func recordMigration(ctx context.Context, conn DBTX, migration Migration) error {
+	_, err := conn.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+	return err
+}
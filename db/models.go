@@ -8,15 +8,65 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Analysis struct {
+	GameID         int64
+	Ord            int32
+	MoveRow        int32
+	MoveCol        int32
+	XMoved         bool
+	BestResult     int32
+	BestDistance   int32
+	PlayedResult   int32
+	PlayedDistance int32
+	Annotation     int32
+	AnalyzedOn     pgtype.Timestamptz
+}
+
+type AuditEvent struct {
+	ID        int64
+	ActorID   pgtype.Int8
+	EventType string
+	TargetID  pgtype.Int8
+	Peer      string
+	Method    string
+	Payload   []byte
+	CreatedOn pgtype.Timestamptz
+}
+
+type DailyPuzzle struct {
+	Day      pgtype.Date
+	PuzzleID int64
+}
+
 type Game struct {
-	ID         int64
-	XPlayer    int64
-	OPlayer    pgtype.Int8
-	BoardState string
-	XTurn      pgtype.Bool
-	UpdatedOn  pgtype.Timestamptz
-	StartedOn  pgtype.Timestamptz
-	Result     int32
+	ID                int64
+	XPlayer           int64
+	OPlayer           pgtype.Int8
+	BoardState        string
+	XTurn             pgtype.Bool
+	UpdatedOn         pgtype.Timestamptz
+	StartedOn         pgtype.Timestamptz
+	Result            int32
+	StartState        string
+	HintBudget        int32
+	XHintsUsed        int32
+	OHintsUsed        int32
+	Opening           int32
+	TakebacksDisabled bool
+	TakebackBy        pgtype.Int8
+	RematchOf         pgtype.Int8
+	RematchBy         pgtype.Int8
+	EndedBy           pgtype.Int8
+	Version           int32
+}
+
+type GameMessage struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int32
+	Text     string
+	SentOn   pgtype.Timestamptz
 }
 
 type GameStep struct {
@@ -30,14 +80,67 @@ type GameStep struct {
 	MadeOn  pgtype.Timestamptz
 }
 
+type IdempotencyKey struct {
+	PlayerID     int64
+	Key          string
+	Method       string
+	RequestHash  string
+	ResponseType string
+	Response     []byte
+	CreatedOn    pgtype.Timestamptz
+	ExpiresOn    pgtype.Timestamptz
+}
+
+type LoginFailure struct {
+	Subject     string
+	Failures    int32
+	FailedOn    pgtype.Timestamptz
+	LockedUntil pgtype.Timestamptz
+}
+
 type PlayerAccount struct {
-	ID       int64
-	Username string
-	Passwd   string
-	Salt     string
+	ID               int64
+	Username         string
+	Passwd           string
+	Salt             string
+	PuzzleStreak     int32
+	BestPuzzleStreak int32
+	DeletedOn        pgtype.Timestamptz
+	Role             int32
+	RegisteredOn     pgtype.Timestamptz
+	BannedOn         pgtype.Timestamptz
+	BanReason        string
 }
 
 type PlayerSession struct {
-	Token    string
-	PlayerID int64
+	PlayerID   int64
+	ID         int64
+	TokenHash  string
+	Device     string
+	CreatedOn  pgtype.Timestamptz
+	LastSeenOn pgtype.Timestamptz
+	ExpiresOn  pgtype.Timestamptz
+}
+
+type Puzzle struct {
+	ID          int64
+	BoardState  string
+	XTurn       bool
+	Depth       int32
+	SolutionRow int32
+	SolutionCol int32
+	Difficulty  int32
+	CreatedOn   pgtype.Timestamptz
+}
+
+type PuzzleAttempt struct {
+	PuzzleID    int64
+	PlayerID    int64
+	BoardState  string
+	XTurn       bool
+	MovesLeft   int32
+	Status      int32
+	StartedOn   pgtype.Timestamptz
+	UpdatedOn   pgtype.Timestamptz
+	CompletedOn pgtype.Timestamptz
 }
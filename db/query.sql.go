@@ -12,20 +12,332 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizePlayer = `-- name: AnonymizePlayer :execresult
+UPDATE player_accounts
+SET username = 'deleted player ' || LPAD(id::TEXT, 19, '0'), passwd = '', salt = '', deleted_on = $2
+WHERE id = $1 AND deleted_on IS NULL
+`
+
+type AnonymizePlayerParams struct {
+	ID        int64
+	DeletedOn pgtype.Timestamptz
+}
+
+func (q *Queries) AnonymizePlayer(ctx context.Context, arg AnonymizePlayerParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, anonymizePlayer, arg.ID, arg.DeletedOn)
+}
+
+const banPlayer = `-- name: BanPlayer :one
+UPDATE player_accounts
+SET banned_on = $2, ban_reason = $3
+WHERE id = $1 AND deleted_on IS NULL AND banned_on IS NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+type BanPlayerParams struct {
+	ID        int64
+	BannedOn  pgtype.Timestamptz
+	BanReason string
+}
+
+func (q *Queries) BanPlayer(ctx context.Context, arg BanPlayerParams) (PlayerAccount, error) {
+	row := q.db.QueryRow(ctx, banPlayer, arg.ID, arg.BannedOn, arg.BanReason)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
+const clearLoginFailures = `-- name: ClearLoginFailures :exec
+DELETE FROM login_failures
+WHERE subject = $1
+`
+
+func (q *Queries) ClearLoginFailures(ctx context.Context, subject string) error {
+	_, err := q.db.Exec(ctx, clearLoginFailures, subject)
+	return err
+}
+
+const clearRematch = `-- name: ClearRematch :execresult
+UPDATE games
+SET rematch_by = NULL
+WHERE id = $1 AND rematch_by = $2
+`
+
+type ClearRematchParams struct {
+	ID        int64
+	RematchBy pgtype.Int8
+}
+
+func (q *Queries) ClearRematch(ctx context.Context, arg ClearRematchParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, clearRematch, arg.ID, arg.RematchBy)
+}
+
+const clearTakeback = `-- name: ClearTakeback :execresult
+UPDATE games
+SET takeback_by = NULL
+WHERE id = $1 AND takeback_by = $2
+`
+
+type ClearTakebackParams struct {
+	ID         int64
+	TakebackBy pgtype.Int8
+}
+
+func (q *Queries) ClearTakeback(ctx context.Context, arg ClearTakebackParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, clearTakeback, arg.ID, arg.TakebackBy)
+}
+
+const countRecentMessages = `-- name: CountRecentMessages :one
+SELECT COUNT(*) FROM game_messages
+WHERE player_id = $1 AND sent_on > $2
+`
+
+type CountRecentMessagesParams struct {
+	PlayerID int64
+	SentOn   pgtype.Timestamptz
+}
+
+func (q *Queries) CountRecentMessages(ctx context.Context, arg CountRecentMessagesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRecentMessages, arg.PlayerID, arg.SentOn)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteMessage = `-- name: DeleteMessage :execresult
+DELETE FROM game_messages
+WHERE id = $1
+`
+
+func (q *Queries) DeleteMessage(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deleteMessage, id)
+}
+
+const deleteOtherSessions = `-- name: DeleteOtherSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = $1 AND id <> $2
+`
+
+type DeleteOtherSessionsParams struct {
+	PlayerID int64
+	ID       int64
+}
+
+func (q *Queries) DeleteOtherSessions(ctx context.Context, arg DeleteOtherSessionsParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deleteOtherSessions, arg.PlayerID, arg.ID)
+}
+
+const deletePlayerSessions = `-- name: DeletePlayerSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = $1
+`
+
+func (q *Queries) DeletePlayerSessions(ctx context.Context, playerID int64) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deletePlayerSessions, playerID)
+}
+
+const deleteSession = `-- name: DeleteSession :execresult
+DELETE FROM player_sessions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deleteSession, id)
+}
+
+const deleteStepsFrom = `-- name: DeleteStepsFrom :execresult
+DELETE FROM game_steps
+WHERE game_id = $1 AND ord >= $2
+`
+
+type DeleteStepsFromParams struct {
+	GameID int64
+	Ord    int32
+}
+
+func (q *Queries) DeleteStepsFrom(ctx context.Context, arg DeleteStepsFromParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deleteStepsFrom, arg.GameID, arg.Ord)
+}
+
+const forceEndGame = `-- name: ForceEndGame :execresult
+UPDATE games
+SET result = $2, updated_on = $3, ended_by = $4, takeback_by = NULL, rematch_by = NULL, version = version + 1
+WHERE id = $1 AND result = 0
+`
+
+type ForceEndGameParams struct {
+	ID        int64
+	Result    int32
+	UpdatedOn pgtype.Timestamptz
+	EndedBy   pgtype.Int8
+}
+
+func (q *Queries) ForceEndGame(ctx context.Context, arg ForceEndGameParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, forceEndGame,
+		arg.ID,
+		arg.Result,
+		arg.UpdatedOn,
+		arg.EndedBy,
+	)
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT id, username, passwd FROM player_accounts WHERE id = $1 AND deleted_on IS NULL
+`
+
+type GetAccountRow struct {
+	ID       int64
+	Username string
+	Passwd   string
+}
+
+func (q *Queries) GetAccount(ctx context.Context, id int64) (GetAccountRow, error) {
+	row := q.db.QueryRow(ctx, getAccount, id)
+	var i GetAccountRow
+	err := row.Scan(&i.ID, &i.Username, &i.Passwd)
+	return i, err
+}
+
 const getAccountByName = `-- name: GetAccountByName :one
-SELECT id, username, passwd FROM player_accounts WHERE UPPER(username) = UPPER($1)
+SELECT id, username, passwd, banned_on FROM player_accounts WHERE UPPER(username) = UPPER($1) AND deleted_on IS NULL
 `
 
 type GetAccountByNameRow struct {
 	ID       int64
 	Username string
 	Passwd   string
+	BannedOn pgtype.Timestamptz
 }
 
 func (q *Queries) GetAccountByName(ctx context.Context, upper interface{}) (GetAccountByNameRow, error) {
 	row := q.db.QueryRow(ctx, getAccountByName, upper)
 	var i GetAccountByNameRow
-	err := row.Scan(&i.ID, &i.Username, &i.Passwd)
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.BannedOn,
+	)
+	return i, err
+}
+
+const getAnalyses = `-- name: GetAnalyses :many
+SELECT game_id, ord, move_row, move_col, x_moved, best_result, best_distance, played_result, played_distance, annotation, analyzed_on FROM analyses
+WHERE game_id = $1
+ORDER BY ord
+`
+
+func (q *Queries) GetAnalyses(ctx context.Context, gameID int64) ([]Analysis, error) {
+	rows, err := q.db.Query(ctx, getAnalyses, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Analysis
+	for rows.Next() {
+		var i Analysis
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Ord,
+			&i.MoveRow,
+			&i.MoveCol,
+			&i.XMoved,
+			&i.BestResult,
+			&i.BestDistance,
+			&i.PlayedResult,
+			&i.PlayedDistance,
+			&i.Annotation,
+			&i.AnalyzedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyLeaderboard = `-- name: GetDailyLeaderboard :many
+SELECT a.player_id, p.username, a.started_on, a.completed_on
+FROM daily_puzzles d
+INNER JOIN puzzle_attempts a ON a.puzzle_id = d.puzzle_id
+INNER JOIN player_accounts p ON p.id = a.player_id
+WHERE d.day = $1 AND a.status = $2
+ORDER BY a.completed_on - a.started_on ASC, a.player_id ASC
+LIMIT $3
+`
+
+type GetDailyLeaderboardParams struct {
+	Day    pgtype.Date
+	Status int32
+	Limit  int32
+}
+
+type GetDailyLeaderboardRow struct {
+	PlayerID    int64
+	Username    string
+	StartedOn   pgtype.Timestamptz
+	CompletedOn pgtype.Timestamptz
+}
+
+func (q *Queries) GetDailyLeaderboard(ctx context.Context, arg GetDailyLeaderboardParams) ([]GetDailyLeaderboardRow, error) {
+	rows, err := q.db.Query(ctx, getDailyLeaderboard, arg.Day, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDailyLeaderboardRow
+	for rows.Next() {
+		var i GetDailyLeaderboardRow
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.Username,
+			&i.StartedOn,
+			&i.CompletedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyPuzzle = `-- name: GetDailyPuzzle :one
+SELECT p.id, p.board_state, p.x_turn, p.depth, p.solution_row, p.solution_col, p.difficulty, p.created_on FROM daily_puzzles d
+INNER JOIN puzzles p ON p.id = d.puzzle_id
+WHERE d.day = $1
+`
+
+func (q *Queries) GetDailyPuzzle(ctx context.Context, day pgtype.Date) (Puzzle, error) {
+	row := q.db.QueryRow(ctx, getDailyPuzzle, day)
+	var i Puzzle
+	err := row.Scan(
+		&i.ID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.Depth,
+		&i.SolutionRow,
+		&i.SolutionCol,
+		&i.Difficulty,
+		&i.CreatedOn,
+	)
 	return i, err
 }
 
@@ -35,12 +347,25 @@ SELECT
     g.x_player,
     g.o_player,
     g.board_state,
+    g.start_state,
     g.x_turn,
     g.updated_on,
     g.started_on,
     g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
+    g.version,
     a1.username as x_player_name,
-    a2.username as o_player_name
+    a2.username as o_player_name,
+    (a1.deleted_on IS NOT NULL)::BOOLEAN as x_player_deleted,
+    (a2.deleted_on IS NOT NULL)::BOOLEAN as o_player_deleted
 FROM games g
 LEFT JOIN player_accounts a1 ON a1.id = g.x_player
 LEFT JOIN player_accounts a2 ON a2.id = g.o_player
@@ -48,16 +373,29 @@ WHERE g.id = $1
 `
 
 type GetGameRow struct {
-	ID          int64
-	XPlayer     int64
-	OPlayer     pgtype.Int8
-	BoardState  string
-	XTurn       pgtype.Bool
-	UpdatedOn   pgtype.Timestamptz
-	StartedOn   pgtype.Timestamptz
-	Result      int32
-	XPlayerName pgtype.Text
-	OPlayerName pgtype.Text
+	ID                int64
+	XPlayer           int64
+	OPlayer           pgtype.Int8
+	BoardState        string
+	StartState        string
+	XTurn             pgtype.Bool
+	UpdatedOn         pgtype.Timestamptz
+	StartedOn         pgtype.Timestamptz
+	Result            int32
+	HintBudget        int32
+	XHintsUsed        int32
+	OHintsUsed        int32
+	Opening           int32
+	TakebacksDisabled bool
+	TakebackBy        pgtype.Int8
+	RematchOf         pgtype.Int8
+	RematchBy         pgtype.Int8
+	EndedBy           pgtype.Int8
+	Version           int32
+	XPlayerName       pgtype.Text
+	OPlayerName       pgtype.Text
+	XPlayerDeleted    bool
+	OPlayerDeleted    bool
 }
 
 func (q *Queries) GetGame(ctx context.Context, id int64) (GetGameRow, error) {
@@ -68,16 +406,120 @@ func (q *Queries) GetGame(ctx context.Context, id int64) (GetGameRow, error) {
 		&i.XPlayer,
 		&i.OPlayer,
 		&i.BoardState,
+		&i.StartState,
 		&i.XTurn,
 		&i.UpdatedOn,
 		&i.StartedOn,
 		&i.Result,
+		&i.HintBudget,
+		&i.XHintsUsed,
+		&i.OHintsUsed,
+		&i.Opening,
+		&i.TakebacksDisabled,
+		&i.TakebackBy,
+		&i.RematchOf,
+		&i.RematchBy,
+		&i.EndedBy,
+		&i.Version,
 		&i.XPlayerName,
 		&i.OPlayerName,
+		&i.XPlayerDeleted,
+		&i.OPlayerDeleted,
 	)
 	return i, err
 }
 
+const getGameMessages = `-- name: GetGameMessages :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = $1
+ORDER BY m.id
+`
+
+type GetGameMessagesRow struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int32
+	Text     string
+	SentOn   pgtype.Timestamptz
+	Username string
+}
+
+func (q *Queries) GetGameMessages(ctx context.Context, gameID int64) ([]GetGameMessagesRow, error) {
+	rows, err := q.db.Query(ctx, getGameMessages, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGameMessagesRow
+	for rows.Next() {
+		var i GetGameMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PlayerID,
+			&i.Channel,
+			&i.Text,
+			&i.SentOn,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGamePositions = `-- name: GetGamePositions :many
+SELECT id, board_state, x_turn, result, ended_by FROM games
+WHERE id > $1
+ORDER BY id ASC LIMIT $2
+`
+
+type GetGamePositionsParams struct {
+	ID    int64
+	Limit int32
+}
+
+type GetGamePositionsRow struct {
+	ID         int64
+	BoardState string
+	XTurn      pgtype.Bool
+	Result     int32
+	EndedBy    pgtype.Int8
+}
+
+func (q *Queries) GetGamePositions(ctx context.Context, arg GetGamePositionsParams) ([]GetGamePositionsRow, error) {
+	rows, err := q.db.Query(ctx, getGamePositions, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGamePositionsRow
+	for rows.Next() {
+		var i GetGamePositionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BoardState,
+			&i.XTurn,
+			&i.Result,
+			&i.EndedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getGameSteps = `-- name: GetGameSteps :many
 SELECT game_id, ord, move_row, move_col, board, x_turn, result, made_on FROM game_steps
 WHERE game_id = $1
@@ -119,12 +561,24 @@ SELECT
     g.x_player,
     g.o_player,
     g.board_state,
+    g.start_state,
     g.x_turn,
     g.updated_on,
     g.started_on,
     g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
     a1.username as x_player_name,
-    a2.username as o_player_name
+    a2.username as o_player_name,
+    (a1.deleted_on IS NOT NULL)::BOOLEAN as x_player_deleted,
+    (a2.deleted_on IS NOT NULL)::BOOLEAN as o_player_deleted
 FROM games g
 LEFT JOIN player_accounts a1 ON a1.id = g.x_player
 LEFT JOIN player_accounts a2 ON a2.id = g.o_player
@@ -142,16 +596,28 @@ type GetGamesParams struct {
 }
 
 type GetGamesRow struct {
-	ID          int64
-	XPlayer     int64
-	OPlayer     pgtype.Int8
-	BoardState  string
-	XTurn       pgtype.Bool
-	UpdatedOn   pgtype.Timestamptz
-	StartedOn   pgtype.Timestamptz
-	Result      int32
-	XPlayerName pgtype.Text
-	OPlayerName pgtype.Text
+	ID                int64
+	XPlayer           int64
+	OPlayer           pgtype.Int8
+	BoardState        string
+	StartState        string
+	XTurn             pgtype.Bool
+	UpdatedOn         pgtype.Timestamptz
+	StartedOn         pgtype.Timestamptz
+	Result            int32
+	HintBudget        int32
+	XHintsUsed        int32
+	OHintsUsed        int32
+	Opening           int32
+	TakebacksDisabled bool
+	TakebackBy        pgtype.Int8
+	RematchOf         pgtype.Int8
+	RematchBy         pgtype.Int8
+	EndedBy           pgtype.Int8
+	XPlayerName       pgtype.Text
+	OPlayerName       pgtype.Text
+	XPlayerDeleted    bool
+	OPlayerDeleted    bool
 }
 
 func (q *Queries) GetGames(ctx context.Context, arg GetGamesParams) ([]GetGamesRow, error) {
@@ -173,12 +639,24 @@ func (q *Queries) GetGames(ctx context.Context, arg GetGamesParams) ([]GetGamesR
 			&i.XPlayer,
 			&i.OPlayer,
 			&i.BoardState,
+			&i.StartState,
 			&i.XTurn,
 			&i.UpdatedOn,
 			&i.StartedOn,
 			&i.Result,
+			&i.HintBudget,
+			&i.XHintsUsed,
+			&i.OHintsUsed,
+			&i.Opening,
+			&i.TakebacksDisabled,
+			&i.TakebackBy,
+			&i.RematchOf,
+			&i.RematchBy,
+			&i.EndedBy,
 			&i.XPlayerName,
 			&i.OPlayerName,
+			&i.XPlayerDeleted,
+			&i.OPlayerDeleted,
 		); err != nil {
 			return nil, err
 		}
@@ -225,6 +703,33 @@ func (q *Queries) GetGamesSteps(ctx context.Context, gameids []int64) ([]GameSte
 	return items, nil
 }
 
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT player_id, key, method, request_hash, response_type, response, created_on, expires_on FROM idempotency_keys
+WHERE player_id = $1 AND key = $2 AND expires_on > $3
+`
+
+type GetIdempotencyKeyParams struct {
+	PlayerID  int64
+	Key       string
+	ExpiresOn pgtype.Timestamptz
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, arg.PlayerID, arg.Key, arg.ExpiresOn)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Key,
+		&i.Method,
+		&i.RequestHash,
+		&i.ResponseType,
+		&i.Response,
+		&i.CreatedOn,
+		&i.ExpiresOn,
+	)
+	return i, err
+}
+
 const getLastStep = `-- name: GetLastStep :one
 SELECT game_id, ord, move_row, move_col, board, x_turn, result, made_on FROM game_steps
 WHERE game_id = $1
@@ -247,42 +752,246 @@ func (q *Queries) GetLastStep(ctx context.Context, gameID int64) (GameStep, erro
 	return i, err
 }
 
-const getPlayer = `-- name: GetPlayer :one
-SELECT id, username FROM player_accounts WHERE id = $1
+const getLoginLockout = `-- name: GetLoginLockout :one
+SELECT MAX(locked_until)::TIMESTAMPTZ as locked_until FROM login_failures
+WHERE subject = ANY ($1::TEXT[])
 `
 
-type GetPlayerRow struct {
+func (q *Queries) GetLoginLockout(ctx context.Context, subjects []string) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getLoginLockout, subjects)
+	var locked_until pgtype.Timestamptz
+	err := row.Scan(&locked_until)
+	return locked_until, err
+}
+
+const getMessage = `-- name: GetMessage :one
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.id = $1
+`
+
+type GetMessageRow struct {
 	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int32
+	Text     string
+	SentOn   pgtype.Timestamptz
 	Username string
 }
 
-func (q *Queries) GetPlayer(ctx context.Context, id int64) (GetPlayerRow, error) {
-	row := q.db.QueryRow(ctx, getPlayer, id)
-	var i GetPlayerRow
-	err := row.Scan(&i.ID, &i.Username)
+func (q *Queries) GetMessage(ctx context.Context, id int64) (GetMessageRow, error) {
+	row := q.db.QueryRow(ctx, getMessage, id)
+	var i GetMessageRow
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PlayerID,
+		&i.Channel,
+		&i.Text,
+		&i.SentOn,
+		&i.Username,
+	)
 	return i, err
 }
 
-const getPlayers = `-- name: GetPlayers :many
-SELECT id, username, (SELECT COUNT(*) FROM player_sessions s WHERE s.player_id = a.id) as cnt
-FROM player_accounts a
-WHERE id > $1
-ORDER BY id ASC LIMIT $2
+const getMessagesAfter = `-- name: GetMessagesAfter :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = $1 AND m.channel = $2 AND m.id > $3
+ORDER BY m.id LIMIT $4
 `
 
-type GetPlayersParams struct {
-	ID    int64
-	Limit int32
+type GetMessagesAfterParams struct {
+	GameID  int64
+	Channel int32
+	ID      int64
+	Limit   int32
 }
 
-type GetPlayersRow struct {
+type GetMessagesAfterRow struct {
 	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int32
+	Text     string
+	SentOn   pgtype.Timestamptz
 	Username string
-	Cnt      int64
 }
 
-func (q *Queries) GetPlayers(ctx context.Context, arg GetPlayersParams) ([]GetPlayersRow, error) {
-	rows, err := q.db.Query(ctx, getPlayers, arg.ID, arg.Limit)
+func (q *Queries) GetMessagesAfter(ctx context.Context, arg GetMessagesAfterParams) ([]GetMessagesAfterRow, error) {
+	rows, err := q.db.Query(ctx, getMessagesAfter,
+		arg.GameID,
+		arg.Channel,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMessagesAfterRow
+	for rows.Next() {
+		var i GetMessagesAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PlayerID,
+			&i.Channel,
+			&i.Text,
+			&i.SentOn,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpeningStats = `-- name: GetOpeningStats :many
+SELECT
+    g.opening,
+    g.result,
+    COALESCE(g.x_player = $1, FALSE)::BOOLEAN as player_is_x,
+    COUNT(*) as games
+FROM games g
+WHERE g.opening <> 0
+    AND (g.x_player = $1 OR g.o_player = $1 OR $1 IS NULL)
+GROUP BY g.opening, g.result, player_is_x
+ORDER BY g.opening, g.result
+`
+
+type GetOpeningStatsRow struct {
+	Opening   int32
+	Result    int32
+	PlayerIsX bool
+	Games     int64
+}
+
+func (q *Queries) GetOpeningStats(ctx context.Context, player pgtype.Int8) ([]GetOpeningStatsRow, error) {
+	rows, err := q.db.Query(ctx, getOpeningStats, player)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOpeningStatsRow
+	for rows.Next() {
+		var i GetOpeningStatsRow
+		if err := rows.Scan(
+			&i.Opening,
+			&i.Result,
+			&i.PlayerIsX,
+			&i.Games,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayer = `-- name: GetPlayer :one
+SELECT id, username FROM player_accounts WHERE id = $1
+`
+
+type GetPlayerRow struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) GetPlayer(ctx context.Context, id int64) (GetPlayerRow, error) {
+	row := q.db.QueryRow(ctx, getPlayer, id)
+	var i GetPlayerRow
+	err := row.Scan(&i.ID, &i.Username)
+	return i, err
+}
+
+const getPlayerRole = `-- name: GetPlayerRole :one
+SELECT role, banned_on FROM player_accounts
+WHERE id = $1 AND deleted_on IS NULL
+`
+
+type GetPlayerRoleRow struct {
+	Role     int32
+	BannedOn pgtype.Timestamptz
+}
+
+func (q *Queries) GetPlayerRole(ctx context.Context, id int64) (GetPlayerRoleRow, error) {
+	row := q.db.QueryRow(ctx, getPlayerRole, id)
+	var i GetPlayerRoleRow
+	err := row.Scan(&i.Role, &i.BannedOn)
+	return i, err
+}
+
+const getPlayerSessions = `-- name: GetPlayerSessions :many
+SELECT id, device, created_on, last_seen_on, expires_on FROM player_sessions
+WHERE player_id = $1 AND expires_on > CURRENT_TIMESTAMP
+ORDER BY last_seen_on DESC
+`
+
+type GetPlayerSessionsRow struct {
+	ID         int64
+	Device     string
+	CreatedOn  pgtype.Timestamptz
+	LastSeenOn pgtype.Timestamptz
+	ExpiresOn  pgtype.Timestamptz
+}
+
+func (q *Queries) GetPlayerSessions(ctx context.Context, playerID int64) ([]GetPlayerSessionsRow, error) {
+	rows, err := q.db.Query(ctx, getPlayerSessions, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPlayerSessionsRow
+	for rows.Next() {
+		var i GetPlayerSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Device,
+			&i.CreatedOn,
+			&i.LastSeenOn,
+			&i.ExpiresOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayers = `-- name: GetPlayers :many
+SELECT a.id, a.username, (SELECT COUNT(*) FROM player_sessions s WHERE s.player_id = a.id AND s.expires_on > CURRENT_TIMESTAMP) as cnt
+FROM player_accounts a
+WHERE a.id > $1 AND a.deleted_on IS NULL
+ORDER BY a.id ASC LIMIT $2
+`
+
+type GetPlayersParams struct {
+	ID    int64
+	Limit int32
+}
+
+type GetPlayersRow struct {
+	ID       int64
+	Username string
+	Cnt      int64
+}
+
+func (q *Queries) GetPlayers(ctx context.Context, arg GetPlayersParams) ([]GetPlayersRow, error) {
+	rows, err := q.db.Query(ctx, getPlayers, arg.ID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -301,37 +1010,321 @@ func (q *Queries) GetPlayers(ctx context.Context, arg GetPlayersParams) ([]GetPl
 	return items, nil
 }
 
+const getPuzzle = `-- name: GetPuzzle :one
+SELECT id, board_state, x_turn, depth, solution_row, solution_col, difficulty, created_on FROM puzzles WHERE id = $1
+`
+
+func (q *Queries) GetPuzzle(ctx context.Context, id int64) (Puzzle, error) {
+	row := q.db.QueryRow(ctx, getPuzzle, id)
+	var i Puzzle
+	err := row.Scan(
+		&i.ID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.Depth,
+		&i.SolutionRow,
+		&i.SolutionCol,
+		&i.Difficulty,
+		&i.CreatedOn,
+	)
+	return i, err
+}
+
+const getPuzzleAttempt = `-- name: GetPuzzleAttempt :one
+SELECT puzzle_id, player_id, board_state, x_turn, moves_left, status, started_on, updated_on, completed_on FROM puzzle_attempts
+WHERE puzzle_id = $1 AND player_id = $2
+`
+
+type GetPuzzleAttemptParams struct {
+	PuzzleID int64
+	PlayerID int64
+}
+
+func (q *Queries) GetPuzzleAttempt(ctx context.Context, arg GetPuzzleAttemptParams) (PuzzleAttempt, error) {
+	row := q.db.QueryRow(ctx, getPuzzleAttempt, arg.PuzzleID, arg.PlayerID)
+	var i PuzzleAttempt
+	err := row.Scan(
+		&i.PuzzleID,
+		&i.PlayerID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.MovesLeft,
+		&i.Status,
+		&i.StartedOn,
+		&i.UpdatedOn,
+		&i.CompletedOn,
+	)
+	return i, err
+}
+
+const getPuzzleStreak = `-- name: GetPuzzleStreak :one
+SELECT puzzle_streak, best_puzzle_streak FROM player_accounts WHERE id = $1
+`
+
+type GetPuzzleStreakRow struct {
+	PuzzleStreak     int32
+	BestPuzzleStreak int32
+}
+
+func (q *Queries) GetPuzzleStreak(ctx context.Context, id int64) (GetPuzzleStreakRow, error) {
+	row := q.db.QueryRow(ctx, getPuzzleStreak, id)
+	var i GetPuzzleStreakRow
+	err := row.Scan(&i.PuzzleStreak, &i.BestPuzzleStreak)
+	return i, err
+}
+
+const getRegistrations = `-- name: GetRegistrations :many
+SELECT id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason FROM player_accounts
+WHERE deleted_on IS NULL AND registered_on >= $1
+ORDER BY id DESC LIMIT $3 OFFSET $2
+`
+
+type GetRegistrationsParams struct {
+	Since  pgtype.Timestamptz
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) GetRegistrations(ctx context.Context, arg GetRegistrationsParams) ([]PlayerAccount, error) {
+	rows, err := q.db.Query(ctx, getRegistrations, arg.Since, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PlayerAccount
+	for rows.Next() {
+		var i PlayerAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Passwd,
+			&i.Salt,
+			&i.PuzzleStreak,
+			&i.BestPuzzleStreak,
+			&i.DeletedOn,
+			&i.Role,
+			&i.RegisteredOn,
+			&i.BannedOn,
+			&i.BanReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRematch = `-- name: GetRematch :one
+SELECT id FROM games
+WHERE rematch_of = $1
+`
+
+func (q *Queries) GetRematch(ctx context.Context, rematchOf pgtype.Int8) (int64, error) {
+	row := q.db.QueryRow(ctx, getRematch, rematchOf)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getSeries = `-- name: GetSeries :many
+WITH RECURSIVE series AS (
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    WHERE g.id = $1
+    UNION ALL
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    INNER JOIN series s ON g.id = s.rematch_of
+)
+SELECT id, x_player, o_player, result FROM series
+ORDER BY id
+`
+
+type GetSeriesRow struct {
+	ID      int64
+	XPlayer int64
+	OPlayer pgtype.Int8
+	Result  int32
+}
+
+func (q *Queries) GetSeries(ctx context.Context, id int64) ([]GetSeriesRow, error) {
+	rows, err := q.db.Query(ctx, getSeries, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSeriesRow
+	for rows.Next() {
+		var i GetSeriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.XPlayer,
+			&i.OPlayer,
+			&i.Result,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getServerStats = `-- name: GetServerStats :one
+SELECT
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.deleted_on IS NULL) as players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.banned_on IS NOT NULL) as banned_players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.registered_on >= $1::TIMESTAMPTZ) as registrations,
+    (SELECT COUNT(*) FROM player_sessions s WHERE s.expires_on > CURRENT_TIMESTAMP) as sessions,
+    (SELECT COUNT(*) FROM games g) as games,
+    (SELECT COUNT(*) FROM games g WHERE g.result = 0) as active_games,
+    (SELECT COUNT(*) FROM game_messages m) as messages
+`
+
+type GetServerStatsRow struct {
+	Players       int64
+	BannedPlayers int64
+	Registrations int64
+	Sessions      int64
+	Games         int64
+	ActiveGames   int64
+	Messages      int64
+}
+
+func (q *Queries) GetServerStats(ctx context.Context, since pgtype.Timestamptz) (GetServerStatsRow, error) {
+	row := q.db.QueryRow(ctx, getServerStats, since)
+	var i GetServerStatsRow
+	err := row.Scan(
+		&i.Players,
+		&i.BannedPlayers,
+		&i.Registrations,
+		&i.Sessions,
+		&i.Games,
+		&i.ActiveGames,
+		&i.Messages,
+	)
+	return i, err
+}
+
 const getSession = `-- name: GetSession :one
-SELECT a.id, a.username FROM player_sessions s
+SELECT a.id, a.username, s.id as session_id FROM player_sessions s
 INNER JOIN player_accounts a ON a.id = s.player_id
-WHERE token = $1
+WHERE s.token_hash = $1 AND s.expires_on > CURRENT_TIMESTAMP AND a.banned_on IS NULL
 `
 
 type GetSessionRow struct {
-	ID       int64
-	Username string
+	ID        int64
+	Username  string
+	SessionID int64
 }
 
-func (q *Queries) GetSession(ctx context.Context, token string) (GetSessionRow, error) {
-	row := q.db.QueryRow(ctx, getSession, token)
+func (q *Queries) GetSession(ctx context.Context, tokenHash string) (GetSessionRow, error) {
+	row := q.db.QueryRow(ctx, getSession, tokenHash)
 	var i GetSessionRow
-	err := row.Scan(&i.ID, &i.Username)
+	err := row.Scan(&i.ID, &i.Username, &i.SessionID)
 	return i, err
 }
 
+const insertAnalysis = `-- name: InsertAnalysis :execresult
+INSERT INTO analyses (game_id, ord, move_row, move_col, x_moved, best_result, best_distance, played_result, played_distance, annotation)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (game_id, ord) DO NOTHING
+`
+
+type InsertAnalysisParams struct {
+	GameID         int64
+	Ord            int32
+	MoveRow        int32
+	MoveCol        int32
+	XMoved         bool
+	BestResult     int32
+	BestDistance   int32
+	PlayedResult   int32
+	PlayedDistance int32
+	Annotation     int32
+}
+
+func (q *Queries) InsertAnalysis(ctx context.Context, arg InsertAnalysisParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, insertAnalysis,
+		arg.GameID,
+		arg.Ord,
+		arg.MoveRow,
+		arg.MoveCol,
+		arg.XMoved,
+		arg.BestResult,
+		arg.BestDistance,
+		arg.PlayedResult,
+		arg.PlayedDistance,
+		arg.Annotation,
+	)
+}
+
+const insertAuditEvent = `-- name: InsertAuditEvent :one
+INSERT INTO audit_events (actor_id, event_type, target_id, peer, method, payload, created_on)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id
+`
+
+type InsertAuditEventParams struct {
+	ActorID   pgtype.Int8
+	EventType string
+	TargetID  pgtype.Int8
+	Peer      string
+	Method    string
+	Payload   []byte
+	CreatedOn pgtype.Timestamptz
+}
+
+func (q *Queries) InsertAuditEvent(ctx context.Context, arg InsertAuditEventParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertAuditEvent,
+		arg.ActorID,
+		arg.EventType,
+		arg.TargetID,
+		arg.Peer,
+		arg.Method,
+		arg.Payload,
+		arg.CreatedOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertDailyPuzzle = `-- name: InsertDailyPuzzle :execresult
+INSERT INTO daily_puzzles (day, puzzle_id)
+VALUES ($1, $2)
+ON CONFLICT (day) DO NOTHING
+`
+
+type InsertDailyPuzzleParams struct {
+	Day      pgtype.Date
+	PuzzleID int64
+}
+
+func (q *Queries) InsertDailyPuzzle(ctx context.Context, arg InsertDailyPuzzleParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, insertDailyPuzzle, arg.Day, arg.PuzzleID)
+}
+
 const insertGame = `-- name: InsertGame :one
-INSERT INTO games (x_player, o_player, board_state, x_turn, updated_on, started_on)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO games (x_player, o_player, board_state, start_state, x_turn, updated_on, started_on, hint_budget, takebacks_disabled, rematch_of)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id
 `
 
 type InsertGameParams struct {
-	XPlayer    int64
-	OPlayer    pgtype.Int8
-	BoardState string
-	XTurn      pgtype.Bool
-	UpdatedOn  pgtype.Timestamptz
-	StartedOn  pgtype.Timestamptz
+	XPlayer           int64
+	OPlayer           pgtype.Int8
+	BoardState        string
+	StartState        string
+	XTurn             pgtype.Bool
+	UpdatedOn         pgtype.Timestamptz
+	StartedOn         pgtype.Timestamptz
+	HintBudget        int32
+	TakebacksDisabled bool
+	RematchOf         pgtype.Int8
 }
 
 func (q *Queries) InsertGame(ctx context.Context, arg InsertGameParams) (int64, error) {
@@ -339,9 +1332,40 @@ func (q *Queries) InsertGame(ctx context.Context, arg InsertGameParams) (int64,
 		arg.XPlayer,
 		arg.OPlayer,
 		arg.BoardState,
+		arg.StartState,
 		arg.XTurn,
 		arg.UpdatedOn,
 		arg.StartedOn,
+		arg.HintBudget,
+		arg.TakebacksDisabled,
+		arg.RematchOf,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertMessage = `-- name: InsertMessage :one
+INSERT INTO game_messages (game_id, player_id, channel, text, sent_on)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+type InsertMessageParams struct {
+	GameID   int64
+	PlayerID int64
+	Channel  int32
+	Text     string
+	SentOn   pgtype.Timestamptz
+}
+
+func (q *Queries) InsertMessage(ctx context.Context, arg InsertMessageParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertMessage,
+		arg.GameID,
+		arg.PlayerID,
+		arg.Channel,
+		arg.Text,
+		arg.SentOn,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -372,18 +1396,86 @@ func (q *Queries) InsertPlayer(ctx context.Context, arg InsertPlayerParams) (Ins
 	return i, err
 }
 
-const insertSession = `-- name: InsertSession :execresult
-INSERT INTO player_sessions (token, player_id)
-VALUES ($1, $2)
+const insertPuzzle = `-- name: InsertPuzzle :one
+INSERT INTO puzzles (board_state, x_turn, depth, solution_row, solution_col, difficulty)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+type InsertPuzzleParams struct {
+	BoardState  string
+	XTurn       bool
+	Depth       int32
+	SolutionRow int32
+	SolutionCol int32
+	Difficulty  int32
+}
+
+func (q *Queries) InsertPuzzle(ctx context.Context, arg InsertPuzzleParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertPuzzle,
+		arg.BoardState,
+		arg.XTurn,
+		arg.Depth,
+		arg.SolutionRow,
+		arg.SolutionCol,
+		arg.Difficulty,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertPuzzleAttempt = `-- name: InsertPuzzleAttempt :execresult
+INSERT INTO puzzle_attempts (puzzle_id, player_id, board_state, x_turn, moves_left)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (puzzle_id, player_id) DO NOTHING
+`
+
+type InsertPuzzleAttemptParams struct {
+	PuzzleID   int64
+	PlayerID   int64
+	BoardState string
+	XTurn      bool
+	MovesLeft  int32
+}
+
+func (q *Queries) InsertPuzzleAttempt(ctx context.Context, arg InsertPuzzleAttemptParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, insertPuzzleAttempt,
+		arg.PuzzleID,
+		arg.PlayerID,
+		arg.BoardState,
+		arg.XTurn,
+		arg.MovesLeft,
+	)
+}
+
+const insertSession = `-- name: InsertSession :one
+INSERT INTO player_sessions (token_hash, player_id, device, created_on, last_seen_on, expires_on)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
 `
 
 type InsertSessionParams struct {
-	Token    string
-	PlayerID int64
+	TokenHash  string
+	PlayerID   int64
+	Device     string
+	CreatedOn  pgtype.Timestamptz
+	LastSeenOn pgtype.Timestamptz
+	ExpiresOn  pgtype.Timestamptz
 }
 
-func (q *Queries) InsertSession(ctx context.Context, arg InsertSessionParams) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, insertSession, arg.Token, arg.PlayerID)
+func (q *Queries) InsertSession(ctx context.Context, arg InsertSessionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertSession,
+		arg.TokenHash,
+		arg.PlayerID,
+		arg.Device,
+		arg.CreatedOn,
+		arg.LastSeenOn,
+		arg.ExpiresOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const insertStep = `-- name: InsertStep :execresult
@@ -412,10 +1504,333 @@ func (q *Queries) InsertStep(ctx context.Context, arg InsertStepParams) (pgconn.
 	)
 }
 
+const lockLogin = `-- name: LockLogin :exec
+UPDATE login_failures
+SET locked_until = $2
+WHERE subject = $1
+`
+
+type LockLoginParams struct {
+	Subject     string
+	LockedUntil pgtype.Timestamptz
+}
+
+func (q *Queries) LockLogin(ctx context.Context, arg LockLoginParams) error {
+	_, err := q.db.Exec(ctx, lockLogin, arg.Subject, arg.LockedUntil)
+	return err
+}
+
+const lockPlayer = `-- name: LockPlayer :one
+SELECT id FROM player_accounts
+WHERE id = $1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) LockPlayer(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRow(ctx, lockPlayer, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const offerRematch = `-- name: OfferRematch :execresult
+UPDATE games
+SET rematch_by = $1
+WHERE id = $2 AND rematch_by IS NULL
+`
+
+type OfferRematchParams struct {
+	RematchBy pgtype.Int8
+	ID        int64
+}
+
+func (q *Queries) OfferRematch(ctx context.Context, arg OfferRematchParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, offerRematch, arg.RematchBy, arg.ID)
+}
+
+const purgeExpiredSessions = `-- name: PurgeExpiredSessions :execresult
+DELETE FROM player_sessions
+WHERE expires_on <= $1
+`
+
+func (q *Queries) PurgeExpiredSessions(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, purgeExpiredSessions, expiresOn)
+}
+
+const purgeIdempotencyKeys = `-- name: PurgeIdempotencyKeys :execresult
+DELETE FROM idempotency_keys
+WHERE expires_on <= $1
+`
+
+func (q *Queries) PurgeIdempotencyKeys(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, purgeIdempotencyKeys, expiresOn)
+}
+
+const purgeLoginFailures = `-- name: PurgeLoginFailures :execresult
+DELETE FROM login_failures
+WHERE failed_on <= $1 AND locked_until <= $2
+`
+
+type PurgeLoginFailuresParams struct {
+	WindowStart pgtype.Timestamptz
+	Now         pgtype.Timestamptz
+}
+
+func (q *Queries) PurgeLoginFailures(ctx context.Context, arg PurgeLoginFailuresParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, purgeLoginFailures, arg.WindowStart, arg.Now)
+}
+
+const queryAuditLog = `-- name: QueryAuditLog :many
+SELECT id, actor_id, event_type, target_id, peer, method, payload, created_on FROM audit_events
+WHERE created_on >= $1 AND created_on < $2
+    AND (actor_id = $3 OR $3 IS NULL)
+    AND (event_type = $4 OR $4 IS NULL)
+ORDER BY id DESC LIMIT $6 OFFSET $5
+`
+
+type QueryAuditLogParams struct {
+	Since     pgtype.Timestamptz
+	Until     pgtype.Timestamptz
+	ActorId   pgtype.Int8
+	EventType pgtype.Text
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) QueryAuditLog(ctx context.Context, arg QueryAuditLogParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, queryAuditLog,
+		arg.Since,
+		arg.Until,
+		arg.ActorId,
+		arg.EventType,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.EventType,
+			&i.TargetID,
+			&i.Peer,
+			&i.Method,
+			&i.Payload,
+			&i.CreatedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_failures (subject, failures, failed_on, locked_until)
+VALUES ($1, 1, $2, $2)
+ON CONFLICT (subject) DO UPDATE
+SET failures = CASE WHEN login_failures.failed_on > $3 THEN login_failures.failures + 1 ELSE 1 END,
+    failed_on = EXCLUDED.failed_on
+RETURNING failures
+`
+
+type RecordLoginFailureParams struct {
+	Subject     string
+	FailedOn    pgtype.Timestamptz
+	WindowStart pgtype.Timestamptz
+}
+
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (int32, error) {
+	row := q.db.QueryRow(ctx, recordLoginFailure, arg.Subject, arg.FailedOn, arg.WindowStart)
+	var failures int32
+	err := row.Scan(&failures)
+	return failures, err
+}
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE player_id = $1 AND key = $2 AND response IS NULL
+`
+
+type ReleaseIdempotencyKeyParams struct {
+	PlayerID int64
+	Key      string
+}
+
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, releaseIdempotencyKey, arg.PlayerID, arg.Key)
+	return err
+}
+
+const requestTakeback = `-- name: RequestTakeback :execresult
+UPDATE games
+SET takeback_by = $1
+WHERE id = $2 AND takeback_by IS NULL
+`
+
+type RequestTakebackParams struct {
+	TakebackBy pgtype.Int8
+	ID         int64
+}
+
+func (q *Queries) RequestTakeback(ctx context.Context, arg RequestTakebackParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, requestTakeback, arg.TakebackBy, arg.ID)
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :execresult
+INSERT INTO idempotency_keys (player_id, key, method, request_hash, created_on, expires_on)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (player_id, key) DO UPDATE
+SET method = EXCLUDED.method,
+    request_hash = EXCLUDED.request_hash,
+    response_type = '',
+    response = NULL,
+    created_on = EXCLUDED.created_on,
+    expires_on = EXCLUDED.expires_on
+WHERE idempotency_keys.expires_on <= EXCLUDED.created_on
+`
+
+type ReserveIdempotencyKeyParams struct {
+	PlayerID    int64
+	Key         string
+	Method      string
+	RequestHash string
+	CreatedOn   pgtype.Timestamptz
+	ExpiresOn   pgtype.Timestamptz
+}
+
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, reserveIdempotencyKey,
+		arg.PlayerID,
+		arg.Key,
+		arg.Method,
+		arg.RequestHash,
+		arg.CreatedOn,
+		arg.ExpiresOn,
+	)
+}
+
+const rotateSession = `-- name: RotateSession :execresult
+UPDATE player_sessions
+SET token_hash = $1, last_seen_on = $2, expires_on = $3
+WHERE id = $4 AND token_hash = $5
+`
+
+type RotateSessionParams struct {
+	NewTokenHash string
+	LastSeenOn   pgtype.Timestamptz
+	ExpiresOn    pgtype.Timestamptz
+	ID           int64
+	TokenHash    string
+}
+
+func (q *Queries) RotateSession(ctx context.Context, arg RotateSessionParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, rotateSession,
+		arg.NewTokenHash,
+		arg.LastSeenOn,
+		arg.ExpiresOn,
+		arg.ID,
+		arg.TokenHash,
+	)
+}
+
+const setRole = `-- name: SetRole :one
+UPDATE player_accounts
+SET role = $2
+WHERE id = $1 AND deleted_on IS NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+type SetRoleParams struct {
+	ID   int64
+	Role int32
+}
+
+func (q *Queries) SetRole(ctx context.Context, arg SetRoleParams) (PlayerAccount, error) {
+	row := q.db.QueryRow(ctx, setRole, arg.ID, arg.Role)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
+const storeIdempotentResponse = `-- name: StoreIdempotentResponse :exec
+UPDATE idempotency_keys
+SET response_type = $3, response = $4
+WHERE player_id = $1 AND key = $2
+`
+
+type StoreIdempotentResponseParams struct {
+	PlayerID     int64
+	Key          string
+	ResponseType string
+	Response     []byte
+}
+
+func (q *Queries) StoreIdempotentResponse(ctx context.Context, arg StoreIdempotentResponseParams) error {
+	_, err := q.db.Exec(ctx, storeIdempotentResponse,
+		arg.PlayerID,
+		arg.Key,
+		arg.ResponseType,
+		arg.Response,
+	)
+	return err
+}
+
+const unbanPlayer = `-- name: UnbanPlayer :one
+UPDATE player_accounts
+SET banned_on = NULL, ban_reason = ''
+WHERE id = $1 AND banned_on IS NOT NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+func (q *Queries) UnbanPlayer(ctx context.Context, id int64) (PlayerAccount, error) {
+	row := q.db.QueryRow(ctx, unbanPlayer, id)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
 const updateGame = `-- name: UpdateGame :execresult
 UPDATE games 
-SET board_state = $1, x_turn = $2, updated_on = $3, result = $4
-WHERE id = $5
+SET board_state = $1,
+    x_turn = $2,
+    updated_on = $3,
+    result = $4,
+    opening = COALESCE($5, opening),
+    takeback_by = NULL,
+    version = version + 1
+WHERE id = $6 AND version = $7
 `
 
 type UpdateGameParams struct {
@@ -423,7 +1838,9 @@ type UpdateGameParams struct {
 	XTurn      pgtype.Bool
 	UpdatedOn  pgtype.Timestamptz
 	Result     int32
+	Opening    pgtype.Int4
 	ID         int64
+	Version    int32
 }
 
 func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (pgconn.CommandTag, error) {
@@ -432,6 +1849,98 @@ func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (pgconn.
 		arg.XTurn,
 		arg.UpdatedOn,
 		arg.Result,
+		arg.Opening,
 		arg.ID,
+		arg.Version,
 	)
 }
+
+const updatePassword = `-- name: UpdatePassword :execresult
+UPDATE player_accounts
+SET passwd = $2
+WHERE id = $1 AND deleted_on IS NULL
+`
+
+type UpdatePasswordParams struct {
+	ID     int64
+	Passwd string
+}
+
+func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updatePassword, arg.ID, arg.Passwd)
+}
+
+const updatePuzzleAttempt = `-- name: UpdatePuzzleAttempt :execresult
+UPDATE puzzle_attempts
+SET board_state = $1, x_turn = $2, moves_left = $3, status = $4, updated_on = $5,
+    completed_on = $6
+WHERE puzzle_id = $7 AND player_id = $8 AND board_state = $9
+`
+
+type UpdatePuzzleAttemptParams struct {
+	BoardState     string
+	XTurn          bool
+	MovesLeft      int32
+	Status         int32
+	UpdatedOn      pgtype.Timestamptz
+	CompletedOn    pgtype.Timestamptz
+	PuzzleID       int64
+	PlayerID       int64
+	PrevBoardState string
+}
+
+func (q *Queries) UpdatePuzzleAttempt(ctx context.Context, arg UpdatePuzzleAttemptParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updatePuzzleAttempt,
+		arg.BoardState,
+		arg.XTurn,
+		arg.MovesLeft,
+		arg.Status,
+		arg.UpdatedOn,
+		arg.CompletedOn,
+		arg.PuzzleID,
+		arg.PlayerID,
+		arg.PrevBoardState,
+	)
+}
+
+const updatePuzzleStreak = `-- name: UpdatePuzzleStreak :one
+UPDATE player_accounts
+SET puzzle_streak = CASE WHEN $1::BOOLEAN THEN puzzle_streak + 1 ELSE 0 END,
+    best_puzzle_streak = GREATEST(best_puzzle_streak, CASE WHEN $1::BOOLEAN THEN puzzle_streak + 1 ELSE 0 END)
+WHERE id = $2
+RETURNING puzzle_streak, best_puzzle_streak
+`
+
+type UpdatePuzzleStreakParams struct {
+	Solved bool
+	ID     int64
+}
+
+type UpdatePuzzleStreakRow struct {
+	PuzzleStreak     int32
+	BestPuzzleStreak int32
+}
+
+func (q *Queries) UpdatePuzzleStreak(ctx context.Context, arg UpdatePuzzleStreakParams) (UpdatePuzzleStreakRow, error) {
+	row := q.db.QueryRow(ctx, updatePuzzleStreak, arg.Solved, arg.ID)
+	var i UpdatePuzzleStreakRow
+	err := row.Scan(&i.PuzzleStreak, &i.BestPuzzleStreak)
+	return i, err
+}
+
+const useHint = `-- name: UseHint :execresult
+UPDATE games
+SET x_hints_used = x_hints_used + CASE WHEN $1::BOOLEAN THEN 1 ELSE 0 END,
+    o_hints_used = o_hints_used + CASE WHEN $1::BOOLEAN THEN 0 ELSE 1 END
+WHERE id = $2
+    AND CASE WHEN $1::BOOLEAN THEN x_hints_used ELSE o_hints_used END < hint_budget
+`
+
+type UseHintParams struct {
+	XTurn bool
+	ID    int64
+}
+
+func (q *Queries) UseHint(ctx context.Context, arg UseHintParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, useHint, arg.XTurn, arg.ID)
+}
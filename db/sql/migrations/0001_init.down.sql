@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS game_steps;
+DROP TABLE IF EXISTS games;
+DROP TABLE IF EXISTS player_sessions;
+DROP TABLE IF EXISTS player_accounts;
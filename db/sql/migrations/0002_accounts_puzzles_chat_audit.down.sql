@@ -0,0 +1,44 @@
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS reject_audit_change();
+
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS login_failures;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS game_messages;
+DROP TABLE IF EXISTS daily_puzzles;
+DROP TABLE IF EXISTS puzzle_attempts;
+DROP TABLE IF EXISTS puzzles;
+DROP TABLE IF EXISTS analyses;
+
+DROP INDEX IF EXISTS games_rematch_of;
+DROP INDEX IF EXISTS games_opening;
+ALTER TABLE games
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS ended_by,
+    DROP COLUMN IF EXISTS rematch_by,
+    DROP COLUMN IF EXISTS rematch_of,
+    DROP COLUMN IF EXISTS takeback_by,
+    DROP COLUMN IF EXISTS takebacks_disabled,
+    DROP COLUMN IF EXISTS opening,
+    DROP COLUMN IF EXISTS o_hints_used,
+    DROP COLUMN IF EXISTS x_hints_used,
+    DROP COLUMN IF EXISTS hint_budget,
+    DROP COLUMN IF EXISTS start_state;
+
+-- hashed tokens can not be turned back into tokens, so the sessions are ended
+DROP TABLE IF EXISTS player_sessions;
+CREATE TABLE player_sessions (
+    token TEXT NOT NULL,
+    player_id BIGINT NOT NULL REFERENCES player_accounts(id),
+    PRIMARY KEY(token)
+);
+CREATE INDEX player_sessions_id ON player_sessions(player_id);
+
+ALTER TABLE player_accounts
+    DROP COLUMN IF EXISTS ban_reason,
+    DROP COLUMN IF EXISTS banned_on,
+    DROP COLUMN IF EXISTS registered_on,
+    DROP COLUMN IF EXISTS role,
+    DROP COLUMN IF EXISTS deleted_on,
+    DROP COLUMN IF EXISTS best_puzzle_streak,
+    DROP COLUMN IF EXISTS puzzle_streak;
@@ -0,0 +1,161 @@
+-- databases adopted at 0001_init may have been created from a later schema, so every change is skipped where
+-- it has been made already
+
+ALTER TABLE player_accounts
+    ADD COLUMN IF NOT EXISTS puzzle_streak INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS best_puzzle_streak INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS deleted_on TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS role INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS registered_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    ADD COLUMN IF NOT EXISTS banned_on TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS ban_reason TEXT DEFAULT '' NOT NULL;
+
+ALTER TABLE player_sessions
+    ADD COLUMN IF NOT EXISTS id BIGINT GENERATED ALWAYS AS IDENTITY NOT NULL UNIQUE,
+    ADD COLUMN IF NOT EXISTS token_hash TEXT,
+    ADD COLUMN IF NOT EXISTS device TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN IF NOT EXISTS created_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    ADD COLUMN IF NOT EXISTS last_seen_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    ADD COLUMN IF NOT EXISTS expires_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP + INTERVAL '30 days' NOT NULL;
+
+-- sessions used to be keyed by the token itself, it is hashed the way HashToken does so the sessions carry
+-- over
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema()
+               AND table_name = 'player_sessions' AND column_name = 'token') THEN
+        UPDATE player_sessions SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+    END IF;
+END;
+$$;
+
+ALTER TABLE player_sessions
+    DROP COLUMN IF EXISTS token,
+    ALTER COLUMN token_hash SET NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = current_schema()
+                   AND table_name = 'player_sessions' AND constraint_type = 'PRIMARY KEY') THEN
+        ALTER TABLE player_sessions ADD PRIMARY KEY (token_hash);
+    END IF;
+END;
+$$;
+
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS start_state TEXT DEFAULT '_________' NOT NULL,
+    ADD COLUMN IF NOT EXISTS hint_budget INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS x_hints_used INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS o_hints_used INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS opening INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS takebacks_disabled BOOLEAN DEFAULT FALSE NOT NULL,
+    ADD COLUMN IF NOT EXISTS takeback_by BIGINT REFERENCES player_accounts(id),
+    ADD COLUMN IF NOT EXISTS rematch_of BIGINT REFERENCES games(id),
+    ADD COLUMN IF NOT EXISTS rematch_by BIGINT REFERENCES player_accounts(id),
+    ADD COLUMN IF NOT EXISTS ended_by BIGINT REFERENCES player_accounts(id),
+    ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 0 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS analyses (
+    game_id BIGINT NOT NULL REFERENCES games(id),
+    ord INTEGER NOT NULL,
+    move_row INTEGER NOT NULL,
+    move_col INTEGER NOT NULL,
+    x_moved BOOLEAN NOT NULL,
+    best_result INTEGER NOT NULL,
+    best_distance INTEGER NOT NULL,
+    played_result INTEGER NOT NULL,
+    played_distance INTEGER NOT NULL,
+    annotation INTEGER NOT NULL,
+    analyzed_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(game_id, ord)
+);
+
+CREATE TABLE IF NOT EXISTS puzzles (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    board_state TEXT NOT NULL,
+    x_turn BOOLEAN NOT NULL,
+    depth INTEGER NOT NULL,
+    solution_row INTEGER NOT NULL,
+    solution_col INTEGER NOT NULL,
+    difficulty INTEGER DEFAULT 0 NOT NULL,
+    created_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS puzzle_attempts (
+    puzzle_id BIGINT NOT NULL REFERENCES puzzles(id),
+    player_id BIGINT NOT NULL REFERENCES player_accounts(id),
+    board_state TEXT NOT NULL,
+    x_turn BOOLEAN NOT NULL,
+    moves_left INTEGER NOT NULL,
+    status INTEGER DEFAULT 0 NOT NULL,
+    started_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_on TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY(puzzle_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS daily_puzzles (
+    day DATE NOT NULL,
+    puzzle_id BIGINT NOT NULL REFERENCES puzzles(id),
+    PRIMARY KEY(day)
+);
+
+CREATE TABLE IF NOT EXISTS game_messages (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id),
+    player_id BIGINT NOT NULL REFERENCES player_accounts(id),
+    channel INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    sent_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    actor_id BIGINT REFERENCES player_accounts(id),
+    event_type TEXT NOT NULL,
+    target_id BIGINT,
+    peer TEXT DEFAULT '' NOT NULL,
+    method TEXT DEFAULT '' NOT NULL,
+    payload JSONB DEFAULT '{}' NOT NULL,
+    created_on TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    subject TEXT NOT NULL,
+    failures INTEGER NOT NULL,
+    failed_on TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY(subject)
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    player_id BIGINT NOT NULL REFERENCES player_accounts(id),
+    key TEXT NOT NULL,
+    method TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    response_type TEXT DEFAULT '' NOT NULL,
+    response BYTEA,
+    created_on TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_on TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY(player_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS player_sessions_id ON player_sessions(player_id);
+CREATE INDEX IF NOT EXISTS player_sessions_expires ON player_sessions(expires_on);
+CREATE INDEX IF NOT EXISTS games_opening ON games(opening);
+CREATE UNIQUE INDEX IF NOT EXISTS games_rematch_of ON games(rematch_of);
+CREATE INDEX IF NOT EXISTS game_messages_game ON game_messages(game_id, channel, id);
+CREATE INDEX IF NOT EXISTS game_messages_player ON game_messages(player_id, sent_on);
+CREATE INDEX IF NOT EXISTS audit_events_actor ON audit_events(actor_id, id);
+CREATE INDEX IF NOT EXISTS audit_events_created ON audit_events(created_on);
+
+-- the audit log is append-only, rows can be added but never changed or removed
+CREATE OR REPLACE FUNCTION reject_audit_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW EXECUTE FUNCTION reject_audit_change();
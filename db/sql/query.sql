@@ -4,12 +4,25 @@ SELECT
     g.x_player,
     g.o_player,
     g.board_state,
+    g.start_state,
     g.x_turn,
     g.updated_on,
     g.started_on,
     g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
+    g.version,
     a1.username as x_player_name,
-    a2.username as o_player_name
+    a2.username as o_player_name,
+    (a1.deleted_on IS NOT NULL)::BOOLEAN as x_player_deleted,
+    (a2.deleted_on IS NOT NULL)::BOOLEAN as o_player_deleted
 FROM games g
 LEFT JOIN player_accounts a1 ON a1.id = g.x_player
 LEFT JOIN player_accounts a2 ON a2.id = g.o_player
@@ -21,12 +34,24 @@ SELECT
     g.x_player,
     g.o_player,
     g.board_state,
+    g.start_state,
     g.x_turn,
     g.updated_on,
     g.started_on,
     g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
     a1.username as x_player_name,
-    a2.username as o_player_name
+    a2.username as o_player_name,
+    (a1.deleted_on IS NOT NULL)::BOOLEAN as x_player_deleted,
+    (a2.deleted_on IS NOT NULL)::BOOLEAN as o_player_deleted
 FROM games g
 LEFT JOIN player_accounts a1 ON a1.id = g.x_player
 LEFT JOIN player_accounts a2 ON a2.id = g.o_player
@@ -35,15 +60,80 @@ WHERE g.id > sqlc.arg('id')
     AND (g.o_player = sqlc.narg('oPlayer') OR sqlc.narg('oPlayer') IS NULL)
 ORDER BY g.id ASC LIMIT sqlc.arg('limit');
 
+-- name: GetGamePositions :many
+SELECT id, board_state, x_turn, result, ended_by FROM games
+WHERE id > $1
+ORDER BY id ASC LIMIT $2;
+
 -- name: InsertGame :one
-INSERT INTO games (x_player, o_player, board_state, x_turn, updated_on, started_on)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO games (x_player, o_player, board_state, start_state, x_turn, updated_on, started_on, hint_budget, takebacks_disabled, rematch_of)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id;
 
 -- name: UpdateGame :execresult
 UPDATE games 
-SET board_state = $1, x_turn = $2, updated_on = $3, result = $4
-WHERE id = $5;
+SET board_state = sqlc.arg('board_state'),
+    x_turn = sqlc.arg('x_turn'),
+    updated_on = sqlc.arg('updated_on'),
+    result = sqlc.arg('result'),
+    opening = COALESCE(sqlc.narg('opening'), opening),
+    takeback_by = NULL,
+    version = version + 1
+WHERE id = sqlc.arg('id') AND version = sqlc.arg('version');
+
+-- name: RequestTakeback :execresult
+UPDATE games
+SET takeback_by = $1
+WHERE id = $2 AND takeback_by IS NULL;
+
+-- name: OfferRematch :execresult
+UPDATE games
+SET rematch_by = $1
+WHERE id = $2 AND rematch_by IS NULL;
+
+-- name: ClearRematch :execresult
+UPDATE games
+SET rematch_by = NULL
+WHERE id = $1 AND rematch_by = $2;
+
+-- name: GetRematch :one
+SELECT id FROM games
+WHERE rematch_of = $1;
+
+-- name: GetSeries :many
+WITH RECURSIVE series AS (
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    WHERE g.id = $1
+    UNION ALL
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    INNER JOIN series s ON g.id = s.rematch_of
+)
+SELECT id, x_player, o_player, result FROM series
+ORDER BY id;
+
+-- name: ClearTakeback :execresult
+UPDATE games
+SET takeback_by = NULL
+WHERE id = $1 AND takeback_by = $2;
+
+-- name: GetOpeningStats :many
+SELECT
+    g.opening,
+    g.result,
+    COALESCE(g.x_player = sqlc.narg('player'), FALSE)::BOOLEAN as player_is_x,
+    COUNT(*) as games
+FROM games g
+WHERE g.opening <> 0
+    AND (g.x_player = sqlc.narg('player') OR g.o_player = sqlc.narg('player') OR sqlc.narg('player') IS NULL)
+GROUP BY g.opening, g.result, player_is_x
+ORDER BY g.opening, g.result;
+
+-- name: UseHint :execresult
+UPDATE games
+SET x_hints_used = x_hints_used + CASE WHEN sqlc.arg('xTurn')::BOOLEAN THEN 1 ELSE 0 END,
+    o_hints_used = o_hints_used + CASE WHEN sqlc.arg('xTurn')::BOOLEAN THEN 0 ELSE 1 END
+WHERE id = sqlc.arg('id')
+    AND CASE WHEN sqlc.arg('xTurn')::BOOLEAN THEN x_hints_used ELSE o_hints_used END < hint_budget;
 
 -- name: GetGamesSteps :many
 SELECT * FROM game_steps 
@@ -60,33 +150,285 @@ INSERT INTO game_steps (game_id, move_row, move_col, board, x_turn, result, ord)
 VALUES ($1, $2, $3, $4, $5, $6,
         COALESCE((SELECT ord FROM game_steps WHERE game_id = $1 ORDER BY ord DESC LIMIT 1), -1) + 1);
 
+-- name: DeleteStepsFrom :execresult
+DELETE FROM game_steps
+WHERE game_id = $1 AND ord >= $2;
+
 -- name: GetLastStep :one
 SELECT * FROM game_steps
 WHERE game_id = $1
 ORDER BY ord DESC LIMIT 1;
 
 -- name: GetPlayers :many
-SELECT id, username, (SELECT COUNT(*) FROM player_sessions s WHERE s.player_id = a.id) as cnt
+SELECT a.id, a.username, (SELECT COUNT(*) FROM player_sessions s WHERE s.player_id = a.id AND s.expires_on > CURRENT_TIMESTAMP) as cnt
 FROM player_accounts a
-WHERE id > $1
-ORDER BY id ASC LIMIT $2;
+WHERE a.id > $1 AND a.deleted_on IS NULL
+ORDER BY a.id ASC LIMIT $2;
 
 -- name: InsertPlayer :one
 INSERT INTO player_accounts (username, passwd, salt)
 VALUES ($1, $2, $3)
 RETURNING id, username;
 
--- name: InsertSession :execresult
-INSERT INTO player_sessions (token, player_id)
-VALUES ($1, $2);
+-- name: InsertSession :one
+INSERT INTO player_sessions (token_hash, player_id, device, created_on, last_seen_on, expires_on)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
 
 -- name: GetSession :one
-SELECT a.id, a.username FROM player_sessions s
+SELECT a.id, a.username, s.id as session_id FROM player_sessions s
 INNER JOIN player_accounts a ON a.id = s.player_id
-WHERE token = $1;
+WHERE s.token_hash = $1 AND s.expires_on > CURRENT_TIMESTAMP AND a.banned_on IS NULL;
+
+-- name: RotateSession :execresult
+UPDATE player_sessions
+SET token_hash = sqlc.arg('new_token_hash'), last_seen_on = sqlc.arg('last_seen_on'), expires_on = sqlc.arg('expires_on')
+WHERE id = sqlc.arg('id') AND token_hash = sqlc.arg('token_hash');
+
+-- name: GetPlayerSessions :many
+SELECT id, device, created_on, last_seen_on, expires_on FROM player_sessions
+WHERE player_id = $1 AND expires_on > CURRENT_TIMESTAMP
+ORDER BY last_seen_on DESC;
+
+-- name: DeleteSession :execresult
+DELETE FROM player_sessions
+WHERE id = $1;
+
+-- name: DeletePlayerSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = $1;
+
+-- name: DeleteOtherSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = $1 AND id <> $2;
+
+-- name: PurgeExpiredSessions :execresult
+DELETE FROM player_sessions
+WHERE expires_on <= $1;
+
+-- name: GetLoginLockout :one
+SELECT MAX(locked_until)::TIMESTAMPTZ as locked_until FROM login_failures
+WHERE subject = ANY (sqlc.arg('subjects')::TEXT[]);
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_failures (subject, failures, failed_on, locked_until)
+VALUES (sqlc.arg('subject'), 1, sqlc.arg('failed_on'), sqlc.arg('failed_on'))
+ON CONFLICT (subject) DO UPDATE
+SET failures = CASE WHEN login_failures.failed_on > sqlc.arg('window_start') THEN login_failures.failures + 1 ELSE 1 END,
+    failed_on = EXCLUDED.failed_on
+RETURNING failures;
+
+-- name: LockLogin :exec
+UPDATE login_failures
+SET locked_until = $2
+WHERE subject = $1;
+
+-- name: ClearLoginFailures :exec
+DELETE FROM login_failures
+WHERE subject = $1;
+
+-- name: PurgeLoginFailures :execresult
+DELETE FROM login_failures
+WHERE failed_on <= sqlc.arg('window_start') AND locked_until <= sqlc.arg('now');
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE player_id = $1 AND key = $2 AND expires_on > $3;
+
+-- name: ReserveIdempotencyKey :execresult
+INSERT INTO idempotency_keys (player_id, key, method, request_hash, created_on, expires_on)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (player_id, key) DO UPDATE
+SET method = EXCLUDED.method,
+    request_hash = EXCLUDED.request_hash,
+    response_type = '',
+    response = NULL,
+    created_on = EXCLUDED.created_on,
+    expires_on = EXCLUDED.expires_on
+WHERE idempotency_keys.expires_on <= EXCLUDED.created_on;
+
+-- name: StoreIdempotentResponse :exec
+UPDATE idempotency_keys
+SET response_type = $3, response = $4
+WHERE player_id = $1 AND key = $2;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE player_id = $1 AND key = $2 AND response IS NULL;
+
+-- name: PurgeIdempotencyKeys :execresult
+DELETE FROM idempotency_keys
+WHERE expires_on <= $1;
 
 -- name: GetPlayer :one
 SELECT id, username FROM player_accounts WHERE id = $1;
 
 -- name: GetAccountByName :one
-SELECT id, username, passwd FROM player_accounts WHERE UPPER(username) = UPPER($1);
\ No newline at end of file
+SELECT id, username, passwd, banned_on FROM player_accounts WHERE UPPER(username) = UPPER($1) AND deleted_on IS NULL;
+
+-- name: GetAccount :one
+SELECT id, username, passwd FROM player_accounts WHERE id = $1 AND deleted_on IS NULL;
+
+-- name: UpdatePassword :execresult
+UPDATE player_accounts
+SET passwd = $2
+WHERE id = $1 AND deleted_on IS NULL;
+
+-- name: AnonymizePlayer :execresult
+UPDATE player_accounts
+SET username = 'deleted player ' || LPAD(id::TEXT, 19, '0'), passwd = '', salt = '', deleted_on = $2
+WHERE id = $1 AND deleted_on IS NULL;
+
+-- name: GetAnalyses :many
+SELECT * FROM analyses
+WHERE game_id = $1
+ORDER BY ord;
+
+-- name: InsertAnalysis :execresult
+INSERT INTO analyses (game_id, ord, move_row, move_col, x_moved, best_result, best_distance, played_result, played_distance, annotation)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (game_id, ord) DO NOTHING;
+
+-- name: GetPuzzle :one
+SELECT * FROM puzzles WHERE id = $1;
+
+-- name: InsertPuzzle :one
+INSERT INTO puzzles (board_state, x_turn, depth, solution_row, solution_col, difficulty)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: GetPuzzleAttempt :one
+SELECT * FROM puzzle_attempts
+WHERE puzzle_id = $1 AND player_id = $2;
+
+-- name: InsertPuzzleAttempt :execresult
+INSERT INTO puzzle_attempts (puzzle_id, player_id, board_state, x_turn, moves_left)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (puzzle_id, player_id) DO NOTHING;
+
+-- name: UpdatePuzzleAttempt :execresult
+UPDATE puzzle_attempts
+SET board_state = sqlc.arg('boardState'), x_turn = sqlc.arg('xTurn'), moves_left = sqlc.arg('movesLeft'), status = sqlc.arg('status'), updated_on = sqlc.arg('updatedOn'),
+    completed_on = sqlc.narg('completedOn')
+WHERE puzzle_id = sqlc.arg('puzzle_id') AND player_id = sqlc.arg('player_id') AND board_state = sqlc.arg('prev_board_state');
+
+-- name: GetPuzzleStreak :one
+SELECT puzzle_streak, best_puzzle_streak FROM player_accounts WHERE id = $1;
+
+-- name: UpdatePuzzleStreak :one
+UPDATE player_accounts
+SET puzzle_streak = CASE WHEN sqlc.arg('solved')::BOOLEAN THEN puzzle_streak + 1 ELSE 0 END,
+    best_puzzle_streak = GREATEST(best_puzzle_streak, CASE WHEN sqlc.arg('solved')::BOOLEAN THEN puzzle_streak + 1 ELSE 0 END)
+WHERE id = sqlc.arg('id')
+RETURNING puzzle_streak, best_puzzle_streak;
+
+-- name: GetDailyPuzzle :one
+SELECT p.* FROM daily_puzzles d
+INNER JOIN puzzles p ON p.id = d.puzzle_id
+WHERE d.day = $1;
+
+-- name: InsertDailyPuzzle :execresult
+INSERT INTO daily_puzzles (day, puzzle_id)
+VALUES ($1, $2)
+ON CONFLICT (day) DO NOTHING;
+
+-- name: GetDailyLeaderboard :many
+SELECT a.player_id, p.username, a.started_on, a.completed_on
+FROM daily_puzzles d
+INNER JOIN puzzle_attempts a ON a.puzzle_id = d.puzzle_id
+INNER JOIN player_accounts p ON p.id = a.player_id
+WHERE d.day = sqlc.arg('day') AND a.status = sqlc.arg('status')
+ORDER BY a.completed_on - a.started_on ASC, a.player_id ASC
+LIMIT sqlc.arg('limit');
+
+-- name: InsertMessage :one
+INSERT INTO game_messages (game_id, player_id, channel, text, sent_on)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: GetGameMessages :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = $1
+ORDER BY m.id;
+
+-- name: GetMessage :one
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.id = $1;
+
+-- name: DeleteMessage :execresult
+DELETE FROM game_messages
+WHERE id = $1;
+
+-- name: GetMessagesAfter :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = $1 AND m.channel = $2 AND m.id > $3
+ORDER BY m.id LIMIT $4;
+
+-- name: LockPlayer :one
+SELECT id FROM player_accounts
+WHERE id = $1
+FOR NO KEY UPDATE;
+
+-- name: CountRecentMessages :one
+SELECT COUNT(*) FROM game_messages
+WHERE player_id = $1 AND sent_on > $2;
+
+-- name: GetPlayerRole :one
+SELECT role, banned_on FROM player_accounts
+WHERE id = $1 AND deleted_on IS NULL;
+
+-- name: SetRole :one
+UPDATE player_accounts
+SET role = $2
+WHERE id = $1 AND deleted_on IS NULL
+RETURNING *;
+
+-- name: BanPlayer :one
+UPDATE player_accounts
+SET banned_on = $2, ban_reason = $3
+WHERE id = $1 AND deleted_on IS NULL AND banned_on IS NULL
+RETURNING *;
+
+-- name: UnbanPlayer :one
+UPDATE player_accounts
+SET banned_on = NULL, ban_reason = ''
+WHERE id = $1 AND banned_on IS NOT NULL
+RETURNING *;
+
+-- name: ForceEndGame :execresult
+UPDATE games
+SET result = $2, updated_on = $3, ended_by = $4, takeback_by = NULL, rematch_by = NULL, version = version + 1
+WHERE id = $1 AND result = 0;
+
+-- name: GetRegistrations :many
+SELECT * FROM player_accounts
+WHERE deleted_on IS NULL AND registered_on >= sqlc.arg('since')
+ORDER BY id DESC LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetServerStats :one
+SELECT
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.deleted_on IS NULL) as players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.banned_on IS NOT NULL) as banned_players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.registered_on >= sqlc.arg('since')::TIMESTAMPTZ) as registrations,
+    (SELECT COUNT(*) FROM player_sessions s WHERE s.expires_on > CURRENT_TIMESTAMP) as sessions,
+    (SELECT COUNT(*) FROM games g) as games,
+    (SELECT COUNT(*) FROM games g WHERE g.result = 0) as active_games,
+    (SELECT COUNT(*) FROM game_messages m) as messages;
+
+-- name: InsertAuditEvent :one
+INSERT INTO audit_events (actor_id, event_type, target_id, peer, method, payload, created_on)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: QueryAuditLog :many
+SELECT * FROM audit_events
+WHERE created_on >= sqlc.arg('since') AND created_on < sqlc.arg('until')
+    AND (actor_id = sqlc.narg('actorId') OR sqlc.narg('actorId') IS NULL)
+    AND (event_type = sqlc.narg('eventType') OR sqlc.narg('eventType') IS NULL)
+ORDER BY id DESC LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
\ No newline at end of file
@@ -1,11 +1,11 @@
 INSERT INTO player_accounts (username, passwd, salt) VALUES ('user1', 'password123', 'test');
 INSERT INTO player_accounts (username, passwd, salt) VALUES ('user2', 'password123', 'test');
 INSERT INTO player_accounts (username, passwd, salt) VALUES ('user3', 'password123', 'test');
-INSERT INTO player_accounts (username, passwd, salt) VALUES ('user4', 'password123', 'test');
-INSERT INTO player_accounts (username, passwd, salt) VALUES ('user5', 'password123', 'test');
+INSERT INTO player_accounts (username, passwd, salt, role) VALUES ('user4', 'password123', 'test', 1);
+INSERT INTO player_accounts (username, passwd, salt, role) VALUES ('user5', 'password123', 'test', 2);
 
-INSERT INTO player_sessions (token, player_id) VALUES ('User1Token', 1);
-INSERT INTO player_sessions (token, player_id) VALUES ('User3Token', 3);
+INSERT INTO player_sessions (token_hash, player_id) VALUES ('50a39151b3ca9e41506c1350df9aa22c773bcdf00863f91782c628ea5d0357d6', 1);
+INSERT INTO player_sessions (token_hash, player_id) VALUES ('402f295ba942d2569068177f54ec33459577ed95e168570333a0a43365856f73', 3);
 
 INSERT INTO games (x_player, o_player, board_state, x_turn, result)
 VALUES (1, 2, 'x_o______', true, 0);
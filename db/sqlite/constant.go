@@ -0,0 +1,6 @@
+package sqlite
+
+import _ "embed"
+
+//go:embed sql/schema.sql
+var CreateSchema string
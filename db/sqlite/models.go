@@ -0,0 +1,147 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Analysis struct {
+	GameID         int64
+	Ord            int64
+	MoveRow        int64
+	MoveCol        int64
+	XMoved         bool
+	BestResult     int64
+	BestDistance   int64
+	PlayedResult   int64
+	PlayedDistance int64
+	Annotation     int64
+	AnalyzedOn     time.Time
+}
+
+type AuditEvent struct {
+	ID        int64
+	ActorID   sql.NullInt64
+	EventType string
+	TargetID  sql.NullInt64
+	Peer      string
+	Method    string
+	Payload   string
+	CreatedOn time.Time
+}
+
+type DailyPuzzle struct {
+	Day      time.Time
+	PuzzleID int64
+}
+
+type Game struct {
+	ID                int64
+	XPlayer           int64
+	OPlayer           sql.NullInt64
+	BoardState        string
+	StartState        string
+	XTurn             sql.NullBool
+	UpdatedOn         time.Time
+	StartedOn         time.Time
+	Result            int64
+	HintBudget        int64
+	XHintsUsed        int64
+	OHintsUsed        int64
+	Opening           int64
+	TakebacksDisabled bool
+	TakebackBy        sql.NullInt64
+	RematchOf         sql.NullInt64
+	RematchBy         sql.NullInt64
+	EndedBy           sql.NullInt64
+	Version           int64
+}
+
+type GameMessage struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int64
+	Text     string
+	SentOn   time.Time
+}
+
+type GameStep struct {
+	GameID  int64
+	Ord     int64
+	MoveRow int64
+	MoveCol int64
+	Board   string
+	XTurn   bool
+	Result  int64
+	MadeOn  time.Time
+}
+
+type IdempotencyKey struct {
+	PlayerID     int64
+	Key          string
+	Method       string
+	RequestHash  string
+	ResponseType string
+	Response     []byte
+	CreatedOn    time.Time
+	ExpiresOn    time.Time
+}
+
+type LoginFailure struct {
+	Subject     string
+	Failures    int64
+	FailedOn    time.Time
+	LockedUntil time.Time
+}
+
+type PlayerAccount struct {
+	ID               int64
+	Username         string
+	Passwd           string
+	Salt             string
+	PuzzleStreak     int64
+	BestPuzzleStreak int64
+	DeletedOn        sql.NullTime
+	Role             int64
+	RegisteredOn     time.Time
+	BannedOn         sql.NullTime
+	BanReason        string
+}
+
+type PlayerSession struct {
+	ID         int64
+	TokenHash  string
+	PlayerID   int64
+	Device     string
+	CreatedOn  time.Time
+	LastSeenOn time.Time
+	ExpiresOn  time.Time
+}
+
+type Puzzle struct {
+	ID          int64
+	BoardState  string
+	XTurn       bool
+	Depth       int64
+	SolutionRow int64
+	SolutionCol int64
+	Difficulty  int64
+	CreatedOn   time.Time
+}
+
+type PuzzleAttempt struct {
+	PuzzleID    int64
+	PlayerID    int64
+	BoardState  string
+	XTurn       bool
+	MovesLeft   int64
+	Status      int64
+	StartedOn   time.Time
+	UpdatedOn   time.Time
+	CompletedOn sql.NullTime
+}
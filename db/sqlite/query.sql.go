@@ -0,0 +1,2054 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: query.sql
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const anonymizePlayer = `-- name: AnonymizePlayer :execresult
+UPDATE player_accounts
+SET username = 'deleted player ' || printf('%019d', id), passwd = '', salt = '', deleted_on = ?
+WHERE id = ? AND deleted_on IS NULL
+`
+
+type AnonymizePlayerParams struct {
+	DeletedOn sql.NullTime
+	ID        int64
+}
+
+func (q *Queries) AnonymizePlayer(ctx context.Context, arg AnonymizePlayerParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, anonymizePlayer, arg.DeletedOn, arg.ID)
+}
+
+const banPlayer = `-- name: BanPlayer :one
+UPDATE player_accounts
+SET banned_on = ?1, ban_reason = ?2
+WHERE id = ?3 AND deleted_on IS NULL AND banned_on IS NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+type BanPlayerParams struct {
+	BannedOn  sql.NullTime
+	BanReason string
+	ID        int64
+}
+
+func (q *Queries) BanPlayer(ctx context.Context, arg BanPlayerParams) (PlayerAccount, error) {
+	row := q.db.QueryRowContext(ctx, banPlayer, arg.BannedOn, arg.BanReason, arg.ID)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
+const clearLoginFailures = `-- name: ClearLoginFailures :exec
+DELETE FROM login_failures
+WHERE subject = ?
+`
+
+func (q *Queries) ClearLoginFailures(ctx context.Context, subject string) error {
+	_, err := q.db.ExecContext(ctx, clearLoginFailures, subject)
+	return err
+}
+
+const clearRematch = `-- name: ClearRematch :execresult
+UPDATE games
+SET rematch_by = NULL
+WHERE id = ? AND rematch_by = ?
+`
+
+type ClearRematchParams struct {
+	ID        int64
+	RematchBy sql.NullInt64
+}
+
+func (q *Queries) ClearRematch(ctx context.Context, arg ClearRematchParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, clearRematch, arg.ID, arg.RematchBy)
+}
+
+const clearTakeback = `-- name: ClearTakeback :execresult
+UPDATE games
+SET takeback_by = NULL
+WHERE id = ? AND takeback_by = ?
+`
+
+type ClearTakebackParams struct {
+	ID         int64
+	TakebackBy sql.NullInt64
+}
+
+func (q *Queries) ClearTakeback(ctx context.Context, arg ClearTakebackParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, clearTakeback, arg.ID, arg.TakebackBy)
+}
+
+const countRecentMessages = `-- name: CountRecentMessages :one
+SELECT COUNT(*) FROM game_messages
+WHERE player_id = ? AND sent_on > ?
+`
+
+type CountRecentMessagesParams struct {
+	PlayerID int64
+	SentOn   time.Time
+}
+
+func (q *Queries) CountRecentMessages(ctx context.Context, arg CountRecentMessagesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecentMessages, arg.PlayerID, arg.SentOn)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteMessage = `-- name: DeleteMessage :execresult
+DELETE FROM game_messages
+WHERE id = ?
+`
+
+func (q *Queries) DeleteMessage(ctx context.Context, id int64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteMessage, id)
+}
+
+const deleteOtherSessions = `-- name: DeleteOtherSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = ? AND id <> ?
+`
+
+type DeleteOtherSessionsParams struct {
+	PlayerID int64
+	ID       int64
+}
+
+func (q *Queries) DeleteOtherSessions(ctx context.Context, arg DeleteOtherSessionsParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteOtherSessions, arg.PlayerID, arg.ID)
+}
+
+const deletePlayerSessions = `-- name: DeletePlayerSessions :execresult
+DELETE FROM player_sessions
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerSessions(ctx context.Context, playerID int64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deletePlayerSessions, playerID)
+}
+
+const deleteSession = `-- name: DeleteSession :execresult
+DELETE FROM player_sessions
+WHERE id = ?
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, id int64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteSession, id)
+}
+
+const deleteStepsFrom = `-- name: DeleteStepsFrom :execresult
+DELETE FROM game_steps
+WHERE game_id = ? AND ord >= ?
+`
+
+type DeleteStepsFromParams struct {
+	GameID int64
+	Ord    int64
+}
+
+func (q *Queries) DeleteStepsFrom(ctx context.Context, arg DeleteStepsFromParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteStepsFrom, arg.GameID, arg.Ord)
+}
+
+const forceEndGame = `-- name: ForceEndGame :execresult
+UPDATE games
+SET result = ?1, updated_on = ?2, ended_by = ?3, takeback_by = NULL, rematch_by = NULL, version = version + 1
+WHERE id = ?4 AND result = 0
+`
+
+type ForceEndGameParams struct {
+	Result    int64
+	UpdatedOn time.Time
+	EndedBy   sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) ForceEndGame(ctx context.Context, arg ForceEndGameParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, forceEndGame,
+		arg.Result,
+		arg.UpdatedOn,
+		arg.EndedBy,
+		arg.ID,
+	)
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT id, username, passwd FROM player_accounts WHERE id = ? AND deleted_on IS NULL
+`
+
+type GetAccountRow struct {
+	ID       int64
+	Username string
+	Passwd   string
+}
+
+func (q *Queries) GetAccount(ctx context.Context, id int64) (GetAccountRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, id)
+	var i GetAccountRow
+	err := row.Scan(&i.ID, &i.Username, &i.Passwd)
+	return i, err
+}
+
+const getAccountByName = `-- name: GetAccountByName :one
+SELECT id, username, passwd, banned_on FROM player_accounts WHERE UPPER(username) = UPPER(?) AND deleted_on IS NULL
+`
+
+type GetAccountByNameRow struct {
+	ID       int64
+	Username string
+	Passwd   string
+	BannedOn sql.NullTime
+}
+
+func (q *Queries) GetAccountByName(ctx context.Context, upper string) (GetAccountByNameRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByName, upper)
+	var i GetAccountByNameRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.BannedOn,
+	)
+	return i, err
+}
+
+const getAnalyses = `-- name: GetAnalyses :many
+SELECT game_id, ord, move_row, move_col, x_moved, best_result, best_distance, played_result, played_distance, annotation, analyzed_on FROM analyses
+WHERE game_id = ?
+ORDER BY ord
+`
+
+func (q *Queries) GetAnalyses(ctx context.Context, gameID int64) ([]Analysis, error) {
+	rows, err := q.db.QueryContext(ctx, getAnalyses, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Analysis
+	for rows.Next() {
+		var i Analysis
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Ord,
+			&i.MoveRow,
+			&i.MoveCol,
+			&i.XMoved,
+			&i.BestResult,
+			&i.BestDistance,
+			&i.PlayedResult,
+			&i.PlayedDistance,
+			&i.Annotation,
+			&i.AnalyzedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyLeaderboard = `-- name: GetDailyLeaderboard :many
+SELECT a.player_id, p.username, a.started_on, a.completed_on
+FROM daily_puzzles d
+INNER JOIN puzzle_attempts a ON a.puzzle_id = d.puzzle_id
+INNER JOIN player_accounts p ON p.id = a.player_id
+WHERE d.day = ?1 AND a.status = ?2
+ORDER BY julianday(a.completed_on) - julianday(a.started_on) ASC, a.player_id ASC
+LIMIT ?3
+`
+
+type GetDailyLeaderboardParams struct {
+	Day    time.Time
+	Status int64
+	Limit  int64
+}
+
+type GetDailyLeaderboardRow struct {
+	PlayerID    int64
+	Username    string
+	StartedOn   time.Time
+	CompletedOn sql.NullTime
+}
+
+func (q *Queries) GetDailyLeaderboard(ctx context.Context, arg GetDailyLeaderboardParams) ([]GetDailyLeaderboardRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDailyLeaderboard, arg.Day, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDailyLeaderboardRow
+	for rows.Next() {
+		var i GetDailyLeaderboardRow
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.Username,
+			&i.StartedOn,
+			&i.CompletedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyPuzzle = `-- name: GetDailyPuzzle :one
+SELECT p.id, p.board_state, p.x_turn, p.depth, p.solution_row, p.solution_col, p.difficulty, p.created_on FROM daily_puzzles d
+INNER JOIN puzzles p ON p.id = d.puzzle_id
+WHERE d.day = ?
+`
+
+func (q *Queries) GetDailyPuzzle(ctx context.Context, day time.Time) (Puzzle, error) {
+	row := q.db.QueryRowContext(ctx, getDailyPuzzle, day)
+	var i Puzzle
+	err := row.Scan(
+		&i.ID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.Depth,
+		&i.SolutionRow,
+		&i.SolutionCol,
+		&i.Difficulty,
+		&i.CreatedOn,
+	)
+	return i, err
+}
+
+const getGame = `-- name: GetGame :one
+SELECT
+    g.id,
+    g.x_player,
+    g.o_player,
+    g.board_state,
+    g.start_state,
+    g.x_turn,
+    g.updated_on,
+    g.started_on,
+    g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
+    g.version,
+    a1.username as x_player_name,
+    a2.username as o_player_name,
+    CAST(a1.deleted_on IS NOT NULL AS BOOLEAN) as x_player_deleted,
+    CAST(a2.deleted_on IS NOT NULL AS BOOLEAN) as o_player_deleted
+FROM games g
+LEFT JOIN player_accounts a1 ON a1.id = g.x_player
+LEFT JOIN player_accounts a2 ON a2.id = g.o_player
+WHERE g.id = ?
+`
+
+type GetGameRow struct {
+	ID                int64
+	XPlayer           int64
+	OPlayer           sql.NullInt64
+	BoardState        string
+	StartState        string
+	XTurn             sql.NullBool
+	UpdatedOn         time.Time
+	StartedOn         time.Time
+	Result            int64
+	HintBudget        int64
+	XHintsUsed        int64
+	OHintsUsed        int64
+	Opening           int64
+	TakebacksDisabled bool
+	TakebackBy        sql.NullInt64
+	RematchOf         sql.NullInt64
+	RematchBy         sql.NullInt64
+	EndedBy           sql.NullInt64
+	Version           int64
+	XPlayerName       sql.NullString
+	OPlayerName       sql.NullString
+	XPlayerDeleted    bool
+	OPlayerDeleted    bool
+}
+
+func (q *Queries) GetGame(ctx context.Context, id int64) (GetGameRow, error) {
+	row := q.db.QueryRowContext(ctx, getGame, id)
+	var i GetGameRow
+	err := row.Scan(
+		&i.ID,
+		&i.XPlayer,
+		&i.OPlayer,
+		&i.BoardState,
+		&i.StartState,
+		&i.XTurn,
+		&i.UpdatedOn,
+		&i.StartedOn,
+		&i.Result,
+		&i.HintBudget,
+		&i.XHintsUsed,
+		&i.OHintsUsed,
+		&i.Opening,
+		&i.TakebacksDisabled,
+		&i.TakebackBy,
+		&i.RematchOf,
+		&i.RematchBy,
+		&i.EndedBy,
+		&i.Version,
+		&i.XPlayerName,
+		&i.OPlayerName,
+		&i.XPlayerDeleted,
+		&i.OPlayerDeleted,
+	)
+	return i, err
+}
+
+const getGameMessages = `-- name: GetGameMessages :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = ?
+ORDER BY m.id
+`
+
+type GetGameMessagesRow struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int64
+	Text     string
+	SentOn   time.Time
+	Username string
+}
+
+func (q *Queries) GetGameMessages(ctx context.Context, gameID int64) ([]GetGameMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGameMessages, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGameMessagesRow
+	for rows.Next() {
+		var i GetGameMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PlayerID,
+			&i.Channel,
+			&i.Text,
+			&i.SentOn,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGamePositions = `-- name: GetGamePositions :many
+SELECT id, board_state, x_turn, result, ended_by FROM games
+WHERE id > ?
+ORDER BY id ASC LIMIT ?
+`
+
+type GetGamePositionsParams struct {
+	ID    int64
+	Limit int64
+}
+
+type GetGamePositionsRow struct {
+	ID         int64
+	BoardState string
+	XTurn      sql.NullBool
+	Result     int64
+	EndedBy    sql.NullInt64
+}
+
+func (q *Queries) GetGamePositions(ctx context.Context, arg GetGamePositionsParams) ([]GetGamePositionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGamePositions, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGamePositionsRow
+	for rows.Next() {
+		var i GetGamePositionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BoardState,
+			&i.XTurn,
+			&i.Result,
+			&i.EndedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGameSteps = `-- name: GetGameSteps :many
+SELECT game_id, ord, move_row, move_col, board, x_turn, result, made_on FROM game_steps
+WHERE game_id = ?
+ORDER BY ord
+`
+
+func (q *Queries) GetGameSteps(ctx context.Context, gameID int64) ([]GameStep, error) {
+	rows, err := q.db.QueryContext(ctx, getGameSteps, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GameStep
+	for rows.Next() {
+		var i GameStep
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Ord,
+			&i.MoveRow,
+			&i.MoveCol,
+			&i.Board,
+			&i.XTurn,
+			&i.Result,
+			&i.MadeOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGames = `-- name: GetGames :many
+SELECT
+    g.id,
+    g.x_player,
+    g.o_player,
+    g.board_state,
+    g.start_state,
+    g.x_turn,
+    g.updated_on,
+    g.started_on,
+    g.result,
+    g.hint_budget,
+    g.x_hints_used,
+    g.o_hints_used,
+    g.opening,
+    g.takebacks_disabled,
+    g.takeback_by,
+    g.rematch_of,
+    g.rematch_by,
+    g.ended_by,
+    a1.username as x_player_name,
+    a2.username as o_player_name,
+    CAST(a1.deleted_on IS NOT NULL AS BOOLEAN) as x_player_deleted,
+    CAST(a2.deleted_on IS NOT NULL AS BOOLEAN) as o_player_deleted
+FROM games g
+LEFT JOIN player_accounts a1 ON a1.id = g.x_player
+LEFT JOIN player_accounts a2 ON a2.id = g.o_player
+WHERE g.id > ?1
+    AND (g.x_player = ?2 OR ?2 IS NULL)
+    AND (g.o_player = ?3 OR ?3 IS NULL)
+ORDER BY g.id ASC LIMIT ?4
+`
+
+type GetGamesParams struct {
+	ID      int64
+	XPlayer sql.NullInt64
+	OPlayer sql.NullInt64
+	Limit   int64
+}
+
+type GetGamesRow struct {
+	ID                int64
+	XPlayer           int64
+	OPlayer           sql.NullInt64
+	BoardState        string
+	StartState        string
+	XTurn             sql.NullBool
+	UpdatedOn         time.Time
+	StartedOn         time.Time
+	Result            int64
+	HintBudget        int64
+	XHintsUsed        int64
+	OHintsUsed        int64
+	Opening           int64
+	TakebacksDisabled bool
+	TakebackBy        sql.NullInt64
+	RematchOf         sql.NullInt64
+	RematchBy         sql.NullInt64
+	EndedBy           sql.NullInt64
+	XPlayerName       sql.NullString
+	OPlayerName       sql.NullString
+	XPlayerDeleted    bool
+	OPlayerDeleted    bool
+}
+
+func (q *Queries) GetGames(ctx context.Context, arg GetGamesParams) ([]GetGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGames,
+		arg.ID,
+		arg.XPlayer,
+		arg.OPlayer,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGamesRow
+	for rows.Next() {
+		var i GetGamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.XPlayer,
+			&i.OPlayer,
+			&i.BoardState,
+			&i.StartState,
+			&i.XTurn,
+			&i.UpdatedOn,
+			&i.StartedOn,
+			&i.Result,
+			&i.HintBudget,
+			&i.XHintsUsed,
+			&i.OHintsUsed,
+			&i.Opening,
+			&i.TakebacksDisabled,
+			&i.TakebackBy,
+			&i.RematchOf,
+			&i.RematchBy,
+			&i.EndedBy,
+			&i.XPlayerName,
+			&i.OPlayerName,
+			&i.XPlayerDeleted,
+			&i.OPlayerDeleted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGamesSteps = `-- name: GetGamesSteps :many
+SELECT game_id, ord, move_row, move_col, board, x_turn, result, made_on FROM game_steps
+WHERE game_id IN (/*SLICE:gameIds*/?)
+ORDER BY game_id, ord
+`
+
+func (q *Queries) GetGamesSteps(ctx context.Context, gameids []int64) ([]GameStep, error) {
+	query := getGamesSteps
+	var queryParams []interface{}
+	if len(gameids) > 0 {
+		for _, v := range gameids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:gameIds*/?", strings.Repeat(",?", len(gameids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:gameIds*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GameStep
+	for rows.Next() {
+		var i GameStep
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Ord,
+			&i.MoveRow,
+			&i.MoveCol,
+			&i.Board,
+			&i.XTurn,
+			&i.Result,
+			&i.MadeOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT player_id, "key", method, request_hash, response_type, response, created_on, expires_on FROM idempotency_keys
+WHERE player_id = ? AND key = ? AND expires_on > ?
+`
+
+type GetIdempotencyKeyParams struct {
+	PlayerID  int64
+	Key       string
+	ExpiresOn time.Time
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.PlayerID, arg.Key, arg.ExpiresOn)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Key,
+		&i.Method,
+		&i.RequestHash,
+		&i.ResponseType,
+		&i.Response,
+		&i.CreatedOn,
+		&i.ExpiresOn,
+	)
+	return i, err
+}
+
+const getLastStep = `-- name: GetLastStep :one
+SELECT game_id, ord, move_row, move_col, board, x_turn, result, made_on FROM game_steps
+WHERE game_id = ?
+ORDER BY ord DESC LIMIT 1
+`
+
+func (q *Queries) GetLastStep(ctx context.Context, gameID int64) (GameStep, error) {
+	row := q.db.QueryRowContext(ctx, getLastStep, gameID)
+	var i GameStep
+	err := row.Scan(
+		&i.GameID,
+		&i.Ord,
+		&i.MoveRow,
+		&i.MoveCol,
+		&i.Board,
+		&i.XTurn,
+		&i.Result,
+		&i.MadeOn,
+	)
+	return i, err
+}
+
+const getLoginLockout = `-- name: GetLoginLockout :one
+SELECT locked_until FROM login_failures
+WHERE subject IN (/*SLICE:subjects*/?)
+ORDER BY locked_until DESC LIMIT 1
+`
+
+func (q *Queries) GetLoginLockout(ctx context.Context, subjects []string) (time.Time, error) {
+	query := getLoginLockout
+	var queryParams []interface{}
+	if len(subjects) > 0 {
+		for _, v := range subjects {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:subjects*/?", strings.Repeat(",?", len(subjects))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:subjects*/?", "NULL", 1)
+	}
+	row := q.db.QueryRowContext(ctx, query, queryParams...)
+	var locked_until time.Time
+	err := row.Scan(&locked_until)
+	return locked_until, err
+}
+
+const getMessage = `-- name: GetMessage :one
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.id = ?
+`
+
+type GetMessageRow struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int64
+	Text     string
+	SentOn   time.Time
+	Username string
+}
+
+func (q *Queries) GetMessage(ctx context.Context, id int64) (GetMessageRow, error) {
+	row := q.db.QueryRowContext(ctx, getMessage, id)
+	var i GetMessageRow
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PlayerID,
+		&i.Channel,
+		&i.Text,
+		&i.SentOn,
+		&i.Username,
+	)
+	return i, err
+}
+
+const getMessagesAfter = `-- name: GetMessagesAfter :many
+SELECT m.id, m.game_id, m.player_id, m.channel, m.text, m.sent_on, a.username
+FROM game_messages m
+INNER JOIN player_accounts a ON a.id = m.player_id
+WHERE m.game_id = ? AND m.channel = ? AND m.id > ?
+ORDER BY m.id LIMIT ?
+`
+
+type GetMessagesAfterParams struct {
+	GameID  int64
+	Channel int64
+	ID      int64
+	Limit   int64
+}
+
+type GetMessagesAfterRow struct {
+	ID       int64
+	GameID   int64
+	PlayerID int64
+	Channel  int64
+	Text     string
+	SentOn   time.Time
+	Username string
+}
+
+func (q *Queries) GetMessagesAfter(ctx context.Context, arg GetMessagesAfterParams) ([]GetMessagesAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesAfter,
+		arg.GameID,
+		arg.Channel,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMessagesAfterRow
+	for rows.Next() {
+		var i GetMessagesAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PlayerID,
+			&i.Channel,
+			&i.Text,
+			&i.SentOn,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpeningStats = `-- name: GetOpeningStats :many
+SELECT
+    g.opening,
+    g.result,
+    CAST(COALESCE(g.x_player = ?1, FALSE) AS BOOLEAN) as player_is_x,
+    COUNT(*) as games
+FROM games g
+WHERE g.opening <> 0
+    AND (g.x_player = ?1 OR g.o_player = ?1 OR ?1 IS NULL)
+GROUP BY g.opening, g.result, player_is_x
+ORDER BY g.opening, g.result
+`
+
+type GetOpeningStatsRow struct {
+	Opening   int64
+	Result    int64
+	PlayerIsX bool
+	Games     int64
+}
+
+func (q *Queries) GetOpeningStats(ctx context.Context, player sql.NullInt64) ([]GetOpeningStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOpeningStats, player)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOpeningStatsRow
+	for rows.Next() {
+		var i GetOpeningStatsRow
+		if err := rows.Scan(
+			&i.Opening,
+			&i.Result,
+			&i.PlayerIsX,
+			&i.Games,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayer = `-- name: GetPlayer :one
+SELECT id, username FROM player_accounts WHERE id = ?
+`
+
+type GetPlayerRow struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) GetPlayer(ctx context.Context, id int64) (GetPlayerRow, error) {
+	row := q.db.QueryRowContext(ctx, getPlayer, id)
+	var i GetPlayerRow
+	err := row.Scan(&i.ID, &i.Username)
+	return i, err
+}
+
+const getPlayerRole = `-- name: GetPlayerRole :one
+SELECT role, banned_on FROM player_accounts
+WHERE id = ? AND deleted_on IS NULL
+`
+
+type GetPlayerRoleRow struct {
+	Role     int64
+	BannedOn sql.NullTime
+}
+
+func (q *Queries) GetPlayerRole(ctx context.Context, id int64) (GetPlayerRoleRow, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerRole, id)
+	var i GetPlayerRoleRow
+	err := row.Scan(&i.Role, &i.BannedOn)
+	return i, err
+}
+
+const getPlayerSessions = `-- name: GetPlayerSessions :many
+SELECT id, device, created_on, last_seen_on, expires_on FROM player_sessions
+WHERE player_id = ?1 AND expires_on > ?2
+ORDER BY last_seen_on DESC
+`
+
+type GetPlayerSessionsParams struct {
+	PlayerID int64
+	Now      time.Time
+}
+
+type GetPlayerSessionsRow struct {
+	ID         int64
+	Device     string
+	CreatedOn  time.Time
+	LastSeenOn time.Time
+	ExpiresOn  time.Time
+}
+
+func (q *Queries) GetPlayerSessions(ctx context.Context, arg GetPlayerSessionsParams) ([]GetPlayerSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerSessions, arg.PlayerID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPlayerSessionsRow
+	for rows.Next() {
+		var i GetPlayerSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Device,
+			&i.CreatedOn,
+			&i.LastSeenOn,
+			&i.ExpiresOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayers = `-- name: GetPlayers :many
+SELECT a.id, a.username, (SELECT COUNT(*) FROM player_sessions s WHERE s.player_id = a.id AND s.expires_on > ?1) as cnt
+FROM player_accounts a
+WHERE a.id > ?2 AND a.deleted_on IS NULL
+ORDER BY a.id ASC LIMIT ?3
+`
+
+type GetPlayersParams struct {
+	Now   time.Time
+	ID    int64
+	Limit int64
+}
+
+type GetPlayersRow struct {
+	ID       int64
+	Username string
+	Cnt      int64
+}
+
+func (q *Queries) GetPlayers(ctx context.Context, arg GetPlayersParams) ([]GetPlayersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayers, arg.Now, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPlayersRow
+	for rows.Next() {
+		var i GetPlayersRow
+		if err := rows.Scan(&i.ID, &i.Username, &i.Cnt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPuzzle = `-- name: GetPuzzle :one
+SELECT id, board_state, x_turn, depth, solution_row, solution_col, difficulty, created_on FROM puzzles WHERE id = ?
+`
+
+func (q *Queries) GetPuzzle(ctx context.Context, id int64) (Puzzle, error) {
+	row := q.db.QueryRowContext(ctx, getPuzzle, id)
+	var i Puzzle
+	err := row.Scan(
+		&i.ID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.Depth,
+		&i.SolutionRow,
+		&i.SolutionCol,
+		&i.Difficulty,
+		&i.CreatedOn,
+	)
+	return i, err
+}
+
+const getPuzzleAttempt = `-- name: GetPuzzleAttempt :one
+SELECT puzzle_id, player_id, board_state, x_turn, moves_left, status, started_on, updated_on, completed_on FROM puzzle_attempts
+WHERE puzzle_id = ? AND player_id = ?
+`
+
+type GetPuzzleAttemptParams struct {
+	PuzzleID int64
+	PlayerID int64
+}
+
+func (q *Queries) GetPuzzleAttempt(ctx context.Context, arg GetPuzzleAttemptParams) (PuzzleAttempt, error) {
+	row := q.db.QueryRowContext(ctx, getPuzzleAttempt, arg.PuzzleID, arg.PlayerID)
+	var i PuzzleAttempt
+	err := row.Scan(
+		&i.PuzzleID,
+		&i.PlayerID,
+		&i.BoardState,
+		&i.XTurn,
+		&i.MovesLeft,
+		&i.Status,
+		&i.StartedOn,
+		&i.UpdatedOn,
+		&i.CompletedOn,
+	)
+	return i, err
+}
+
+const getPuzzleStreak = `-- name: GetPuzzleStreak :one
+SELECT puzzle_streak, best_puzzle_streak FROM player_accounts WHERE id = ?
+`
+
+type GetPuzzleStreakRow struct {
+	PuzzleStreak     int64
+	BestPuzzleStreak int64
+}
+
+func (q *Queries) GetPuzzleStreak(ctx context.Context, id int64) (GetPuzzleStreakRow, error) {
+	row := q.db.QueryRowContext(ctx, getPuzzleStreak, id)
+	var i GetPuzzleStreakRow
+	err := row.Scan(&i.PuzzleStreak, &i.BestPuzzleStreak)
+	return i, err
+}
+
+const getRegistrations = `-- name: GetRegistrations :many
+SELECT id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason FROM player_accounts
+WHERE deleted_on IS NULL AND registered_on >= ?1
+ORDER BY id DESC LIMIT ?3 OFFSET ?2
+`
+
+type GetRegistrationsParams struct {
+	Since  time.Time
+	Offset int64
+	Limit  int64
+}
+
+func (q *Queries) GetRegistrations(ctx context.Context, arg GetRegistrationsParams) ([]PlayerAccount, error) {
+	rows, err := q.db.QueryContext(ctx, getRegistrations, arg.Since, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PlayerAccount
+	for rows.Next() {
+		var i PlayerAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Passwd,
+			&i.Salt,
+			&i.PuzzleStreak,
+			&i.BestPuzzleStreak,
+			&i.DeletedOn,
+			&i.Role,
+			&i.RegisteredOn,
+			&i.BannedOn,
+			&i.BanReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRematch = `-- name: GetRematch :one
+SELECT id FROM games
+WHERE rematch_of = ?
+`
+
+func (q *Queries) GetRematch(ctx context.Context, rematchOf sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getRematch, rematchOf)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getSeries = `-- name: GetSeries :many
+WITH RECURSIVE series(id, rematch_of, x_player, o_player, result) AS (
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    WHERE g.id = ?
+    UNION ALL
+    SELECT g.id, g.rematch_of, g.x_player, g.o_player, g.result FROM games g
+    INNER JOIN series s ON g.id = s.rematch_of
+)
+SELECT id, x_player, o_player, result FROM series
+ORDER BY id
+`
+
+type GetSeriesRow struct {
+	ID      int64
+	XPlayer int64
+	OPlayer sql.NullInt64
+	Result  int64
+}
+
+func (q *Queries) GetSeries(ctx context.Context, id int64) ([]GetSeriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSeries, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSeriesRow
+	for rows.Next() {
+		var i GetSeriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.XPlayer,
+			&i.OPlayer,
+			&i.Result,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getServerStats = `-- name: GetServerStats :one
+SELECT
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.deleted_on IS NULL) as players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.banned_on IS NOT NULL) as banned_players,
+    (SELECT COUNT(*) FROM player_accounts p WHERE p.registered_on >= ?1) as registrations,
+    (SELECT COUNT(*) FROM player_sessions s WHERE s.expires_on > ?2) as sessions,
+    (SELECT COUNT(*) FROM games g) as games,
+    (SELECT COUNT(*) FROM games g WHERE g.result = 0) as active_games,
+    (SELECT COUNT(*) FROM game_messages m) as messages
+`
+
+type GetServerStatsParams struct {
+	Since time.Time
+	Now   time.Time
+}
+
+type GetServerStatsRow struct {
+	Players       int64
+	BannedPlayers int64
+	Registrations int64
+	Sessions      int64
+	Games         int64
+	ActiveGames   int64
+	Messages      int64
+}
+
+func (q *Queries) GetServerStats(ctx context.Context, arg GetServerStatsParams) (GetServerStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getServerStats, arg.Since, arg.Now)
+	var i GetServerStatsRow
+	err := row.Scan(
+		&i.Players,
+		&i.BannedPlayers,
+		&i.Registrations,
+		&i.Sessions,
+		&i.Games,
+		&i.ActiveGames,
+		&i.Messages,
+	)
+	return i, err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT a.id, a.username, s.id as session_id FROM player_sessions s
+INNER JOIN player_accounts a ON a.id = s.player_id
+WHERE s.token_hash = ?1 AND s.expires_on > ?2 AND a.banned_on IS NULL
+`
+
+type GetSessionParams struct {
+	TokenHash string
+	Now       time.Time
+}
+
+type GetSessionRow struct {
+	ID        int64
+	Username  string
+	SessionID int64
+}
+
+func (q *Queries) GetSession(ctx context.Context, arg GetSessionParams) (GetSessionRow, error) {
+	row := q.db.QueryRowContext(ctx, getSession, arg.TokenHash, arg.Now)
+	var i GetSessionRow
+	err := row.Scan(&i.ID, &i.Username, &i.SessionID)
+	return i, err
+}
+
+const insertAnalysis = `-- name: InsertAnalysis :execresult
+INSERT INTO analyses (game_id, ord, move_row, move_col, x_moved, best_result, best_distance, played_result, played_distance, annotation, analyzed_on)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (game_id, ord) DO NOTHING
+`
+
+type InsertAnalysisParams struct {
+	GameID         int64
+	Ord            int64
+	MoveRow        int64
+	MoveCol        int64
+	XMoved         bool
+	BestResult     int64
+	BestDistance   int64
+	PlayedResult   int64
+	PlayedDistance int64
+	Annotation     int64
+	AnalyzedOn     time.Time
+}
+
+func (q *Queries) InsertAnalysis(ctx context.Context, arg InsertAnalysisParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, insertAnalysis,
+		arg.GameID,
+		arg.Ord,
+		arg.MoveRow,
+		arg.MoveCol,
+		arg.XMoved,
+		arg.BestResult,
+		arg.BestDistance,
+		arg.PlayedResult,
+		arg.PlayedDistance,
+		arg.Annotation,
+		arg.AnalyzedOn,
+	)
+}
+
+const insertAuditEvent = `-- name: InsertAuditEvent :one
+INSERT INTO audit_events (actor_id, event_type, target_id, peer, method, payload, created_on)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type InsertAuditEventParams struct {
+	ActorID   sql.NullInt64
+	EventType string
+	TargetID  sql.NullInt64
+	Peer      string
+	Method    string
+	Payload   string
+	CreatedOn time.Time
+}
+
+func (q *Queries) InsertAuditEvent(ctx context.Context, arg InsertAuditEventParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertAuditEvent,
+		arg.ActorID,
+		arg.EventType,
+		arg.TargetID,
+		arg.Peer,
+		arg.Method,
+		arg.Payload,
+		arg.CreatedOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertDailyPuzzle = `-- name: InsertDailyPuzzle :execresult
+INSERT INTO daily_puzzles (day, puzzle_id)
+VALUES (?, ?)
+ON CONFLICT (day) DO NOTHING
+`
+
+type InsertDailyPuzzleParams struct {
+	Day      time.Time
+	PuzzleID int64
+}
+
+func (q *Queries) InsertDailyPuzzle(ctx context.Context, arg InsertDailyPuzzleParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, insertDailyPuzzle, arg.Day, arg.PuzzleID)
+}
+
+const insertGame = `-- name: InsertGame :one
+INSERT INTO games (x_player, o_player, board_state, start_state, x_turn, updated_on, started_on, hint_budget, takebacks_disabled, rematch_of)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type InsertGameParams struct {
+	XPlayer           int64
+	OPlayer           sql.NullInt64
+	BoardState        string
+	StartState        string
+	XTurn             sql.NullBool
+	UpdatedOn         time.Time
+	StartedOn         time.Time
+	HintBudget        int64
+	TakebacksDisabled bool
+	RematchOf         sql.NullInt64
+}
+
+func (q *Queries) InsertGame(ctx context.Context, arg InsertGameParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertGame,
+		arg.XPlayer,
+		arg.OPlayer,
+		arg.BoardState,
+		arg.StartState,
+		arg.XTurn,
+		arg.UpdatedOn,
+		arg.StartedOn,
+		arg.HintBudget,
+		arg.TakebacksDisabled,
+		arg.RematchOf,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertMessage = `-- name: InsertMessage :one
+INSERT INTO game_messages (game_id, player_id, channel, text, sent_on)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type InsertMessageParams struct {
+	GameID   int64
+	PlayerID int64
+	Channel  int64
+	Text     string
+	SentOn   time.Time
+}
+
+func (q *Queries) InsertMessage(ctx context.Context, arg InsertMessageParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertMessage,
+		arg.GameID,
+		arg.PlayerID,
+		arg.Channel,
+		arg.Text,
+		arg.SentOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertPlayer = `-- name: InsertPlayer :one
+INSERT INTO player_accounts (username, passwd, salt, registered_on)
+VALUES (?, ?, ?, ?)
+RETURNING id, username
+`
+
+type InsertPlayerParams struct {
+	Username     string
+	Passwd       string
+	Salt         string
+	RegisteredOn time.Time
+}
+
+type InsertPlayerRow struct {
+	ID       int64
+	Username string
+}
+
+func (q *Queries) InsertPlayer(ctx context.Context, arg InsertPlayerParams) (InsertPlayerRow, error) {
+	row := q.db.QueryRowContext(ctx, insertPlayer,
+		arg.Username,
+		arg.Passwd,
+		arg.Salt,
+		arg.RegisteredOn,
+	)
+	var i InsertPlayerRow
+	err := row.Scan(&i.ID, &i.Username)
+	return i, err
+}
+
+const insertPuzzle = `-- name: InsertPuzzle :one
+INSERT INTO puzzles (board_state, x_turn, depth, solution_row, solution_col, difficulty, created_on)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type InsertPuzzleParams struct {
+	BoardState  string
+	XTurn       bool
+	Depth       int64
+	SolutionRow int64
+	SolutionCol int64
+	Difficulty  int64
+	CreatedOn   time.Time
+}
+
+func (q *Queries) InsertPuzzle(ctx context.Context, arg InsertPuzzleParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertPuzzle,
+		arg.BoardState,
+		arg.XTurn,
+		arg.Depth,
+		arg.SolutionRow,
+		arg.SolutionCol,
+		arg.Difficulty,
+		arg.CreatedOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertPuzzleAttempt = `-- name: InsertPuzzleAttempt :execresult
+INSERT INTO puzzle_attempts (puzzle_id, player_id, board_state, x_turn, moves_left, started_on, updated_on)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?6)
+ON CONFLICT (puzzle_id, player_id) DO NOTHING
+`
+
+type InsertPuzzleAttemptParams struct {
+	PuzzleID   int64
+	PlayerID   int64
+	BoardState string
+	XTurn      bool
+	MovesLeft  int64
+	Now        time.Time
+}
+
+func (q *Queries) InsertPuzzleAttempt(ctx context.Context, arg InsertPuzzleAttemptParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, insertPuzzleAttempt,
+		arg.PuzzleID,
+		arg.PlayerID,
+		arg.BoardState,
+		arg.XTurn,
+		arg.MovesLeft,
+		arg.Now,
+	)
+}
+
+const insertSession = `-- name: InsertSession :one
+INSERT INTO player_sessions (token_hash, player_id, device, created_on, last_seen_on, expires_on)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type InsertSessionParams struct {
+	TokenHash  string
+	PlayerID   int64
+	Device     string
+	CreatedOn  time.Time
+	LastSeenOn time.Time
+	ExpiresOn  time.Time
+}
+
+func (q *Queries) InsertSession(ctx context.Context, arg InsertSessionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertSession,
+		arg.TokenHash,
+		arg.PlayerID,
+		arg.Device,
+		arg.CreatedOn,
+		arg.LastSeenOn,
+		arg.ExpiresOn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertStep = `-- name: InsertStep :execresult
+INSERT INTO game_steps (game_id, move_row, move_col, board, x_turn, result, made_on, ord)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7,
+        COALESCE((SELECT ord FROM game_steps WHERE game_id = ?1 ORDER BY ord DESC LIMIT 1), -1) + 1)
+`
+
+type InsertStepParams struct {
+	GameID  int64
+	MoveRow int64
+	MoveCol int64
+	Board   string
+	XTurn   bool
+	Result  int64
+	MadeOn  time.Time
+}
+
+func (q *Queries) InsertStep(ctx context.Context, arg InsertStepParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, insertStep,
+		arg.GameID,
+		arg.MoveRow,
+		arg.MoveCol,
+		arg.Board,
+		arg.XTurn,
+		arg.Result,
+		arg.MadeOn,
+	)
+}
+
+const lockLogin = `-- name: LockLogin :exec
+UPDATE login_failures
+SET locked_until = ?
+WHERE subject = ?
+`
+
+type LockLoginParams struct {
+	LockedUntil time.Time
+	Subject     string
+}
+
+func (q *Queries) LockLogin(ctx context.Context, arg LockLoginParams) error {
+	_, err := q.db.ExecContext(ctx, lockLogin, arg.LockedUntil, arg.Subject)
+	return err
+}
+
+const lockPlayer = `-- name: LockPlayer :one
+SELECT id FROM player_accounts
+WHERE id = ?
+`
+
+// sqlite has no row locks, its store runs one transaction at a time so reading the row is enough
+func (q *Queries) LockPlayer(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, lockPlayer, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const offerRematch = `-- name: OfferRematch :execresult
+UPDATE games
+SET rematch_by = ?
+WHERE id = ? AND rematch_by IS NULL
+`
+
+type OfferRematchParams struct {
+	RematchBy sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) OfferRematch(ctx context.Context, arg OfferRematchParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, offerRematch, arg.RematchBy, arg.ID)
+}
+
+const purgeExpiredSessions = `-- name: PurgeExpiredSessions :execresult
+DELETE FROM player_sessions
+WHERE expires_on <= ?
+`
+
+func (q *Queries) PurgeExpiredSessions(ctx context.Context, expiresOn time.Time) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeExpiredSessions, expiresOn)
+}
+
+const purgeIdempotencyKeys = `-- name: PurgeIdempotencyKeys :execresult
+DELETE FROM idempotency_keys
+WHERE expires_on <= ?
+`
+
+func (q *Queries) PurgeIdempotencyKeys(ctx context.Context, expiresOn time.Time) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeIdempotencyKeys, expiresOn)
+}
+
+const purgeLoginFailures = `-- name: PurgeLoginFailures :execresult
+DELETE FROM login_failures
+WHERE failed_on <= ?1 AND locked_until <= ?2
+`
+
+type PurgeLoginFailuresParams struct {
+	WindowStart time.Time
+	Now         time.Time
+}
+
+func (q *Queries) PurgeLoginFailures(ctx context.Context, arg PurgeLoginFailuresParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeLoginFailures, arg.WindowStart, arg.Now)
+}
+
+const queryAuditLog = `-- name: QueryAuditLog :many
+SELECT id, actor_id, event_type, target_id, peer, method, payload, created_on FROM audit_events
+WHERE created_on >= ?1 AND created_on < ?2
+    AND (actor_id = ?3 OR ?3 IS NULL)
+    AND (event_type = ?4 OR ?4 IS NULL)
+ORDER BY id DESC LIMIT ?6 OFFSET ?5
+`
+
+type QueryAuditLogParams struct {
+	Since     time.Time
+	Until     time.Time
+	ActorId   sql.NullInt64
+	EventType sql.NullString
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) QueryAuditLog(ctx context.Context, arg QueryAuditLogParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, queryAuditLog,
+		arg.Since,
+		arg.Until,
+		arg.ActorId,
+		arg.EventType,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.EventType,
+			&i.TargetID,
+			&i.Peer,
+			&i.Method,
+			&i.Payload,
+			&i.CreatedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_failures (subject, failures, failed_on, locked_until)
+VALUES (?1, 1, ?2, ?2)
+ON CONFLICT (subject) DO UPDATE
+SET failures = login_failures.failures + 1,
+    failed_on = excluded.failed_on
+RETURNING failures
+`
+
+type RecordLoginFailureParams struct {
+	Subject  string
+	FailedOn time.Time
+}
+
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, recordLoginFailure, arg.Subject, arg.FailedOn)
+	var failures int64
+	err := row.Scan(&failures)
+	return failures, err
+}
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE player_id = ? AND key = ? AND response IS NULL
+`
+
+type ReleaseIdempotencyKeyParams struct {
+	PlayerID int64
+	Key      string
+}
+
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, releaseIdempotencyKey, arg.PlayerID, arg.Key)
+	return err
+}
+
+const requestTakeback = `-- name: RequestTakeback :execresult
+UPDATE games
+SET takeback_by = ?
+WHERE id = ? AND takeback_by IS NULL
+`
+
+type RequestTakebackParams struct {
+	TakebackBy sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) RequestTakeback(ctx context.Context, arg RequestTakebackParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, requestTakeback, arg.TakebackBy, arg.ID)
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :execresult
+INSERT INTO idempotency_keys (player_id, key, method, request_hash, created_on, expires_on)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (player_id, key) DO UPDATE
+SET method = excluded.method,
+    request_hash = excluded.request_hash,
+    response_type = '',
+    response = NULL,
+    created_on = excluded.created_on,
+    expires_on = excluded.expires_on
+WHERE idempotency_keys.expires_on <= excluded.created_on
+`
+
+type ReserveIdempotencyKeyParams struct {
+	PlayerID    int64
+	Key         string
+	Method      string
+	RequestHash string
+	CreatedOn   time.Time
+	ExpiresOn   time.Time
+}
+
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, reserveIdempotencyKey,
+		arg.PlayerID,
+		arg.Key,
+		arg.Method,
+		arg.RequestHash,
+		arg.CreatedOn,
+		arg.ExpiresOn,
+	)
+}
+
+const resetLoginFailures = `-- name: ResetLoginFailures :exec
+UPDATE login_failures
+SET failures = 0
+WHERE subject = ?1 AND failed_on <= ?2
+`
+
+type ResetLoginFailuresParams struct {
+	Subject     string
+	WindowStart time.Time
+}
+
+func (q *Queries) ResetLoginFailures(ctx context.Context, arg ResetLoginFailuresParams) error {
+	_, err := q.db.ExecContext(ctx, resetLoginFailures, arg.Subject, arg.WindowStart)
+	return err
+}
+
+const rotateSession = `-- name: RotateSession :execresult
+UPDATE player_sessions
+SET token_hash = ?1, last_seen_on = ?2, expires_on = ?3
+WHERE id = ?4 AND token_hash = ?5
+`
+
+type RotateSessionParams struct {
+	NewTokenHash string
+	LastSeenOn   time.Time
+	ExpiresOn    time.Time
+	ID           int64
+	TokenHash    string
+}
+
+func (q *Queries) RotateSession(ctx context.Context, arg RotateSessionParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, rotateSession,
+		arg.NewTokenHash,
+		arg.LastSeenOn,
+		arg.ExpiresOn,
+		arg.ID,
+		arg.TokenHash,
+	)
+}
+
+const setRole = `-- name: SetRole :one
+UPDATE player_accounts
+SET role = ?1
+WHERE id = ?2 AND deleted_on IS NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+type SetRoleParams struct {
+	Role int64
+	ID   int64
+}
+
+func (q *Queries) SetRole(ctx context.Context, arg SetRoleParams) (PlayerAccount, error) {
+	row := q.db.QueryRowContext(ctx, setRole, arg.Role, arg.ID)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
+const storeIdempotentResponse = `-- name: StoreIdempotentResponse :exec
+UPDATE idempotency_keys
+SET response_type = ?, response = ?
+WHERE player_id = ? AND key = ?
+`
+
+type StoreIdempotentResponseParams struct {
+	ResponseType string
+	Response     []byte
+	PlayerID     int64
+	Key          string
+}
+
+func (q *Queries) StoreIdempotentResponse(ctx context.Context, arg StoreIdempotentResponseParams) error {
+	_, err := q.db.ExecContext(ctx, storeIdempotentResponse,
+		arg.ResponseType,
+		arg.Response,
+		arg.PlayerID,
+		arg.Key,
+	)
+	return err
+}
+
+const unbanPlayer = `-- name: UnbanPlayer :one
+UPDATE player_accounts
+SET banned_on = NULL, ban_reason = ''
+WHERE id = ? AND banned_on IS NOT NULL
+RETURNING id, username, passwd, salt, puzzle_streak, best_puzzle_streak, deleted_on, role, registered_on, banned_on, ban_reason
+`
+
+func (q *Queries) UnbanPlayer(ctx context.Context, id int64) (PlayerAccount, error) {
+	row := q.db.QueryRowContext(ctx, unbanPlayer, id)
+	var i PlayerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Passwd,
+		&i.Salt,
+		&i.PuzzleStreak,
+		&i.BestPuzzleStreak,
+		&i.DeletedOn,
+		&i.Role,
+		&i.RegisteredOn,
+		&i.BannedOn,
+		&i.BanReason,
+	)
+	return i, err
+}
+
+const updateGame = `-- name: UpdateGame :execresult
+UPDATE games
+SET board_state = ?1,
+    x_turn = ?2,
+    updated_on = ?3,
+    result = ?4,
+    opening = COALESCE(?5, opening),
+    takeback_by = NULL,
+    version = version + 1
+WHERE id = ?6 AND version = ?7
+`
+
+type UpdateGameParams struct {
+	BoardState string
+	XTurn      sql.NullBool
+	UpdatedOn  time.Time
+	Result     int64
+	Opening    sql.NullInt64
+	ID         int64
+	Version    int64
+}
+
+func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateGame,
+		arg.BoardState,
+		arg.XTurn,
+		arg.UpdatedOn,
+		arg.Result,
+		arg.Opening,
+		arg.ID,
+		arg.Version,
+	)
+}
+
+const updatePassword = `-- name: UpdatePassword :execresult
+UPDATE player_accounts
+SET passwd = ?
+WHERE id = ? AND deleted_on IS NULL
+`
+
+type UpdatePasswordParams struct {
+	Passwd string
+	ID     int64
+}
+
+func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updatePassword, arg.Passwd, arg.ID)
+}
+
+const updatePuzzleAttempt = `-- name: UpdatePuzzleAttempt :execresult
+UPDATE puzzle_attempts
+SET board_state = ?1, x_turn = ?2, moves_left = ?3, status = ?4, updated_on = ?5,
+    completed_on = ?6
+WHERE puzzle_id = ?7 AND player_id = ?8 AND board_state = ?9
+`
+
+type UpdatePuzzleAttemptParams struct {
+	BoardState     string
+	XTurn          bool
+	MovesLeft      int64
+	Status         int64
+	UpdatedOn      time.Time
+	CompletedOn    sql.NullTime
+	PuzzleID       int64
+	PlayerID       int64
+	PrevBoardState string
+}
+
+func (q *Queries) UpdatePuzzleAttempt(ctx context.Context, arg UpdatePuzzleAttemptParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updatePuzzleAttempt,
+		arg.BoardState,
+		arg.XTurn,
+		arg.MovesLeft,
+		arg.Status,
+		arg.UpdatedOn,
+		arg.CompletedOn,
+		arg.PuzzleID,
+		arg.PlayerID,
+		arg.PrevBoardState,
+	)
+}
+
+const updatePuzzleStreak = `-- name: UpdatePuzzleStreak :one
+UPDATE player_accounts
+SET puzzle_streak = CASE WHEN CAST(?1 AS BOOLEAN) THEN puzzle_streak + 1 ELSE 0 END,
+    best_puzzle_streak = MAX(best_puzzle_streak, CASE WHEN CAST(?1 AS BOOLEAN) THEN puzzle_streak + 1 ELSE 0 END)
+WHERE id = ?2
+RETURNING puzzle_streak, best_puzzle_streak
+`
+
+type UpdatePuzzleStreakParams struct {
+	Solved bool
+	ID     int64
+}
+
+type UpdatePuzzleStreakRow struct {
+	PuzzleStreak     int64
+	BestPuzzleStreak int64
+}
+
+func (q *Queries) UpdatePuzzleStreak(ctx context.Context, arg UpdatePuzzleStreakParams) (UpdatePuzzleStreakRow, error) {
+	row := q.db.QueryRowContext(ctx, updatePuzzleStreak, arg.Solved, arg.ID)
+	var i UpdatePuzzleStreakRow
+	err := row.Scan(&i.PuzzleStreak, &i.BestPuzzleStreak)
+	return i, err
+}
+
+const useHint = `-- name: UseHint :execresult
+UPDATE games
+SET x_hints_used = x_hints_used + CASE WHEN ?1 THEN 1 ELSE 0 END,
+    o_hints_used = o_hints_used + CASE WHEN ?1 THEN 0 ELSE 1 END
+WHERE id = ?2
+    AND CASE WHEN ?1 THEN x_hints_used ELSE o_hints_used END < hint_budget
+`
+
+type UseHintParams struct {
+	XTurn int64
+	ID    int64
+}
+
+func (q *Queries) UseHint(ctx context.Context, arg UseHintParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, useHint, arg.XTurn, arg.ID)
+}
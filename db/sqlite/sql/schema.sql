@@ -0,0 +1,163 @@
+CREATE TABLE IF NOT EXISTS player_accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL,
+    passwd TEXT NOT NULL,
+    salt TEXT NOT NULL,
+    puzzle_streak INTEGER DEFAULT 0 NOT NULL,
+    best_puzzle_streak INTEGER DEFAULT 0 NOT NULL,
+    deleted_on TIMESTAMP,
+    role INTEGER DEFAULT 0 NOT NULL,
+    registered_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    banned_on TIMESTAMP,
+    ban_reason TEXT DEFAULT '' NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS player_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token_hash TEXT NOT NULL UNIQUE,
+    player_id INTEGER NOT NULL REFERENCES player_accounts(id),
+    device TEXT DEFAULT '' NOT NULL,
+    created_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_seen_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_on TIMESTAMP DEFAULT (datetime('now', '+30 days')) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS games (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    x_player INTEGER NOT NULL REFERENCES player_accounts(id),
+    o_player INTEGER REFERENCES player_accounts(id),
+    board_state TEXT NOT NULL,
+    start_state TEXT DEFAULT '_________' NOT NULL,
+    x_turn BOOLEAN,
+    updated_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    started_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    result INTEGER DEFAULT 0 NOT NULL,
+    hint_budget INTEGER DEFAULT 0 NOT NULL,
+    x_hints_used INTEGER DEFAULT 0 NOT NULL,
+    o_hints_used INTEGER DEFAULT 0 NOT NULL,
+    opening INTEGER DEFAULT 0 NOT NULL,
+    takebacks_disabled BOOLEAN DEFAULT FALSE NOT NULL,
+    takeback_by INTEGER REFERENCES player_accounts(id),
+    rematch_of INTEGER REFERENCES games(id),
+    rematch_by INTEGER REFERENCES player_accounts(id),
+    ended_by INTEGER REFERENCES player_accounts(id),
+    version INTEGER DEFAULT 0 NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS game_steps (
+    game_id INTEGER NOT NULL REFERENCES games(id),
+    ord INTEGER NOT NULL,
+    move_row INTEGER NOT NULL,
+    move_col INTEGER NOT NULL,
+    board TEXT NOT NULL,
+    x_turn BOOLEAN NOT NULL,
+    result INTEGER NOT NULL,
+    made_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(game_id, ord)
+);
+
+CREATE TABLE IF NOT EXISTS analyses (
+    game_id INTEGER NOT NULL REFERENCES games(id),
+    ord INTEGER NOT NULL,
+    move_row INTEGER NOT NULL,
+    move_col INTEGER NOT NULL,
+    x_moved BOOLEAN NOT NULL,
+    best_result INTEGER NOT NULL,
+    best_distance INTEGER NOT NULL,
+    played_result INTEGER NOT NULL,
+    played_distance INTEGER NOT NULL,
+    annotation INTEGER NOT NULL,
+    analyzed_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(game_id, ord)
+);
+
+CREATE TABLE IF NOT EXISTS puzzles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    board_state TEXT NOT NULL,
+    x_turn BOOLEAN NOT NULL,
+    depth INTEGER NOT NULL,
+    solution_row INTEGER NOT NULL,
+    solution_col INTEGER NOT NULL,
+    difficulty INTEGER DEFAULT 0 NOT NULL,
+    created_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS puzzle_attempts (
+    puzzle_id INTEGER NOT NULL REFERENCES puzzles(id),
+    player_id INTEGER NOT NULL REFERENCES player_accounts(id),
+    board_state TEXT NOT NULL,
+    x_turn BOOLEAN NOT NULL,
+    moves_left INTEGER NOT NULL,
+    status INTEGER DEFAULT 0 NOT NULL,
+    started_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_on TIMESTAMP,
+    PRIMARY KEY(puzzle_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS daily_puzzles (
+    day DATE NOT NULL,
+    puzzle_id INTEGER NOT NULL REFERENCES puzzles(id),
+    PRIMARY KEY(day)
+);
+
+CREATE TABLE IF NOT EXISTS game_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id INTEGER NOT NULL REFERENCES games(id),
+    player_id INTEGER NOT NULL REFERENCES player_accounts(id),
+    channel INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    sent_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id INTEGER REFERENCES player_accounts(id),
+    event_type TEXT NOT NULL,
+    target_id INTEGER,
+    peer TEXT DEFAULT '' NOT NULL,
+    method TEXT DEFAULT '' NOT NULL,
+    payload TEXT DEFAULT '{}' NOT NULL,
+    created_on TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    subject TEXT NOT NULL,
+    failures INTEGER NOT NULL,
+    failed_on TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    PRIMARY KEY(subject)
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    player_id INTEGER NOT NULL REFERENCES player_accounts(id),
+    key TEXT NOT NULL,
+    method TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    response_type TEXT DEFAULT '' NOT NULL,
+    response BLOB,
+    created_on TIMESTAMP NOT NULL,
+    expires_on TIMESTAMP NOT NULL,
+    PRIMARY KEY(player_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS player_sessions_id ON player_sessions(player_id);
+CREATE INDEX IF NOT EXISTS player_sessions_expires ON player_sessions(expires_on);
+CREATE INDEX IF NOT EXISTS games_opening ON games(opening);
+CREATE UNIQUE INDEX IF NOT EXISTS games_rematch_of ON games(rematch_of);
+CREATE INDEX IF NOT EXISTS game_messages_game ON game_messages(game_id, channel, id);
+CREATE INDEX IF NOT EXISTS game_messages_player ON game_messages(player_id, sent_on);
+CREATE INDEX IF NOT EXISTS audit_events_actor ON audit_events(actor_id, id);
+CREATE INDEX IF NOT EXISTS audit_events_created ON audit_events(created_on);
+CREATE UNIQUE INDEX IF NOT EXISTS player_accounts_names ON player_accounts(UPPER(username));
+
+-- the audit log is append-only, rows can be added but never changed or removed
+CREATE TRIGGER IF NOT EXISTS audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
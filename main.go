@@ -6,6 +6,7 @@ import (
 	"TicTacGo/server"
 	"TicTacGo/utils"
 	"context"
+	"flag"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
@@ -13,6 +14,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
@@ -29,38 +32,184 @@ func main() {
 	log.SetOutput(io.MultiWriter(f, os.Stdout))
 
 	config := utils.NewConfig()
-
-	dbUser := config.Get("DB_USER")
-	dbName := config.Get("DB_NAME")
-	dbPassword := config.Get("DB_PASSWORD")
-	dbPort := config.Get("DB_PORT")
 	serverPort := config.Get("SERVER_PORT")
 
+	storage := flag.String("storage", config.GetOr("STORAGE", "postgres"), "where to keep players and games, postgres, sqlite or memory")
+	flag.Parse()
+
 	ctx := context.Background()
 
-	connString := fmt.Sprintf("user=%s dbname=%s password=%s port=%s", dbUser, dbName, dbPassword, dbPort)
-	log.Printf("creating database connection on with connString: %s", connString)
+	serve := &server.GrpcServer{}
+	switch *storage {
+	case "postgres":
+		dbUser := config.Get("DB_USER")
+		dbName := config.Get("DB_NAME")
+		dbPassword := config.Get("DB_PASSWORD")
+		dbPort := config.Get("DB_PORT")
 
-	pool, err := pgxpool.New(ctx, connString)
+		connString := fmt.Sprintf("user=%s dbname=%s password=%s port=%s", dbUser, dbName, dbPassword, dbPort)
+		log.Printf("creating database connection on with connString: %s", connString)
+
+		pool, err := pgxpool.New(ctx, connString)
+		if err != nil {
+			log.Fatalf("failed to connect to database with err: %v", err)
+		}
+		defer pool.Close()
+
+		// the migrate command manages migrations itself, and may be run to revert them
+		if flag.Arg(0) != "migrate" {
+			applied, err := db.MigrateUp(ctx, pool)
+			if err != nil {
+				log.Fatalf("failed to migrate the database with err: %v", err)
+			}
+			for _, migration := range applied {
+				log.Printf("applied migration: %d_%s", migration.Version, migration.Name)
+			}
+		}
+
+		serve.Store = server.NewPgStore(pool)
+	case "sqlite":
+		sqlitePath := config.GetOr("SQLITE_PATH", "tictacgo.db")
+		log.Printf("opening sqlite database: %s", sqlitePath)
+
+		store, err := server.NewSqliteStore(ctx, sqlitePath)
+		if err != nil {
+			log.Fatalf("failed to open sqlite database with err: %v", err)
+		}
+		defer func() {
+			if err := store.Close(); err != nil {
+				log.Printf("failed to close sqlite database: %v", err)
+			}
+		}()
+
+		serve.Store = store
+	case "memory":
+		log.Printf("keeping players and games in memory, they are lost when the server stops")
+		serve.Store = server.NewMemStore()
+	default:
+		log.Fatalf("unknown storage: %s, expected postgres, sqlite or memory", *storage)
+	}
+
+	if flag.Arg(0) == "migrate" {
+		pgStore, ok := serve.Store.(*server.PgStore)
+		if !ok {
+			log.Fatalf("migrations are only kept for postgres, not the %s storage", *storage)
+		}
+		migrate(ctx, pgStore.Pool, flag.Arg(1), flag.Arg(2))
+		return
+	}
+
+	blockedWords := server.ParseBlockedWords(config.GetOr("BLOCKED_WORDS", ""))
+	sessionTTL, err := time.ParseDuration(config.GetOr("SESSION_TTL", server.DefaultSessionTTL.String()))
+	if err != nil {
+		log.Fatalf("failed to parse SESSION_TTL: %v", err)
+	}
+	accessTTL, err := time.ParseDuration(config.GetOr("ACCESS_TTL", server.DefaultAccessTTL.String()))
+	if err != nil {
+		log.Fatalf("failed to parse ACCESS_TTL: %v", err)
+	}
+	tokenKeys, err := server.ParseTokenKeys(config.Get("TOKEN_KEYS"))
+	if err != nil {
+		log.Fatalf("failed to parse TOKEN_KEYS: %v", err)
+	}
+	chatLimit, err := strconv.ParseInt(config.GetOr("CHAT_LIMIT", strconv.Itoa(server.DefaultMessagesPerWindow)), 10, 64)
+	if err != nil {
+		log.Fatalf("failed to parse CHAT_LIMIT: %v", err)
+	}
+	chatWindow, err := time.ParseDuration(config.GetOr("CHAT_WINDOW", server.DefaultMessageWindow.String()))
 	if err != nil {
-		log.Fatalf("failed to connect to database with err: %v", err)
+		log.Fatalf("failed to parse CHAT_WINDOW: %v", err)
+	}
+	rateLimits, defaultRateLimit, err := server.ParseRateLimits(config.GetOr("RATE_LIMITS", ""))
+	if err != nil {
+		log.Fatalf("failed to parse RATE_LIMITS: %v", err)
 	}
-	defer pool.Close()
 
-	log.Printf("starting server on port: %s", serverPort)
+	serve.BlockedWords = blockedWords
+	serve.TokenKeys = tokenKeys
+	serve.AccessExpiry = accessTTL
+	serve.SessionExpiry = sessionTTL
+	serve.ChatLimit = chatLimit
+	serve.ChatWindow = chatWindow
+	serve.RateLimiter = server.NewRateLimiter(rateLimits, defaultRateLimit)
+
+	if flag.Arg(0) == "check" {
+		corrupt, err := serve.CheckGames(ctx)
+		if err != nil {
+			log.Fatalf("failed to check games: %v", err)
+		}
+		for _, game := range corrupt {
+			log.Printf("game: %d is corrupt: %s", game.ID, game.Reason)
+		}
+		log.Printf("checked games, found %d corrupt", len(corrupt))
+		if len(corrupt) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
 
-	serve := &server.GrpcServer{Queries: db.New(pool), Pool: pool}
+	go serve.RunDailyPuzzles(ctx, time.Hour)
+	go serve.RunSessionPurge(ctx, time.Hour)
+
+	log.Printf("starting server on port: %s", serverPort)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", serverPort))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	baseServer := grpc.NewServer()
+	baseServer := grpc.NewServer(serve.ServerOptions()...)
 	pb.RegisterTicTacGoServiceServer(baseServer, serve)
+	pb.RegisterAdminServiceServer(baseServer, serve)
 
 	log.Printf("serve listening at %v", lis.Addr())
 	if err := baseServer.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// migrate runs the migrate command, up applies the pending migrations, down reverts the last steps of them, one by
+// default, and status lists every migration with when it was applied.
+func migrate(ctx context.Context, pool *pgxpool.Pool, command string, steps string) {
+	switch command {
+	case "up":
+		applied, err := db.MigrateUp(ctx, pool)
+		if err != nil {
+			log.Fatalf("failed to migrate up: %v", err)
+		}
+		for _, migration := range applied {
+			log.Printf("applied migration: %d_%s", migration.Version, migration.Name)
+		}
+		log.Printf("applied %d migrations", len(applied))
+	case "down":
+		count := 1
+		if steps != "" {
+			var err error
+			if count, err = strconv.Atoi(steps); err != nil || count < 1 {
+				log.Fatalf("expected a positive number of migrations to revert, got: %s", steps)
+			}
+		}
+		reverted, err := db.MigrateDown(ctx, pool, count)
+		if err != nil {
+			log.Fatalf("failed to migrate down: %v", err)
+		}
+		for _, migration := range reverted {
+			log.Printf("reverted migration: %d_%s", migration.Version, migration.Name)
+		}
+		log.Printf("reverted %d migrations", len(reverted))
+	case "status":
+		states, err := db.GetMigrationStates(ctx, pool)
+		if err != nil {
+			log.Fatalf("failed to get the migration status: %v", err)
+		}
+		for _, state := range states {
+			if state.AppliedOn.Valid {
+				log.Printf("migration: %d_%s applied on %s", state.Version, state.Name, state.AppliedOn.Time.Format(time.RFC3339))
+			} else {
+				log.Printf("migration: %d_%s pending", state.Version, state.Name)
+			}
+		}
+	default:
+		log.Fatalf("unknown migrate command: %s, expected up, down or status", command)
+	}
+}
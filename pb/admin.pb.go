@@ -0,0 +1,990 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: pb/admin.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Role          int32                  `protobuf:"varint,2,opt,name=role,proto3" json:"role,omitempty"`
+	RegisteredOn  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=registeredOn,proto3" json:"registeredOn,omitempty"`
+	BannedOn      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=bannedOn,proto3" json:"bannedOn,omitempty"`
+	BanReason     string                 `protobuf:"bytes,5,opt,name=banReason,proto3" json:"banReason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_pb_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *Account) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *Account) GetRegisteredOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredOn
+	}
+	return nil
+}
+
+func (x *Account) GetBannedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedOn
+	}
+	return nil
+}
+
+func (x *Account) GetBanReason() string {
+	if x != nil {
+		return x.BanReason
+	}
+	return ""
+}
+
+type Accounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Accounts) Reset() {
+	*x = Accounts{}
+	mi := &file_pb_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Accounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Accounts) ProtoMessage() {}
+
+func (x *Accounts) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Accounts.ProtoReflect.Descriptor instead.
+func (*Accounts) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Accounts) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type BanPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanPlayerReq) Reset() {
+	*x = BanPlayerReq{}
+	mi := &file_pb_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanPlayerReq) ProtoMessage() {}
+
+func (x *BanPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanPlayerReq.ProtoReflect.Descriptor instead.
+func (*BanPlayerReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BanPlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *BanPlayerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnbanPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanPlayerReq) Reset() {
+	*x = UnbanPlayerReq{}
+	mi := &file_pb_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanPlayerReq) ProtoMessage() {}
+
+func (x *UnbanPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanPlayerReq.ProtoReflect.Descriptor instead.
+func (*UnbanPlayerReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnbanPlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+type SetRoleReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Role          int32                  `protobuf:"varint,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoleReq) Reset() {
+	*x = SetRoleReq{}
+	mi := &file_pb_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleReq) ProtoMessage() {}
+
+func (x *SetRoleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleReq.ProtoReflect.Descriptor instead.
+func (*SetRoleReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetRoleReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *SetRoleReq) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+type ForceEndGameReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Result        int32                  `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceEndGameReq) Reset() {
+	*x = ForceEndGameReq{}
+	mi := &file_pb_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceEndGameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceEndGameReq) ProtoMessage() {}
+
+func (x *ForceEndGameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceEndGameReq.ProtoReflect.Descriptor instead.
+func (*ForceEndGameReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ForceEndGameReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *ForceEndGameReq) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *ForceEndGameReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteMessageReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageReq) Reset() {
+	*x = DeleteMessageReq{}
+	mi := &file_pb_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageReq) ProtoMessage() {}
+
+func (x *DeleteMessageReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageReq.ProtoReflect.Descriptor instead.
+func (*DeleteMessageReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteMessageReq) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *DeleteMessageReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetRegistrationsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,3,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegistrationsReq) Reset() {
+	*x = GetRegistrationsReq{}
+	mi := &file_pb_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegistrationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistrationsReq) ProtoMessage() {}
+
+func (x *GetRegistrationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistrationsReq.ProtoReflect.Descriptor instead.
+func (*GetRegistrationsReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRegistrationsReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetRegistrationsReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetRegistrationsReq) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type GetServerStatsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerStatsReq) Reset() {
+	*x = GetServerStatsReq{}
+	mi := &file_pb_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerStatsReq) ProtoMessage() {}
+
+func (x *GetServerStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerStatsReq.ProtoReflect.Descriptor instead.
+func (*GetServerStatsReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetServerStatsReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type ServerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Players       int64                  `protobuf:"varint,1,opt,name=players,proto3" json:"players,omitempty"`
+	BannedPlayers int64                  `protobuf:"varint,2,opt,name=bannedPlayers,proto3" json:"bannedPlayers,omitempty"`
+	Registrations int64                  `protobuf:"varint,3,opt,name=registrations,proto3" json:"registrations,omitempty"`
+	Sessions      int64                  `protobuf:"varint,4,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	Games         int64                  `protobuf:"varint,5,opt,name=games,proto3" json:"games,omitempty"`
+	ActiveGames   int64                  `protobuf:"varint,6,opt,name=activeGames,proto3" json:"activeGames,omitempty"`
+	Messages      int64                  `protobuf:"varint,7,opt,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStats) Reset() {
+	*x = ServerStats{}
+	mi := &file_pb_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStats) ProtoMessage() {}
+
+func (x *ServerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStats.ProtoReflect.Descriptor instead.
+func (*ServerStats) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ServerStats) GetPlayers() int64 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *ServerStats) GetBannedPlayers() int64 {
+	if x != nil {
+		return x.BannedPlayers
+	}
+	return 0
+}
+
+func (x *ServerStats) GetRegistrations() int64 {
+	if x != nil {
+		return x.Registrations
+	}
+	return 0
+}
+
+func (x *ServerStats) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *ServerStats) GetGames() int64 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *ServerStats) GetActiveGames() int64 {
+	if x != nil {
+		return x.ActiveGames
+	}
+	return 0
+}
+
+func (x *ServerStats) GetMessages() int64 {
+	if x != nil {
+		return x.Messages
+	}
+	return 0
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       int64                  `protobuf:"varint,2,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	TargetId      int64                  `protobuf:"varint,4,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	Peer          string                 `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"`
+	Method        string                 `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	Payload       string                 `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_pb_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+type AuditEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvents) Reset() {
+	*x = AuditEvents{}
+	mi := &file_pb_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvents) ProtoMessage() {}
+
+func (x *AuditEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvents.ProtoReflect.Descriptor instead.
+func (*AuditEvents) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AuditEvents) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type QueryAuditLogReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       int64                  `protobuf:"varint,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,6,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogReq) Reset() {
+	*x = QueryAuditLogReq{}
+	mi := &file_pb_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogReq) ProtoMessage() {}
+
+func (x *QueryAuditLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogReq.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogReq) Descriptor() ([]byte, []int) {
+	return file_pb_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QueryAuditLogReq) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *QueryAuditLogReq) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *QueryAuditLogReq) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *QueryAuditLogReq) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *QueryAuditLogReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryAuditLogReq) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+var File_pb_admin_proto protoreflect.FileDescriptor
+
+const file_pb_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x0epb/admin.proto\x12\aservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11pb/tictacgo.proto\"\xdc\x01\n" +
+	"\aAccount\x12'\n" +
+	"\x06player\x18\x01 \x01(\v2\x0f.service.PlayerR\x06player\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\x05R\x04role\x12>\n" +
+	"\fregisteredOn\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredOn\x126\n" +
+	"\bbannedOn\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bbannedOn\x12\x1c\n" +
+	"\tbanReason\x18\x05 \x01(\tR\tbanReason\"8\n" +
+	"\bAccounts\x12,\n" +
+	"\baccounts\x18\x01 \x03(\v2\x10.service.AccountR\baccounts\"B\n" +
+	"\fBanPlayerReq\x12\x1a\n" +
+	"\bplayerId\x18\x01 \x01(\x03R\bplayerId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\",\n" +
+	"\x0eUnbanPlayerReq\x12\x1a\n" +
+	"\bplayerId\x18\x01 \x01(\x03R\bplayerId\"<\n" +
+	"\n" +
+	"SetRoleReq\x12\x1a\n" +
+	"\bplayerId\x18\x01 \x01(\x03R\bplayerId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\x05R\x04role\"Y\n" +
+	"\x0fForceEndGameReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\x05R\x06result\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"H\n" +
+	"\x10DeleteMessageReq\x12\x1c\n" +
+	"\tmessageId\x18\x01 \x01(\x03R\tmessageId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"u\n" +
+	"\x13GetRegistrationsReq\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x18\n" +
+	"\aperPage\x18\x03 \x01(\x05R\aperPage\"E\n" +
+	"\x11GetServerStatsReq\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\xe3\x01\n" +
+	"\vServerStats\x12\x18\n" +
+	"\aplayers\x18\x01 \x01(\x03R\aplayers\x12$\n" +
+	"\rbannedPlayers\x18\x02 \x01(\x03R\rbannedPlayers\x12$\n" +
+	"\rregistrations\x18\x03 \x01(\x03R\rregistrations\x12\x1a\n" +
+	"\bsessions\x18\x04 \x01(\x03R\bsessions\x12\x14\n" +
+	"\x05games\x18\x05 \x01(\x03R\x05games\x12 \n" +
+	"\vactiveGames\x18\x06 \x01(\x03R\vactiveGames\x12\x1a\n" +
+	"\bmessages\x18\a \x01(\x03R\bmessages\"\xf0\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aactorId\x18\x02 \x01(\x03R\aactorId\x12\x1c\n" +
+	"\teventType\x18\x03 \x01(\tR\teventType\x12\x1a\n" +
+	"\btargetId\x18\x04 \x01(\x03R\btargetId\x12\x12\n" +
+	"\x04peer\x18\x05 \x01(\tR\x04peer\x12\x16\n" +
+	"\x06method\x18\x06 \x01(\tR\x06method\x12\x18\n" +
+	"\apayload\x18\a \x01(\tR\apayload\x128\n" +
+	"\tcreatedOn\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\":\n" +
+	"\vAuditEvents\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.service.AuditEventR\x06events\"\xdc\x01\n" +
+	"\x10QueryAuditLogReq\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\x03R\aactorId\x12\x1c\n" +
+	"\teventType\x18\x02 \x01(\tR\teventType\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x18\n" +
+	"\aperPage\x18\x06 \x01(\x05R\aperPage2\x82\x04\n" +
+	"\fAdminService\x126\n" +
+	"\tBanPlayer\x12\x15.service.BanPlayerReq\x1a\x10.service.Account\"\x00\x12:\n" +
+	"\vUnbanPlayer\x12\x17.service.UnbanPlayerReq\x1a\x10.service.Account\"\x00\x122\n" +
+	"\aSetRole\x12\x13.service.SetRoleReq\x1a\x10.service.Account\"\x00\x129\n" +
+	"\fForceEndGame\x12\x18.service.ForceEndGameReq\x1a\r.service.Game\"\x00\x12>\n" +
+	"\rDeleteMessage\x12\x19.service.DeleteMessageReq\x1a\x10.service.Message\"\x00\x12E\n" +
+	"\x10GetRegistrations\x12\x1c.service.GetRegistrationsReq\x1a\x11.service.Accounts\"\x00\x12D\n" +
+	"\x0eGetServerStats\x12\x1a.service.GetServerStatsReq\x1a\x14.service.ServerStats\"\x00\x12B\n" +
+	"\rQueryAuditLog\x12\x19.service.QueryAuditLogReq\x1a\x14.service.AuditEvents\"\x00B\rZ\vTicTacGo/pbb\x06proto3"
+
+var (
+	file_pb_admin_proto_rawDescOnce sync.Once
+	file_pb_admin_proto_rawDescData []byte
+)
+
+func file_pb_admin_proto_rawDescGZIP() []byte {
+	file_pb_admin_proto_rawDescOnce.Do(func() {
+		file_pb_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_admin_proto_rawDesc), len(file_pb_admin_proto_rawDesc)))
+	})
+	return file_pb_admin_proto_rawDescData
+}
+
+var file_pb_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pb_admin_proto_goTypes = []any{
+	(*Account)(nil),               // 0: service.Account
+	(*Accounts)(nil),              // 1: service.Accounts
+	(*BanPlayerReq)(nil),          // 2: service.BanPlayerReq
+	(*UnbanPlayerReq)(nil),        // 3: service.UnbanPlayerReq
+	(*SetRoleReq)(nil),            // 4: service.SetRoleReq
+	(*ForceEndGameReq)(nil),       // 5: service.ForceEndGameReq
+	(*DeleteMessageReq)(nil),      // 6: service.DeleteMessageReq
+	(*GetRegistrationsReq)(nil),   // 7: service.GetRegistrationsReq
+	(*GetServerStatsReq)(nil),     // 8: service.GetServerStatsReq
+	(*ServerStats)(nil),           // 9: service.ServerStats
+	(*AuditEvent)(nil),            // 10: service.AuditEvent
+	(*AuditEvents)(nil),           // 11: service.AuditEvents
+	(*QueryAuditLogReq)(nil),      // 12: service.QueryAuditLogReq
+	(*Player)(nil),                // 13: service.Player
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*Game)(nil),                  // 15: service.Game
+	(*Message)(nil),               // 16: service.Message
+}
+var file_pb_admin_proto_depIdxs = []int32{
+	13, // 0: service.Account.player:type_name -> service.Player
+	14, // 1: service.Account.registeredOn:type_name -> google.protobuf.Timestamp
+	14, // 2: service.Account.bannedOn:type_name -> google.protobuf.Timestamp
+	0,  // 3: service.Accounts.accounts:type_name -> service.Account
+	14, // 4: service.GetRegistrationsReq.since:type_name -> google.protobuf.Timestamp
+	14, // 5: service.GetServerStatsReq.since:type_name -> google.protobuf.Timestamp
+	14, // 6: service.AuditEvent.createdOn:type_name -> google.protobuf.Timestamp
+	10, // 7: service.AuditEvents.events:type_name -> service.AuditEvent
+	14, // 8: service.QueryAuditLogReq.since:type_name -> google.protobuf.Timestamp
+	14, // 9: service.QueryAuditLogReq.until:type_name -> google.protobuf.Timestamp
+	2,  // 10: service.AdminService.BanPlayer:input_type -> service.BanPlayerReq
+	3,  // 11: service.AdminService.UnbanPlayer:input_type -> service.UnbanPlayerReq
+	4,  // 12: service.AdminService.SetRole:input_type -> service.SetRoleReq
+	5,  // 13: service.AdminService.ForceEndGame:input_type -> service.ForceEndGameReq
+	6,  // 14: service.AdminService.DeleteMessage:input_type -> service.DeleteMessageReq
+	7,  // 15: service.AdminService.GetRegistrations:input_type -> service.GetRegistrationsReq
+	8,  // 16: service.AdminService.GetServerStats:input_type -> service.GetServerStatsReq
+	12, // 17: service.AdminService.QueryAuditLog:input_type -> service.QueryAuditLogReq
+	0,  // 18: service.AdminService.BanPlayer:output_type -> service.Account
+	0,  // 19: service.AdminService.UnbanPlayer:output_type -> service.Account
+	0,  // 20: service.AdminService.SetRole:output_type -> service.Account
+	15, // 21: service.AdminService.ForceEndGame:output_type -> service.Game
+	16, // 22: service.AdminService.DeleteMessage:output_type -> service.Message
+	1,  // 23: service.AdminService.GetRegistrations:output_type -> service.Accounts
+	9,  // 24: service.AdminService.GetServerStats:output_type -> service.ServerStats
+	11, // 25: service.AdminService.QueryAuditLog:output_type -> service.AuditEvents
+	18, // [18:26] is the sub-list for method output_type
+	10, // [10:18] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_pb_admin_proto_init() }
+func file_pb_admin_proto_init() {
+	if File_pb_admin_proto != nil {
+		return
+	}
+	file_pb_tictacgo_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_admin_proto_rawDesc), len(file_pb_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pb_admin_proto_goTypes,
+		DependencyIndexes: file_pb_admin_proto_depIdxs,
+		MessageInfos:      file_pb_admin_proto_msgTypes,
+	}.Build()
+	File_pb_admin_proto = out.File
+	file_pb_admin_proto_goTypes = nil
+	file_pb_admin_proto_depIdxs = nil
+}
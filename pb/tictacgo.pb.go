@@ -127,18 +127,30 @@ func (x *Players) GetPlayers() []*Player {
 }
 
 type Game struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	XPlayer       *Player                `protobuf:"bytes,2,opt,name=xPlayer,proto3" json:"xPlayer,omitempty"`
-	OPlayer       *Player                `protobuf:"bytes,3,opt,name=oPlayer,proto3" json:"oPlayer,omitempty"`
-	BoardState    string                 `protobuf:"bytes,4,opt,name=boardState,proto3" json:"boardState,omitempty"`
-	XTurn         bool                   `protobuf:"varint,5,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
-	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedOn,proto3" json:"updatedOn,omitempty"`
-	StartedOn     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=startedOn,proto3" json:"startedOn,omitempty"`
-	Result        int32                  `protobuf:"varint,8,opt,name=result,proto3" json:"result,omitempty"`
-	Steps         []*Step                `protobuf:"bytes,9,rep,name=steps,proto3" json:"steps,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XPlayer           *Player                `protobuf:"bytes,2,opt,name=xPlayer,proto3" json:"xPlayer,omitempty"`
+	OPlayer           *Player                `protobuf:"bytes,3,opt,name=oPlayer,proto3" json:"oPlayer,omitempty"`
+	BoardState        string                 `protobuf:"bytes,4,opt,name=boardState,proto3" json:"boardState,omitempty"`
+	XTurn             bool                   `protobuf:"varint,5,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
+	UpdatedOn         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedOn,proto3" json:"updatedOn,omitempty"`
+	StartedOn         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=startedOn,proto3" json:"startedOn,omitempty"`
+	Result            int32                  `protobuf:"varint,8,opt,name=result,proto3" json:"result,omitempty"`
+	Steps             []*Step                `protobuf:"bytes,9,rep,name=steps,proto3" json:"steps,omitempty"`
+	HintBudget        int32                  `protobuf:"varint,10,opt,name=hintBudget,proto3" json:"hintBudget,omitempty"`
+	XHintsUsed        int32                  `protobuf:"varint,11,opt,name=xHintsUsed,proto3" json:"xHintsUsed,omitempty"`
+	OHintsUsed        int32                  `protobuf:"varint,12,opt,name=oHintsUsed,proto3" json:"oHintsUsed,omitempty"`
+	StartState        string                 `protobuf:"bytes,13,opt,name=startState,proto3" json:"startState,omitempty"`
+	Opening           *Opening               `protobuf:"bytes,14,opt,name=opening,proto3" json:"opening,omitempty"`
+	TakebacksDisabled bool                   `protobuf:"varint,15,opt,name=takebacksDisabled,proto3" json:"takebacksDisabled,omitempty"`
+	TakebackBy        int64                  `protobuf:"varint,16,opt,name=takebackBy,proto3" json:"takebackBy,omitempty"`
+	RematchOf         int64                  `protobuf:"varint,17,opt,name=rematchOf,proto3" json:"rematchOf,omitempty"`
+	RematchBy         int64                  `protobuf:"varint,18,opt,name=rematchBy,proto3" json:"rematchBy,omitempty"`
+	Series            *Series                `protobuf:"bytes,19,opt,name=series,proto3" json:"series,omitempty"`
+	Messages          []*Message             `protobuf:"bytes,20,rep,name=messages,proto3" json:"messages,omitempty"`
+	EndedBy           int64                  `protobuf:"varint,21,opt,name=endedBy,proto3" json:"endedBy,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Game) Reset() {
@@ -234,27 +246,116 @@ func (x *Game) GetSteps() []*Step {
 	return nil
 }
 
-type Games struct {
+func (x *Game) GetHintBudget() int32 {
+	if x != nil {
+		return x.HintBudget
+	}
+	return 0
+}
+
+func (x *Game) GetXHintsUsed() int32 {
+	if x != nil {
+		return x.XHintsUsed
+	}
+	return 0
+}
+
+func (x *Game) GetOHintsUsed() int32 {
+	if x != nil {
+		return x.OHintsUsed
+	}
+	return 0
+}
+
+func (x *Game) GetStartState() string {
+	if x != nil {
+		return x.StartState
+	}
+	return ""
+}
+
+func (x *Game) GetOpening() *Opening {
+	if x != nil {
+		return x.Opening
+	}
+	return nil
+}
+
+func (x *Game) GetTakebacksDisabled() bool {
+	if x != nil {
+		return x.TakebacksDisabled
+	}
+	return false
+}
+
+func (x *Game) GetTakebackBy() int64 {
+	if x != nil {
+		return x.TakebackBy
+	}
+	return 0
+}
+
+func (x *Game) GetRematchOf() int64 {
+	if x != nil {
+		return x.RematchOf
+	}
+	return 0
+}
+
+func (x *Game) GetRematchBy() int64 {
+	if x != nil {
+		return x.RematchBy
+	}
+	return 0
+}
+
+func (x *Game) GetSeries() *Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *Game) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *Game) GetEndedBy() int64 {
+	if x != nil {
+		return x.EndedBy
+	}
+	return 0
+}
+
+type Message struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Games         []*Game                `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameId        int64                  `protobuf:"varint,2,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Player        *Player                `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Channel       int32                  `protobuf:"varint,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	SentOn        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sentOn,proto3" json:"sentOn,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Games) Reset() {
-	*x = Games{}
+func (x *Message) Reset() {
+	*x = Message{}
 	mi := &file_pb_tictacgo_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Games) String() string {
+func (x *Message) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Games) ProtoMessage() {}
+func (*Message) ProtoMessage() {}
 
-func (x *Games) ProtoReflect() protoreflect.Message {
+func (x *Message) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_tictacgo_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -266,45 +367,76 @@ func (x *Games) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Games.ProtoReflect.Descriptor instead.
-func (*Games) Descriptor() ([]byte, []int) {
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
 	return file_pb_tictacgo_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Games) GetGames() []*Game {
+func (x *Message) GetId() int64 {
 	if x != nil {
-		return x.Games
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Message) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *Message) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
 	}
 	return nil
 }
 
-type Step struct {
+func (x *Message) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetSentOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentOn
+	}
+	return nil
+}
+
+type SendMessageReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
-	Ord           int32                  `protobuf:"varint,2,opt,name=ord,proto3" json:"ord,omitempty"`
-	MoveRow       int32                  `protobuf:"varint,3,opt,name=moveRow,proto3" json:"moveRow,omitempty"`
-	MoveCol       int32                  `protobuf:"varint,4,opt,name=moveCol,proto3" json:"moveCol,omitempty"`
-	Board         string                 `protobuf:"bytes,5,opt,name=board,proto3" json:"board,omitempty"`
-	XTurn         bool                   `protobuf:"varint,6,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
-	Result        int32                  `protobuf:"varint,7,opt,name=result,proto3" json:"result,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Channel       int32                  `protobuf:"varint,3,opt,name=channel,proto3" json:"channel,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Step) Reset() {
-	*x = Step{}
+func (x *SendMessageReq) Reset() {
+	*x = SendMessageReq{}
 	mi := &file_pb_tictacgo_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Step) String() string {
+func (x *SendMessageReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Step) ProtoMessage() {}
+func (*SendMessageReq) ProtoMessage() {}
 
-func (x *Step) ProtoReflect() protoreflect.Message {
+func (x *SendMessageReq) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_tictacgo_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -316,85 +448,2289 @@ func (x *Step) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Step.ProtoReflect.Descriptor instead.
-func (*Step) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendMessageReq.ProtoReflect.Descriptor instead.
+func (*SendMessageReq) Descriptor() ([]byte, []int) {
 	return file_pb_tictacgo_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Step) GetGameId() int64 {
+func (x *SendMessageReq) GetGameId() int64 {
 	if x != nil {
 		return x.GameId
 	}
 	return 0
 }
 
-func (x *Step) GetOrd() int32 {
+func (x *SendMessageReq) GetText() string {
 	if x != nil {
-		return x.Ord
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendMessageReq) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
 	}
 	return 0
 }
 
-func (x *Step) GetMoveRow() int32 {
+type StreamMessagesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Channel       int32                  `protobuf:"varint,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	AfterId       int64                  `protobuf:"varint,3,opt,name=afterId,proto3" json:"afterId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMessagesReq) Reset() {
+	*x = StreamMessagesReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMessagesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMessagesReq) ProtoMessage() {}
+
+func (x *StreamMessagesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[5]
 	if x != nil {
-		return x.MoveRow
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMessagesReq.ProtoReflect.Descriptor instead.
+func (*StreamMessagesReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamMessagesReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
 	}
 	return 0
 }
 
-func (x *Step) GetMoveCol() int32 {
+func (x *StreamMessagesReq) GetChannel() int32 {
 	if x != nil {
-		return x.MoveCol
+		return x.Channel
 	}
 	return 0
 }
 
-func (x *Step) GetBoard() string {
+func (x *StreamMessagesReq) GetAfterId() int64 {
 	if x != nil {
-		return x.Board
+		return x.AfterId
 	}
-	return ""
+	return 0
 }
 
-func (x *Step) GetXTurn() bool {
+type Series struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         int32                  `protobuf:"varint,1,opt,name=games,proto3" json:"games,omitempty"`
+	XPlayerWins   int32                  `protobuf:"varint,2,opt,name=xPlayerWins,proto3" json:"xPlayerWins,omitempty"`
+	OPlayerWins   int32                  `protobuf:"varint,3,opt,name=oPlayerWins,proto3" json:"oPlayerWins,omitempty"`
+	Draws         int32                  `protobuf:"varint,4,opt,name=draws,proto3" json:"draws,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_pb_tictacgo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[6]
 	if x != nil {
-		return x.XTurn
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Series) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *Series) GetXPlayerWins() int32 {
+	if x != nil {
+		return x.XPlayerWins
+	}
+	return 0
+}
+
+func (x *Series) GetOPlayerWins() int32 {
+	if x != nil {
+		return x.OPlayerWins
+	}
+	return 0
+}
+
+func (x *Series) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+type Games struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*Game                `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Games) Reset() {
+	*x = Games{}
+	mi := &file_pb_tictacgo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Games) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Games) ProtoMessage() {}
+
+func (x *Games) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Games.ProtoReflect.Descriptor instead.
+func (*Games) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Games) GetGames() []*Game {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+type Step struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Ord           int32                  `protobuf:"varint,2,opt,name=ord,proto3" json:"ord,omitempty"`
+	MoveRow       int32                  `protobuf:"varint,3,opt,name=moveRow,proto3" json:"moveRow,omitempty"`
+	MoveCol       int32                  `protobuf:"varint,4,opt,name=moveCol,proto3" json:"moveCol,omitempty"`
+	Board         string                 `protobuf:"bytes,5,opt,name=board,proto3" json:"board,omitempty"`
+	XTurn         bool                   `protobuf:"varint,6,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
+	Result        int32                  `protobuf:"varint,7,opt,name=result,proto3" json:"result,omitempty"`
+	Rollback      bool                   `protobuf:"varint,8,opt,name=rollback,proto3" json:"rollback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Step) Reset() {
+	*x = Step{}
+	mi := &file_pb_tictacgo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Step) ProtoMessage() {}
+
+func (x *Step) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Step.ProtoReflect.Descriptor instead.
+func (*Step) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Step) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *Step) GetOrd() int32 {
+	if x != nil {
+		return x.Ord
+	}
+	return 0
+}
+
+func (x *Step) GetMoveRow() int32 {
+	if x != nil {
+		return x.MoveRow
+	}
+	return 0
+}
+
+func (x *Step) GetMoveCol() int32 {
+	if x != nil {
+		return x.MoveCol
+	}
+	return 0
+}
+
+func (x *Step) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *Step) GetXTurn() bool {
+	if x != nil {
+		return x.XTurn
+	}
+	return false
+}
+
+func (x *Step) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *Step) GetRollback() bool {
+	if x != nil {
+		return x.Rollback
+	}
+	return false
+}
+
+type GetGamesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	XPlayer       *Player                `protobuf:"bytes,2,opt,name=xPlayer,proto3" json:"xPlayer,omitempty"`
+	OPlayer       *Player                `protobuf:"bytes,3,opt,name=oPlayer,proto3" json:"oPlayer,omitempty"`
+	PerPage       int32                  `protobuf:"varint,4,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGamesReq) Reset() {
+	*x = GetGamesReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGamesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGamesReq) ProtoMessage() {}
+
+func (x *GetGamesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGamesReq.ProtoReflect.Descriptor instead.
+func (*GetGamesReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetGamesReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetGamesReq) GetXPlayer() *Player {
+	if x != nil {
+		return x.XPlayer
+	}
+	return nil
+}
+
+func (x *GetGamesReq) GetOPlayer() *Player {
+	if x != nil {
+		return x.OPlayer
+	}
+	return nil
+}
+
+func (x *GetGamesReq) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type GetPlayersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,2,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayersReq) Reset() {
+	*x = GetPlayersReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayersReq) ProtoMessage() {}
+
+func (x *GetPlayersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayersReq.ProtoReflect.Descriptor instead.
+func (*GetPlayersReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPlayersReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPlayersReq) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type GetGameReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameReq) Reset() {
+	*x = GetGameReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameReq) ProtoMessage() {}
+
+func (x *GetGameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameReq.ProtoReflect.Descriptor instead.
+func (*GetGameReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetGameReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateGameReq struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	HintBudget       int32                  `protobuf:"varint,1,opt,name=hintBudget,proto3" json:"hintBudget,omitempty"`
+	BoardState       string                 `protobuf:"bytes,2,opt,name=boardState,proto3" json:"boardState,omitempty"`
+	XTurn            bool                   `protobuf:"varint,3,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
+	DisableTakebacks bool                   `protobuf:"varint,4,opt,name=disableTakebacks,proto3" json:"disableTakebacks,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateGameReq) Reset() {
+	*x = CreateGameReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameReq) ProtoMessage() {}
+
+func (x *CreateGameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameReq.ProtoReflect.Descriptor instead.
+func (*CreateGameReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateGameReq) GetHintBudget() int32 {
+	if x != nil {
+		return x.HintBudget
+	}
+	return 0
+}
+
+func (x *CreateGameReq) GetBoardState() string {
+	if x != nil {
+		return x.BoardState
+	}
+	return ""
+}
+
+func (x *CreateGameReq) GetXTurn() bool {
+	if x != nil {
+		return x.XTurn
+	}
+	return false
+}
+
+func (x *CreateGameReq) GetDisableTakebacks() bool {
+	if x != nil {
+		return x.DisableTakebacks
+	}
+	return false
+}
+
+type MakeMoveReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Row    int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col    int32                  `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	GameId int64                  `protobuf:"varint,3,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	// the ord the move is expected to get, one past the last step seen, the move is not checked when unset
+	Ord           *int32 `protobuf:"varint,4,opt,name=ord,proto3,oneof" json:"ord,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeMoveReq) Reset() {
+	*x = MakeMoveReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeMoveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeMoveReq) ProtoMessage() {}
+
+func (x *MakeMoveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeMoveReq.ProtoReflect.Descriptor instead.
+func (*MakeMoveReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MakeMoveReq) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *MakeMoveReq) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *MakeMoveReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *MakeMoveReq) GetOrd() int32 {
+	if x != nil && x.Ord != nil {
+		return *x.Ord
+	}
+	return 0
+}
+
+type RequestTakebackReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTakebackReq) Reset() {
+	*x = RequestTakebackReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTakebackReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTakebackReq) ProtoMessage() {}
+
+func (x *RequestTakebackReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTakebackReq.ProtoReflect.Descriptor instead.
+func (*RequestTakebackReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequestTakebackReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type RespondTakebackReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondTakebackReq) Reset() {
+	*x = RespondTakebackReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondTakebackReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondTakebackReq) ProtoMessage() {}
+
+func (x *RespondTakebackReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondTakebackReq.ProtoReflect.Descriptor instead.
+func (*RespondTakebackReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RespondTakebackReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *RespondTakebackReq) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type OfferRematchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferRematchReq) Reset() {
+	*x = OfferRematchReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferRematchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferRematchReq) ProtoMessage() {}
+
+func (x *OfferRematchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferRematchReq.ProtoReflect.Descriptor instead.
+func (*OfferRematchReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OfferRematchReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type RespondRematchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Accept        bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondRematchReq) Reset() {
+	*x = RespondRematchReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondRematchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondRematchReq) ProtoMessage() {}
+
+func (x *RespondRematchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondRematchReq.ProtoReflect.Descriptor instead.
+func (*RespondRematchReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RespondRematchReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *RespondRematchReq) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type CredentialsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialsReq) Reset() {
+	*x = CredentialsReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialsReq) ProtoMessage() {}
+
+func (x *CredentialsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialsReq.ProtoReflect.Descriptor instead.
+func (*CredentialsReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CredentialsReq) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CredentialsReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResp struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Player           *Player                `protobuf:"bytes,2,opt,name=Player,proto3" json:"Player,omitempty"`
+	ExpiresOn        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiresOn,proto3" json:"expiresOn,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,4,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	RefreshExpiresOn *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=refreshExpiresOn,proto3" json:"refreshExpiresOn,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LoginResp) Reset() {
+	*x = LoginResp{}
+	mi := &file_pb_tictacgo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResp) ProtoMessage() {}
+
+func (x *LoginResp) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResp.ProtoReflect.Descriptor instead.
+func (*LoginResp) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LoginResp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginResp) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *LoginResp) GetExpiresOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return nil
+}
+
+func (x *LoginResp) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResp) GetRefreshExpiresOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpiresOn
+	}
+	return nil
+}
+
+type ChangePasswordReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword   string                 `protobuf:"bytes,1,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordReq) Reset() {
+	*x = ChangePasswordReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordReq) ProtoMessage() {}
+
+func (x *ChangePasswordReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordReq.ProtoReflect.Descriptor instead.
+func (*ChangePasswordReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChangePasswordReq) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordReq) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type DeleteAccountReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountReq) Reset() {
+	*x = DeleteAccountReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountReq) ProtoMessage() {}
+
+func (x *DeleteAccountReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountReq.ProtoReflect.Descriptor instead.
+func (*DeleteAccountReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteAccountReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RefreshTokenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenReq) Reset() {
+	*x = RefreshTokenReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenReq) ProtoMessage() {}
+
+func (x *RefreshTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenReq.ProtoReflect.Descriptor instead.
+func (*RefreshTokenReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RefreshTokenReq) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutReq) Reset() {
+	*x = LogoutReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutReq) ProtoMessage() {}
+
+func (x *LogoutReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutReq.ProtoReflect.Descriptor instead.
+func (*LogoutReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{23}
+}
+
+type LogoutAllReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllReq) Reset() {
+	*x = LogoutAllReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllReq) ProtoMessage() {}
+
+func (x *LogoutAllReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllReq.ProtoReflect.Descriptor instead.
+func (*LogoutAllReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{24}
+}
+
+type LogoutResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      int64                  `protobuf:"varint,1,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResp) Reset() {
+	*x = LogoutResp{}
+	mi := &file_pb_tictacgo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResp) ProtoMessage() {}
+
+func (x *LogoutResp) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResp.ProtoReflect.Descriptor instead.
+func (*LogoutResp) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LogoutResp) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+type ListSessionsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsReq) Reset() {
+	*x = ListSessionsReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsReq) ProtoMessage() {}
+
+func (x *ListSessionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsReq.ProtoReflect.Descriptor instead.
+func (*ListSessionsReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{26}
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	LastSeenOn    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lastSeenOn,proto3" json:"lastSeenOn,omitempty"`
+	ExpiresOn     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiresOn,proto3" json:"expiresOn,omitempty"`
+	Current       bool                   `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_pb_tictacgo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Session) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Session) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *Session) GetLastSeenOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenOn
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type Sessions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sessions) Reset() {
+	*x = Sessions{}
+	mi := &file_pb_tictacgo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sessions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sessions) ProtoMessage() {}
+
+func (x *Sessions) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sessions.ProtoReflect.Descriptor instead.
+func (*Sessions) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Sessions) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type WhoAmIReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIReq) Reset() {
+	*x = WhoAmIReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIReq) ProtoMessage() {}
+
+func (x *WhoAmIReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoAmIReq.ProtoReflect.Descriptor instead.
+func (*WhoAmIReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{29}
+}
+
+type ListenStepsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenStepsReq) Reset() {
+	*x = ListenStepsReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenStepsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenStepsReq) ProtoMessage() {}
+
+func (x *ListenStepsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenStepsReq.ProtoReflect.Descriptor instead.
+func (*ListenStepsReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListenStepsReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AnalyzeGameReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeGameReq) Reset() {
+	*x = AnalyzeGameReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeGameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeGameReq) ProtoMessage() {}
+
+func (x *AnalyzeGameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeGameReq.ProtoReflect.Descriptor instead.
+func (*AnalyzeGameReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AnalyzeGameReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type Evaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        int32                  `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Distance      int32                  `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Evaluation) Reset() {
+	*x = Evaluation{}
+	mi := &file_pb_tictacgo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evaluation) ProtoMessage() {}
+
+func (x *Evaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evaluation.ProtoReflect.Descriptor instead.
+func (*Evaluation) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Evaluation) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *Evaluation) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type MoveAnalysis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ord           int32                  `protobuf:"varint,1,opt,name=ord,proto3" json:"ord,omitempty"`
+	MoveRow       int32                  `protobuf:"varint,2,opt,name=moveRow,proto3" json:"moveRow,omitempty"`
+	MoveCol       int32                  `protobuf:"varint,3,opt,name=moveCol,proto3" json:"moveCol,omitempty"`
+	XMoved        bool                   `protobuf:"varint,4,opt,name=xMoved,proto3" json:"xMoved,omitempty"`
+	Best          *Evaluation            `protobuf:"bytes,5,opt,name=best,proto3" json:"best,omitempty"`
+	Played        *Evaluation            `protobuf:"bytes,6,opt,name=played,proto3" json:"played,omitempty"`
+	Annotation    int32                  `protobuf:"varint,7,opt,name=annotation,proto3" json:"annotation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveAnalysis) Reset() {
+	*x = MoveAnalysis{}
+	mi := &file_pb_tictacgo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveAnalysis) ProtoMessage() {}
+
+func (x *MoveAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveAnalysis.ProtoReflect.Descriptor instead.
+func (*MoveAnalysis) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MoveAnalysis) GetOrd() int32 {
+	if x != nil {
+		return x.Ord
+	}
+	return 0
+}
+
+func (x *MoveAnalysis) GetMoveRow() int32 {
+	if x != nil {
+		return x.MoveRow
+	}
+	return 0
+}
+
+func (x *MoveAnalysis) GetMoveCol() int32 {
+	if x != nil {
+		return x.MoveCol
+	}
+	return 0
+}
+
+func (x *MoveAnalysis) GetXMoved() bool {
+	if x != nil {
+		return x.XMoved
+	}
+	return false
+}
+
+func (x *MoveAnalysis) GetBest() *Evaluation {
+	if x != nil {
+		return x.Best
+	}
+	return nil
+}
+
+func (x *MoveAnalysis) GetPlayed() *Evaluation {
+	if x != nil {
+		return x.Played
+	}
+	return nil
+}
+
+func (x *MoveAnalysis) GetAnnotation() int32 {
+	if x != nil {
+		return x.Annotation
+	}
+	return 0
+}
+
+type GetHintReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHintReq) Reset() {
+	*x = GetHintReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHintReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHintReq) ProtoMessage() {}
+
+func (x *GetHintReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHintReq.ProtoReflect.Descriptor instead.
+func (*GetHintReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetHintReq) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type HintMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col           int32                  `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	Outcome       *Evaluation            `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HintMove) Reset() {
+	*x = HintMove{}
+	mi := &file_pb_tictacgo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintMove) ProtoMessage() {}
+
+func (x *HintMove) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintMove.ProtoReflect.Descriptor instead.
+func (*HintMove) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *HintMove) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *HintMove) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *HintMove) GetOutcome() *Evaluation {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+type Hint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Moves         []*HintMove            `protobuf:"bytes,2,rep,name=moves,proto3" json:"moves,omitempty"`
+	HintsUsed     int32                  `protobuf:"varint,3,opt,name=hintsUsed,proto3" json:"hintsUsed,omitempty"`
+	HintBudget    int32                  `protobuf:"varint,4,opt,name=hintBudget,proto3" json:"hintBudget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hint) Reset() {
+	*x = Hint{}
+	mi := &file_pb_tictacgo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hint) ProtoMessage() {}
+
+func (x *Hint) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hint.ProtoReflect.Descriptor instead.
+func (*Hint) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Hint) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *Hint) GetMoves() []*HintMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *Hint) GetHintsUsed() int32 {
+	if x != nil {
+		return x.HintsUsed
+	}
+	return 0
+}
+
+func (x *Hint) GetHintBudget() int32 {
+	if x != nil {
+		return x.HintBudget
+	}
+	return 0
+}
+
+type Tile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col           int32                  `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tile) Reset() {
+	*x = Tile{}
+	mi := &file_pb_tictacgo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tile) ProtoMessage() {}
+
+func (x *Tile) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tile.ProtoReflect.Descriptor instead.
+func (*Tile) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Tile) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *Tile) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+type Puzzle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BoardState    string                 `protobuf:"bytes,2,opt,name=boardState,proto3" json:"boardState,omitempty"`
+	XTurn         bool                   `protobuf:"varint,3,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	Difficulty    int32                  `protobuf:"varint,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Puzzle) Reset() {
+	*x = Puzzle{}
+	mi := &file_pb_tictacgo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Puzzle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Puzzle) ProtoMessage() {}
+
+func (x *Puzzle) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Puzzle.ProtoReflect.Descriptor instead.
+func (*Puzzle) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Puzzle) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Puzzle) GetBoardState() string {
+	if x != nil {
+		return x.BoardState
+	}
+	return ""
+}
+
+func (x *Puzzle) GetXTurn() bool {
+	if x != nil {
+		return x.XTurn
+	}
+	return false
+}
+
+func (x *Puzzle) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Puzzle) GetDifficulty() int32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+type GetPuzzleReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPuzzleReq) Reset() {
+	*x = GetPuzzleReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPuzzleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPuzzleReq) ProtoMessage() {}
+
+func (x *GetPuzzleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPuzzleReq.ProtoReflect.Descriptor instead.
+func (*GetPuzzleReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetPuzzleReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SubmitPuzzleMoveReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PuzzleId      int64                  `protobuf:"varint,1,opt,name=puzzleId,proto3" json:"puzzleId,omitempty"`
+	Row           int32                  `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Col           int32                  `protobuf:"varint,3,opt,name=col,proto3" json:"col,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPuzzleMoveReq) Reset() {
+	*x = SubmitPuzzleMoveReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPuzzleMoveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPuzzleMoveReq) ProtoMessage() {}
+
+func (x *SubmitPuzzleMoveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPuzzleMoveReq.ProtoReflect.Descriptor instead.
+func (*SubmitPuzzleMoveReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SubmitPuzzleMoveReq) GetPuzzleId() int64 {
+	if x != nil {
+		return x.PuzzleId
+	}
+	return 0
+}
+
+func (x *SubmitPuzzleMoveReq) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *SubmitPuzzleMoveReq) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+type PuzzleAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PuzzleId      int64                  `protobuf:"varint,1,opt,name=puzzleId,proto3" json:"puzzleId,omitempty"`
+	BoardState    string                 `protobuf:"bytes,2,opt,name=boardState,proto3" json:"boardState,omitempty"`
+	XTurn         bool                   `protobuf:"varint,3,opt,name=xTurn,proto3" json:"xTurn,omitempty"`
+	MovesLeft     int32                  `protobuf:"varint,4,opt,name=movesLeft,proto3" json:"movesLeft,omitempty"`
+	Status        int32                  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	Reply         *Tile                  `protobuf:"bytes,6,opt,name=reply,proto3" json:"reply,omitempty"`
+	Solution      *Tile                  `protobuf:"bytes,7,opt,name=solution,proto3" json:"solution,omitempty"`
+	Streak        int32                  `protobuf:"varint,8,opt,name=streak,proto3" json:"streak,omitempty"`
+	BestStreak    int32                  `protobuf:"varint,9,opt,name=bestStreak,proto3" json:"bestStreak,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PuzzleAttempt) Reset() {
+	*x = PuzzleAttempt{}
+	mi := &file_pb_tictacgo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PuzzleAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PuzzleAttempt) ProtoMessage() {}
+
+func (x *PuzzleAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PuzzleAttempt.ProtoReflect.Descriptor instead.
+func (*PuzzleAttempt) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PuzzleAttempt) GetPuzzleId() int64 {
+	if x != nil {
+		return x.PuzzleId
+	}
+	return 0
+}
+
+func (x *PuzzleAttempt) GetBoardState() string {
+	if x != nil {
+		return x.BoardState
+	}
+	return ""
+}
+
+func (x *PuzzleAttempt) GetXTurn() bool {
+	if x != nil {
+		return x.XTurn
+	}
+	return false
+}
+
+func (x *PuzzleAttempt) GetMovesLeft() int32 {
+	if x != nil {
+		return x.MovesLeft
+	}
+	return 0
+}
+
+func (x *PuzzleAttempt) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *PuzzleAttempt) GetReply() *Tile {
+	if x != nil {
+		return x.Reply
+	}
+	return nil
+}
+
+func (x *PuzzleAttempt) GetSolution() *Tile {
+	if x != nil {
+		return x.Solution
+	}
+	return nil
+}
+
+func (x *PuzzleAttempt) GetStreak() int32 {
+	if x != nil {
+		return x.Streak
+	}
+	return 0
+}
+
+func (x *PuzzleAttempt) GetBestStreak() int32 {
+	if x != nil {
+		return x.BestStreak
+	}
+	return 0
+}
+
+type GetDailyPuzzleReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyPuzzleReq) Reset() {
+	*x = GetDailyPuzzleReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyPuzzleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyPuzzleReq) ProtoMessage() {}
+
+func (x *GetDailyPuzzleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyPuzzleReq.ProtoReflect.Descriptor instead.
+func (*GetDailyPuzzleReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{42}
+}
+
+type DailyPuzzle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Puzzle        *Puzzle                `protobuf:"bytes,2,opt,name=puzzle,proto3" json:"puzzle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyPuzzle) Reset() {
+	*x = DailyPuzzle{}
+	mi := &file_pb_tictacgo_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyPuzzle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyPuzzle) ProtoMessage() {}
+
+func (x *DailyPuzzle) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyPuzzle.ProtoReflect.Descriptor instead.
+func (*DailyPuzzle) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DailyPuzzle) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *DailyPuzzle) GetPuzzle() *Puzzle {
+	if x != nil {
+		return x.Puzzle
+	}
+	return nil
+}
+
+type GetDailyLeaderboardReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyLeaderboardReq) Reset() {
+	*x = GetDailyLeaderboardReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyLeaderboardReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyLeaderboardReq) ProtoMessage() {}
+
+func (x *GetDailyLeaderboardReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyLeaderboardReq.ProtoReflect.Descriptor instead.
+func (*GetDailyLeaderboardReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetDailyLeaderboardReq) GetDay() string {
+	if x != nil {
+		return x.Day
 	}
-	return false
+	return ""
 }
 
-func (x *Step) GetResult() int32 {
+func (x *GetDailyLeaderboardReq) GetLimit() int32 {
 	if x != nil {
-		return x.Result
+		return x.Limit
 	}
 	return 0
 }
 
-type GetGamesReq struct {
+type LeaderboardEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	XPlayer       *Player                `protobuf:"bytes,2,opt,name=xPlayer,proto3" json:"xPlayer,omitempty"`
-	OPlayer       *Player                `protobuf:"bytes,3,opt,name=oPlayer,proto3" json:"oPlayer,omitempty"`
-	PerPage       int32                  `protobuf:"varint,4,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Player        *Player                `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,3,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGamesReq) Reset() {
-	*x = GetGamesReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[5]
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_pb_tictacgo_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGamesReq) String() string {
+func (x *LeaderboardEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGamesReq) ProtoMessage() {}
+func (*LeaderboardEntry) ProtoMessage() {}
 
-func (x *GetGamesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[5]
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,62 +2741,55 @@ func (x *GetGamesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGamesReq.ProtoReflect.Descriptor instead.
-func (*GetGamesReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetGamesReq) GetPage() int32 {
+func (x *LeaderboardEntry) GetRank() int32 {
 	if x != nil {
-		return x.Page
+		return x.Rank
 	}
 	return 0
 }
 
-func (x *GetGamesReq) GetXPlayer() *Player {
-	if x != nil {
-		return x.XPlayer
-	}
-	return nil
-}
-
-func (x *GetGamesReq) GetOPlayer() *Player {
+func (x *LeaderboardEntry) GetPlayer() *Player {
 	if x != nil {
-		return x.OPlayer
+		return x.Player
 	}
 	return nil
 }
 
-func (x *GetGamesReq) GetPerPage() int32 {
+func (x *LeaderboardEntry) GetDurationMs() int64 {
 	if x != nil {
-		return x.PerPage
+		return x.DurationMs
 	}
 	return 0
 }
 
-type GetPlayersReq struct {
+type DailyLeaderboard struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PerPage       int32                  `protobuf:"varint,2,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPlayersReq) Reset() {
-	*x = GetPlayersReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[6]
+func (x *DailyLeaderboard) Reset() {
+	*x = DailyLeaderboard{}
+	mi := &file_pb_tictacgo_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPlayersReq) String() string {
+func (x *DailyLeaderboard) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPlayersReq) ProtoMessage() {}
+func (*DailyLeaderboard) ProtoMessage() {}
 
-func (x *GetPlayersReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[6]
+func (x *DailyLeaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,47 +2800,49 @@ func (x *GetPlayersReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPlayersReq.ProtoReflect.Descriptor instead.
-func (*GetPlayersReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DailyLeaderboard.ProtoReflect.Descriptor instead.
+func (*DailyLeaderboard) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *GetPlayersReq) GetPage() int32 {
+func (x *DailyLeaderboard) GetDay() string {
 	if x != nil {
-		return x.Page
+		return x.Day
 	}
-	return 0
+	return ""
 }
 
-func (x *GetPlayersReq) GetPerPage() int32 {
+func (x *DailyLeaderboard) GetEntries() []*LeaderboardEntry {
 	if x != nil {
-		return x.PerPage
+		return x.Entries
 	}
-	return 0
+	return nil
 }
 
-type GetGameReq struct {
+type Opening struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Family        string                 `protobuf:"bytes,3,opt,name=family,proto3" json:"family,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGameReq) Reset() {
-	*x = GetGameReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[7]
+func (x *Opening) Reset() {
+	*x = Opening{}
+	mi := &file_pb_tictacgo_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGameReq) String() string {
+func (x *Opening) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGameReq) ProtoMessage() {}
+func (*Opening) ProtoMessage() {}
 
-func (x *GetGameReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[7]
+func (x *Opening) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,39 +2853,54 @@ func (x *GetGameReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGameReq.ProtoReflect.Descriptor instead.
-func (*GetGameReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Opening.ProtoReflect.Descriptor instead.
+func (*Opening) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetGameReq) GetId() int64 {
+func (x *Opening) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type CreateGameReq struct {
+func (x *Opening) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Opening) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+type GetOpeningStatsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGameReq) Reset() {
-	*x = CreateGameReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[8]
+func (x *GetOpeningStatsReq) Reset() {
+	*x = GetOpeningStatsReq{}
+	mi := &file_pb_tictacgo_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameReq) String() string {
+func (x *GetOpeningStatsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameReq) ProtoMessage() {}
+func (*GetOpeningStatsReq) ProtoMessage() {}
 
-func (x *CreateGameReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[8]
+func (x *GetOpeningStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,35 +2911,48 @@ func (x *CreateGameReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameReq.ProtoReflect.Descriptor instead.
-func (*CreateGameReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetOpeningStatsReq.ProtoReflect.Descriptor instead.
+func (*GetOpeningStatsReq) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{48}
 }
 
-type MakeMoveReq struct {
+func (x *GetOpeningStatsReq) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type OpeningStat struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
-	Col           int32                  `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
-	GameId        int64                  `protobuf:"varint,3,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Opening       *Opening               `protobuf:"bytes,1,opt,name=opening,proto3" json:"opening,omitempty"`
+	Games         int64                  `protobuf:"varint,2,opt,name=games,proto3" json:"games,omitempty"`
+	Wins          int64                  `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Draws         int64                  `protobuf:"varint,4,opt,name=draws,proto3" json:"draws,omitempty"`
+	Losses        int64                  `protobuf:"varint,5,opt,name=losses,proto3" json:"losses,omitempty"`
+	Frequency     float32                `protobuf:"fixed32,6,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	WinRate       float32                `protobuf:"fixed32,7,opt,name=winRate,proto3" json:"winRate,omitempty"`
+	DrawRate      float32                `protobuf:"fixed32,8,opt,name=drawRate,proto3" json:"drawRate,omitempty"`
+	LossRate      float32                `protobuf:"fixed32,9,opt,name=lossRate,proto3" json:"lossRate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MakeMoveReq) Reset() {
-	*x = MakeMoveReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[9]
+func (x *OpeningStat) Reset() {
+	*x = OpeningStat{}
+	mi := &file_pb_tictacgo_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MakeMoveReq) String() string {
+func (x *OpeningStat) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MakeMoveReq) ProtoMessage() {}
+func (*OpeningStat) ProtoMessage() {}
 
-func (x *MakeMoveReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[9]
+func (x *OpeningStat) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,107 +2963,97 @@ func (x *MakeMoveReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MakeMoveReq.ProtoReflect.Descriptor instead.
-func (*MakeMoveReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use OpeningStat.ProtoReflect.Descriptor instead.
+func (*OpeningStat) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *MakeMoveReq) GetRow() int32 {
+func (x *OpeningStat) GetOpening() *Opening {
 	if x != nil {
-		return x.Row
+		return x.Opening
 	}
-	return 0
+	return nil
 }
 
-func (x *MakeMoveReq) GetCol() int32 {
+func (x *OpeningStat) GetGames() int64 {
 	if x != nil {
-		return x.Col
+		return x.Games
 	}
 	return 0
 }
 
-func (x *MakeMoveReq) GetGameId() int64 {
+func (x *OpeningStat) GetWins() int64 {
 	if x != nil {
-		return x.GameId
+		return x.Wins
 	}
 	return 0
 }
 
-type CredentialsReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CredentialsReq) Reset() {
-	*x = CredentialsReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *OpeningStat) GetDraws() int64 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
 }
 
-func (x *CredentialsReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *OpeningStat) GetLosses() int64 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
 }
 
-func (*CredentialsReq) ProtoMessage() {}
-
-func (x *CredentialsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[10]
+func (x *OpeningStat) GetFrequency() float32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Frequency
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CredentialsReq.ProtoReflect.Descriptor instead.
-func (*CredentialsReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{10}
+func (x *OpeningStat) GetWinRate() float32 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
 }
 
-func (x *CredentialsReq) GetUsername() string {
+func (x *OpeningStat) GetDrawRate() float32 {
 	if x != nil {
-		return x.Username
+		return x.DrawRate
 	}
-	return ""
+	return 0
 }
 
-func (x *CredentialsReq) GetPassword() string {
+func (x *OpeningStat) GetLossRate() float32 {
 	if x != nil {
-		return x.Password
+		return x.LossRate
 	}
-	return ""
+	return 0
 }
 
-type LoginResp struct {
+type OpeningStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Player        *Player                `protobuf:"bytes,2,opt,name=Player,proto3" json:"Player,omitempty"`
+	Stats         []*OpeningStat         `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	Games         int64                  `protobuf:"varint,2,opt,name=games,proto3" json:"games,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginResp) Reset() {
-	*x = LoginResp{}
-	mi := &file_pb_tictacgo_proto_msgTypes[11]
+func (x *OpeningStats) Reset() {
+	*x = OpeningStats{}
+	mi := &file_pb_tictacgo_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginResp) String() string {
+func (x *OpeningStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResp) ProtoMessage() {}
+func (*OpeningStats) ProtoMessage() {}
 
-func (x *LoginResp) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[11]
+func (x *OpeningStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,46 +3064,50 @@ func (x *LoginResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResp.ProtoReflect.Descriptor instead.
-func (*LoginResp) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use OpeningStats.ProtoReflect.Descriptor instead.
+func (*OpeningStats) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *LoginResp) GetToken() string {
+func (x *OpeningStats) GetStats() []*OpeningStat {
 	if x != nil {
-		return x.Token
+		return x.Stats
 	}
-	return ""
+	return nil
 }
 
-func (x *LoginResp) GetPlayer() *Player {
+func (x *OpeningStats) GetGames() int64 {
 	if x != nil {
-		return x.Player
+		return x.Games
 	}
-	return nil
+	return 0
 }
 
-type WhoAmIReq struct {
+type GameAnalysis struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int64                  `protobuf:"varint,1,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	Moves         []*MoveAnalysis        `protobuf:"bytes,2,rep,name=moves,proto3" json:"moves,omitempty"`
+	XAccuracy     float32                `protobuf:"fixed32,3,opt,name=xAccuracy,proto3" json:"xAccuracy,omitempty"`
+	OAccuracy     float32                `protobuf:"fixed32,4,opt,name=oAccuracy,proto3" json:"oAccuracy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WhoAmIReq) Reset() {
-	*x = WhoAmIReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[12]
+func (x *GameAnalysis) Reset() {
+	*x = GameAnalysis{}
+	mi := &file_pb_tictacgo_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WhoAmIReq) String() string {
+func (x *GameAnalysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhoAmIReq) ProtoMessage() {}
+func (*GameAnalysis) ProtoMessage() {}
 
-func (x *WhoAmIReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[12]
+func (x *GameAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_tictacgo_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,51 +3118,35 @@ func (x *WhoAmIReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhoAmIReq.ProtoReflect.Descriptor instead.
-func (*WhoAmIReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{12}
-}
-
-type ListenStepsReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListenStepsReq) Reset() {
-	*x = ListenStepsReq{}
-	mi := &file_pb_tictacgo_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+// Deprecated: Use GameAnalysis.ProtoReflect.Descriptor instead.
+func (*GameAnalysis) Descriptor() ([]byte, []int) {
+	return file_pb_tictacgo_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListenStepsReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GameAnalysis) GetGameId() int64 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
 }
 
-func (*ListenStepsReq) ProtoMessage() {}
-
-func (x *ListenStepsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_tictacgo_proto_msgTypes[13]
+func (x *GameAnalysis) GetMoves() []*MoveAnalysis {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Moves
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListenStepsReq.ProtoReflect.Descriptor instead.
-func (*ListenStepsReq) Descriptor() ([]byte, []int) {
-	return file_pb_tictacgo_proto_rawDescGZIP(), []int{13}
+func (x *GameAnalysis) GetXAccuracy() float32 {
+	if x != nil {
+		return x.XAccuracy
+	}
+	return 0
 }
 
-func (x *ListenStepsReq) GetId() int64 {
+func (x *GameAnalysis) GetOAccuracy() float32 {
 	if x != nil {
-		return x.Id
+		return x.OAccuracy
 	}
 	return 0
 }
@@ -824,7 +3161,7 @@ const file_pb_tictacgo_proto_rawDesc = "" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
 	"\x03cnt\x18\x03 \x01(\x05R\x03cnt\"4\n" +
 	"\aPlayers\x12)\n" +
-	"\aplayers\x18\x01 \x03(\v2\x0f.service.PlayerR\aplayers\"\xd3\x02\n" +
+	"\aplayers\x18\x01 \x03(\v2\x0f.service.PlayerR\aplayers\"\xfa\x05\n" +
 	"\x04Game\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
 	"\axPlayer\x18\x02 \x01(\v2\x0f.service.PlayerR\axPlayer\x12)\n" +
@@ -836,9 +3173,52 @@ const file_pb_tictacgo_proto_rawDesc = "" +
 	"\tupdatedOn\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\x128\n" +
 	"\tstartedOn\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedOn\x12\x16\n" +
 	"\x06result\x18\b \x01(\x05R\x06result\x12#\n" +
-	"\x05steps\x18\t \x03(\v2\r.service.StepR\x05steps\",\n" +
+	"\x05steps\x18\t \x03(\v2\r.service.StepR\x05steps\x12\x1e\n" +
+	"\n" +
+	"hintBudget\x18\n" +
+	" \x01(\x05R\n" +
+	"hintBudget\x12\x1e\n" +
+	"\n" +
+	"xHintsUsed\x18\v \x01(\x05R\n" +
+	"xHintsUsed\x12\x1e\n" +
+	"\n" +
+	"oHintsUsed\x18\f \x01(\x05R\n" +
+	"oHintsUsed\x12\x1e\n" +
+	"\n" +
+	"startState\x18\r \x01(\tR\n" +
+	"startState\x12*\n" +
+	"\aopening\x18\x0e \x01(\v2\x10.service.OpeningR\aopening\x12,\n" +
+	"\x11takebacksDisabled\x18\x0f \x01(\bR\x11takebacksDisabled\x12\x1e\n" +
+	"\n" +
+	"takebackBy\x18\x10 \x01(\x03R\n" +
+	"takebackBy\x12\x1c\n" +
+	"\trematchOf\x18\x11 \x01(\x03R\trematchOf\x12\x1c\n" +
+	"\trematchBy\x18\x12 \x01(\x03R\trematchBy\x12'\n" +
+	"\x06series\x18\x13 \x01(\v2\x0f.service.SeriesR\x06series\x12,\n" +
+	"\bmessages\x18\x14 \x03(\v2\x10.service.MessageR\bmessages\x12\x18\n" +
+	"\aendedBy\x18\x15 \x01(\x03R\aendedBy\"\xbc\x01\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06gameId\x18\x02 \x01(\x03R\x06gameId\x12'\n" +
+	"\x06player\x18\x03 \x01(\v2\x0f.service.PlayerR\x06player\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\x05R\achannel\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x122\n" +
+	"\x06sentOn\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06sentOn\"V\n" +
+	"\x0eSendMessageReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\x05R\achannel\"_\n" +
+	"\x11StreamMessagesReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\x05R\achannel\x12\x18\n" +
+	"\aafterId\x18\x03 \x01(\x03R\aafterId\"x\n" +
+	"\x06Series\x12\x14\n" +
+	"\x05games\x18\x01 \x01(\x05R\x05games\x12 \n" +
+	"\vxPlayerWins\x18\x02 \x01(\x05R\vxPlayerWins\x12 \n" +
+	"\voPlayerWins\x18\x03 \x01(\x05R\voPlayerWins\x12\x14\n" +
+	"\x05draws\x18\x04 \x01(\x05R\x05draws\",\n" +
 	"\x05Games\x12#\n" +
-	"\x05games\x18\x01 \x03(\v2\r.service.GameR\x05games\"\xa8\x01\n" +
+	"\x05games\x18\x01 \x03(\v2\r.service.GameR\x05games\"\xc4\x01\n" +
 	"\x04Step\x12\x16\n" +
 	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x10\n" +
 	"\x03ord\x18\x02 \x01(\x05R\x03ord\x12\x18\n" +
@@ -846,7 +3226,8 @@ const file_pb_tictacgo_proto_rawDesc = "" +
 	"\amoveCol\x18\x04 \x01(\x05R\amoveCol\x12\x14\n" +
 	"\x05board\x18\x05 \x01(\tR\x05board\x12\x14\n" +
 	"\x05xTurn\x18\x06 \x01(\bR\x05xTurn\x12\x16\n" +
-	"\x06result\x18\a \x01(\x05R\x06result\"\x91\x01\n" +
+	"\x06result\x18\a \x01(\x05R\x06result\x12\x1a\n" +
+	"\brollback\x18\b \x01(\bR\brollback\"\x91\x01\n" +
 	"\vGetGamesReq\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12)\n" +
 	"\axPlayer\x18\x02 \x01(\v2\x0f.service.PlayerR\axPlayer\x12)\n" +
@@ -857,24 +3238,180 @@ const file_pb_tictacgo_proto_rawDesc = "" +
 	"\aperPage\x18\x02 \x01(\x05R\aperPage\"\x1c\n" +
 	"\n" +
 	"GetGameReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x0f\n" +
-	"\rCreateGameReq\"I\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x91\x01\n" +
+	"\rCreateGameReq\x12\x1e\n" +
+	"\n" +
+	"hintBudget\x18\x01 \x01(\x05R\n" +
+	"hintBudget\x12\x1e\n" +
+	"\n" +
+	"boardState\x18\x02 \x01(\tR\n" +
+	"boardState\x12\x14\n" +
+	"\x05xTurn\x18\x03 \x01(\bR\x05xTurn\x12*\n" +
+	"\x10disableTakebacks\x18\x04 \x01(\bR\x10disableTakebacks\"h\n" +
 	"\vMakeMoveReq\x12\x10\n" +
 	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x10\n" +
 	"\x03col\x18\x02 \x01(\x05R\x03col\x12\x16\n" +
-	"\x06gameId\x18\x03 \x01(\x03R\x06gameId\"H\n" +
+	"\x06gameId\x18\x03 \x01(\x03R\x06gameId\x12\x15\n" +
+	"\x03ord\x18\x04 \x01(\x05H\x00R\x03ord\x88\x01\x01B\x06\n" +
+	"\x04_ord\",\n" +
+	"\x12RequestTakebackReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\"D\n" +
+	"\x12RespondTakebackReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\")\n" +
+	"\x0fOfferRematchReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\"C\n" +
+	"\x11RespondRematchReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\"H\n" +
 	"\x0eCredentialsReq\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"J\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xf0\x01\n" +
 	"\tLoginResp\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12'\n" +
-	"\x06Player\x18\x02 \x01(\v2\x0f.service.PlayerR\x06Player\"\v\n" +
+	"\x06Player\x18\x02 \x01(\v2\x0f.service.PlayerR\x06Player\x128\n" +
+	"\texpiresOn\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresOn\x12\"\n" +
+	"\frefreshToken\x18\x04 \x01(\tR\frefreshToken\x12F\n" +
+	"\x10refreshExpiresOn\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10refreshExpiresOn\"W\n" +
+	"\x11ChangePasswordReq\x12 \n" +
+	"\voldPassword\x18\x01 \x01(\tR\voldPassword\x12 \n" +
+	"\vnewPassword\x18\x02 \x01(\tR\vnewPassword\".\n" +
+	"\x10DeleteAccountReq\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"5\n" +
+	"\x0fRefreshTokenReq\x12\"\n" +
+	"\frefreshToken\x18\x01 \x01(\tR\frefreshToken\"\v\n" +
+	"\tLogoutReq\"\x0e\n" +
+	"\fLogoutAllReq\"(\n" +
+	"\n" +
+	"LogoutResp\x12\x1a\n" +
+	"\bsessions\x18\x01 \x01(\x03R\bsessions\"\x11\n" +
+	"\x0fListSessionsReq\"\xfb\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x128\n" +
+	"\tcreatedOn\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x12:\n" +
+	"\n" +
+	"lastSeenOn\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenOn\x128\n" +
+	"\texpiresOn\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresOn\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\"8\n" +
+	"\bSessions\x12,\n" +
+	"\bsessions\x18\x01 \x03(\v2\x10.service.SessionR\bsessions\"\v\n" +
 	"\tWhoAmIReq\" \n" +
 	"\x0eListenStepsReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id2\xf6\x03\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"(\n" +
+	"\x0eAnalyzeGameReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\"@\n" +
+	"\n" +
+	"Evaluation\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x05R\x06result\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x05R\bdistance\"\xe2\x01\n" +
+	"\fMoveAnalysis\x12\x10\n" +
+	"\x03ord\x18\x01 \x01(\x05R\x03ord\x12\x18\n" +
+	"\amoveRow\x18\x02 \x01(\x05R\amoveRow\x12\x18\n" +
+	"\amoveCol\x18\x03 \x01(\x05R\amoveCol\x12\x16\n" +
+	"\x06xMoved\x18\x04 \x01(\bR\x06xMoved\x12'\n" +
+	"\x04best\x18\x05 \x01(\v2\x13.service.EvaluationR\x04best\x12+\n" +
+	"\x06played\x18\x06 \x01(\v2\x13.service.EvaluationR\x06played\x12\x1e\n" +
+	"\n" +
+	"annotation\x18\a \x01(\x05R\n" +
+	"annotation\"$\n" +
+	"\n" +
+	"GetHintReq\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\"]\n" +
+	"\bHintMove\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x10\n" +
+	"\x03col\x18\x02 \x01(\x05R\x03col\x12-\n" +
+	"\aoutcome\x18\x03 \x01(\v2\x13.service.EvaluationR\aoutcome\"\x85\x01\n" +
+	"\x04Hint\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12'\n" +
+	"\x05moves\x18\x02 \x03(\v2\x11.service.HintMoveR\x05moves\x12\x1c\n" +
+	"\thintsUsed\x18\x03 \x01(\x05R\thintsUsed\x12\x1e\n" +
+	"\n" +
+	"hintBudget\x18\x04 \x01(\x05R\n" +
+	"hintBudget\"*\n" +
+	"\x04Tile\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x10\n" +
+	"\x03col\x18\x02 \x01(\x05R\x03col\"\x84\x01\n" +
+	"\x06Puzzle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\n" +
+	"boardState\x18\x02 \x01(\tR\n" +
+	"boardState\x12\x14\n" +
+	"\x05xTurn\x18\x03 \x01(\bR\x05xTurn\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x05 \x01(\x05R\n" +
+	"difficulty\"\x1e\n" +
+	"\fGetPuzzleReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"U\n" +
+	"\x13SubmitPuzzleMoveReq\x12\x1a\n" +
+	"\bpuzzleId\x18\x01 \x01(\x03R\bpuzzleId\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\x05R\x03row\x12\x10\n" +
+	"\x03col\x18\x03 \x01(\x05R\x03col\"\x9f\x02\n" +
+	"\rPuzzleAttempt\x12\x1a\n" +
+	"\bpuzzleId\x18\x01 \x01(\x03R\bpuzzleId\x12\x1e\n" +
+	"\n" +
+	"boardState\x18\x02 \x01(\tR\n" +
+	"boardState\x12\x14\n" +
+	"\x05xTurn\x18\x03 \x01(\bR\x05xTurn\x12\x1c\n" +
+	"\tmovesLeft\x18\x04 \x01(\x05R\tmovesLeft\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\x05R\x06status\x12#\n" +
+	"\x05reply\x18\x06 \x01(\v2\r.service.TileR\x05reply\x12)\n" +
+	"\bsolution\x18\a \x01(\v2\r.service.TileR\bsolution\x12\x16\n" +
+	"\x06streak\x18\b \x01(\x05R\x06streak\x12\x1e\n" +
+	"\n" +
+	"bestStreak\x18\t \x01(\x05R\n" +
+	"bestStreak\"\x13\n" +
+	"\x11GetDailyPuzzleReq\"H\n" +
+	"\vDailyPuzzle\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12'\n" +
+	"\x06puzzle\x18\x02 \x01(\v2\x0f.service.PuzzleR\x06puzzle\"@\n" +
+	"\x16GetDailyLeaderboardReq\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"o\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12'\n" +
+	"\x06player\x18\x02 \x01(\v2\x0f.service.PlayerR\x06player\x12\x1e\n" +
+	"\n" +
+	"durationMs\x18\x03 \x01(\x03R\n" +
+	"durationMs\"Y\n" +
+	"\x10DailyLeaderboard\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x123\n" +
+	"\aentries\x18\x02 \x03(\v2\x19.service.LeaderboardEntryR\aentries\"E\n" +
+	"\aOpening\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06family\x18\x03 \x01(\tR\x06family\"=\n" +
+	"\x12GetOpeningStatsReq\x12'\n" +
+	"\x06player\x18\x01 \x01(\v2\x0f.service.PlayerR\x06player\"\x81\x02\n" +
+	"\vOpeningStat\x12*\n" +
+	"\aopening\x18\x01 \x01(\v2\x10.service.OpeningR\aopening\x12\x14\n" +
+	"\x05games\x18\x02 \x01(\x03R\x05games\x12\x12\n" +
+	"\x04wins\x18\x03 \x01(\x03R\x04wins\x12\x14\n" +
+	"\x05draws\x18\x04 \x01(\x03R\x05draws\x12\x16\n" +
+	"\x06losses\x18\x05 \x01(\x03R\x06losses\x12\x1c\n" +
+	"\tfrequency\x18\x06 \x01(\x02R\tfrequency\x12\x18\n" +
+	"\awinRate\x18\a \x01(\x02R\awinRate\x12\x1a\n" +
+	"\bdrawRate\x18\b \x01(\x02R\bdrawRate\x12\x1a\n" +
+	"\blossRate\x18\t \x01(\x02R\blossRate\"P\n" +
+	"\fOpeningStats\x12*\n" +
+	"\x05stats\x18\x01 \x03(\v2\x14.service.OpeningStatR\x05stats\x12\x14\n" +
+	"\x05games\x18\x02 \x01(\x03R\x05games\"\x8f\x01\n" +
+	"\fGameAnalysis\x12\x16\n" +
+	"\x06gameId\x18\x01 \x01(\x03R\x06gameId\x12+\n" +
+	"\x05moves\x18\x02 \x03(\v2\x15.service.MoveAnalysisR\x05moves\x12\x1c\n" +
+	"\txAccuracy\x18\x03 \x01(\x02R\txAccuracy\x12\x1c\n" +
+	"\toAccuracy\x18\x04 \x01(\x02R\toAccuracy2\xc2\r\n" +
 	"\x0fTicTacGoService\x126\n" +
 	"\bRegister\x12\x17.service.CredentialsReq\x1a\x0f.service.Player\"\x00\x126\n" +
-	"\x05Login\x12\x17.service.CredentialsReq\x1a\x12.service.LoginResp\"\x00\x128\n" +
+	"\x05Login\x12\x17.service.CredentialsReq\x1a\x12.service.LoginResp\"\x00\x12>\n" +
+	"\fRefreshToken\x12\x18.service.RefreshTokenReq\x1a\x12.service.LoginResp\"\x00\x123\n" +
+	"\x06Logout\x12\x12.service.LogoutReq\x1a\x13.service.LogoutResp\"\x00\x129\n" +
+	"\tLogoutAll\x12\x15.service.LogoutAllReq\x1a\x13.service.LogoutResp\"\x00\x12=\n" +
+	"\fListSessions\x12\x18.service.ListSessionsReq\x1a\x11.service.Sessions\"\x00\x12C\n" +
+	"\x0eChangePassword\x12\x1a.service.ChangePasswordReq\x1a\x13.service.LogoutResp\"\x00\x12A\n" +
+	"\rDeleteAccount\x12\x19.service.DeleteAccountReq\x1a\x13.service.LogoutResp\"\x00\x128\n" +
 	"\n" +
 	"GetPlayers\x12\x16.service.GetPlayersReq\x1a\x10.service.Players\"\x00\x125\n" +
 	"\n" +
@@ -882,8 +3419,21 @@ const file_pb_tictacgo_proto_rawDesc = "" +
 	"\bGetGames\x12\x14.service.GetGamesReq\x1a\x0e.service.Games\"\x00\x12/\n" +
 	"\aGetGame\x12\x13.service.GetGameReq\x1a\r.service.Game\"\x00\x121\n" +
 	"\bMakeMove\x12\x14.service.MakeMoveReq\x1a\r.service.Game\"\x00\x129\n" +
-	"\vListenSteps\x12\x17.service.ListenStepsReq\x1a\r.service.Step\"\x000\x01\x12/\n" +
-	"\x06WhoAmI\x12\x12.service.WhoAmIReq\x1a\x0f.service.Player\"\x00B\rZ\vTicTacGo/pbb\x06proto3"
+	"\vListenSteps\x12\x17.service.ListenStepsReq\x1a\r.service.Step\"\x000\x01\x12?\n" +
+	"\x0fRequestTakeback\x12\x1b.service.RequestTakebackReq\x1a\r.service.Game\"\x00\x12?\n" +
+	"\x0fRespondTakeback\x12\x1b.service.RespondTakebackReq\x1a\r.service.Game\"\x00\x129\n" +
+	"\fOfferRematch\x12\x18.service.OfferRematchReq\x1a\r.service.Game\"\x00\x12=\n" +
+	"\x0eRespondRematch\x12\x1a.service.RespondRematchReq\x1a\r.service.Game\"\x00\x12:\n" +
+	"\vSendMessage\x12\x17.service.SendMessageReq\x1a\x10.service.Message\"\x00\x12B\n" +
+	"\x0eStreamMessages\x12\x1a.service.StreamMessagesReq\x1a\x10.service.Message\"\x000\x01\x12/\n" +
+	"\x06WhoAmI\x12\x12.service.WhoAmIReq\x1a\x0f.service.Player\"\x00\x12?\n" +
+	"\vAnalyzeGame\x12\x17.service.AnalyzeGameReq\x1a\x15.service.GameAnalysis\"\x00\x12/\n" +
+	"\aGetHint\x12\x13.service.GetHintReq\x1a\r.service.Hint\"\x00\x125\n" +
+	"\tGetPuzzle\x12\x15.service.GetPuzzleReq\x1a\x0f.service.Puzzle\"\x00\x12J\n" +
+	"\x10SubmitPuzzleMove\x12\x1c.service.SubmitPuzzleMoveReq\x1a\x16.service.PuzzleAttempt\"\x00\x12D\n" +
+	"\x0eGetDailyPuzzle\x12\x1a.service.GetDailyPuzzleReq\x1a\x14.service.DailyPuzzle\"\x00\x12S\n" +
+	"\x13GetDailyLeaderboard\x12\x1f.service.GetDailyLeaderboardReq\x1a\x19.service.DailyLeaderboard\"\x00\x12G\n" +
+	"\x0fGetOpeningStats\x12\x1b.service.GetOpeningStatsReq\x1a\x15.service.OpeningStats\"\x00B\rZ\vTicTacGo/pbb\x06proto3"
 
 var (
 	file_pb_tictacgo_proto_rawDescOnce sync.Once
@@ -897,58 +3447,158 @@ func file_pb_tictacgo_proto_rawDescGZIP() []byte {
 	return file_pb_tictacgo_proto_rawDescData
 }
 
-var file_pb_tictacgo_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_pb_tictacgo_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_pb_tictacgo_proto_goTypes = []any{
-	(*Player)(nil),                // 0: service.Player
-	(*Players)(nil),               // 1: service.Players
-	(*Game)(nil),                  // 2: service.Game
-	(*Games)(nil),                 // 3: service.Games
-	(*Step)(nil),                  // 4: service.Step
-	(*GetGamesReq)(nil),           // 5: service.GetGamesReq
-	(*GetPlayersReq)(nil),         // 6: service.GetPlayersReq
-	(*GetGameReq)(nil),            // 7: service.GetGameReq
-	(*CreateGameReq)(nil),         // 8: service.CreateGameReq
-	(*MakeMoveReq)(nil),           // 9: service.MakeMoveReq
-	(*CredentialsReq)(nil),        // 10: service.CredentialsReq
-	(*LoginResp)(nil),             // 11: service.LoginResp
-	(*WhoAmIReq)(nil),             // 12: service.WhoAmIReq
-	(*ListenStepsReq)(nil),        // 13: service.ListenStepsReq
-	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*Player)(nil),                 // 0: service.Player
+	(*Players)(nil),                // 1: service.Players
+	(*Game)(nil),                   // 2: service.Game
+	(*Message)(nil),                // 3: service.Message
+	(*SendMessageReq)(nil),         // 4: service.SendMessageReq
+	(*StreamMessagesReq)(nil),      // 5: service.StreamMessagesReq
+	(*Series)(nil),                 // 6: service.Series
+	(*Games)(nil),                  // 7: service.Games
+	(*Step)(nil),                   // 8: service.Step
+	(*GetGamesReq)(nil),            // 9: service.GetGamesReq
+	(*GetPlayersReq)(nil),          // 10: service.GetPlayersReq
+	(*GetGameReq)(nil),             // 11: service.GetGameReq
+	(*CreateGameReq)(nil),          // 12: service.CreateGameReq
+	(*MakeMoveReq)(nil),            // 13: service.MakeMoveReq
+	(*RequestTakebackReq)(nil),     // 14: service.RequestTakebackReq
+	(*RespondTakebackReq)(nil),     // 15: service.RespondTakebackReq
+	(*OfferRematchReq)(nil),        // 16: service.OfferRematchReq
+	(*RespondRematchReq)(nil),      // 17: service.RespondRematchReq
+	(*CredentialsReq)(nil),         // 18: service.CredentialsReq
+	(*LoginResp)(nil),              // 19: service.LoginResp
+	(*ChangePasswordReq)(nil),      // 20: service.ChangePasswordReq
+	(*DeleteAccountReq)(nil),       // 21: service.DeleteAccountReq
+	(*RefreshTokenReq)(nil),        // 22: service.RefreshTokenReq
+	(*LogoutReq)(nil),              // 23: service.LogoutReq
+	(*LogoutAllReq)(nil),           // 24: service.LogoutAllReq
+	(*LogoutResp)(nil),             // 25: service.LogoutResp
+	(*ListSessionsReq)(nil),        // 26: service.ListSessionsReq
+	(*Session)(nil),                // 27: service.Session
+	(*Sessions)(nil),               // 28: service.Sessions
+	(*WhoAmIReq)(nil),              // 29: service.WhoAmIReq
+	(*ListenStepsReq)(nil),         // 30: service.ListenStepsReq
+	(*AnalyzeGameReq)(nil),         // 31: service.AnalyzeGameReq
+	(*Evaluation)(nil),             // 32: service.Evaluation
+	(*MoveAnalysis)(nil),           // 33: service.MoveAnalysis
+	(*GetHintReq)(nil),             // 34: service.GetHintReq
+	(*HintMove)(nil),               // 35: service.HintMove
+	(*Hint)(nil),                   // 36: service.Hint
+	(*Tile)(nil),                   // 37: service.Tile
+	(*Puzzle)(nil),                 // 38: service.Puzzle
+	(*GetPuzzleReq)(nil),           // 39: service.GetPuzzleReq
+	(*SubmitPuzzleMoveReq)(nil),    // 40: service.SubmitPuzzleMoveReq
+	(*PuzzleAttempt)(nil),          // 41: service.PuzzleAttempt
+	(*GetDailyPuzzleReq)(nil),      // 42: service.GetDailyPuzzleReq
+	(*DailyPuzzle)(nil),            // 43: service.DailyPuzzle
+	(*GetDailyLeaderboardReq)(nil), // 44: service.GetDailyLeaderboardReq
+	(*LeaderboardEntry)(nil),       // 45: service.LeaderboardEntry
+	(*DailyLeaderboard)(nil),       // 46: service.DailyLeaderboard
+	(*Opening)(nil),                // 47: service.Opening
+	(*GetOpeningStatsReq)(nil),     // 48: service.GetOpeningStatsReq
+	(*OpeningStat)(nil),            // 49: service.OpeningStat
+	(*OpeningStats)(nil),           // 50: service.OpeningStats
+	(*GameAnalysis)(nil),           // 51: service.GameAnalysis
+	(*timestamppb.Timestamp)(nil),  // 52: google.protobuf.Timestamp
 }
 var file_pb_tictacgo_proto_depIdxs = []int32{
 	0,  // 0: service.Players.players:type_name -> service.Player
 	0,  // 1: service.Game.xPlayer:type_name -> service.Player
 	0,  // 2: service.Game.oPlayer:type_name -> service.Player
-	14, // 3: service.Game.updatedOn:type_name -> google.protobuf.Timestamp
-	14, // 4: service.Game.startedOn:type_name -> google.protobuf.Timestamp
-	4,  // 5: service.Game.steps:type_name -> service.Step
-	2,  // 6: service.Games.games:type_name -> service.Game
-	0,  // 7: service.GetGamesReq.xPlayer:type_name -> service.Player
-	0,  // 8: service.GetGamesReq.oPlayer:type_name -> service.Player
-	0,  // 9: service.LoginResp.Player:type_name -> service.Player
-	10, // 10: service.TicTacGoService.Register:input_type -> service.CredentialsReq
-	10, // 11: service.TicTacGoService.Login:input_type -> service.CredentialsReq
-	6,  // 12: service.TicTacGoService.GetPlayers:input_type -> service.GetPlayersReq
-	8,  // 13: service.TicTacGoService.CreateGame:input_type -> service.CreateGameReq
-	5,  // 14: service.TicTacGoService.GetGames:input_type -> service.GetGamesReq
-	7,  // 15: service.TicTacGoService.GetGame:input_type -> service.GetGameReq
-	9,  // 16: service.TicTacGoService.MakeMove:input_type -> service.MakeMoveReq
-	13, // 17: service.TicTacGoService.ListenSteps:input_type -> service.ListenStepsReq
-	12, // 18: service.TicTacGoService.WhoAmI:input_type -> service.WhoAmIReq
-	0,  // 19: service.TicTacGoService.Register:output_type -> service.Player
-	11, // 20: service.TicTacGoService.Login:output_type -> service.LoginResp
-	1,  // 21: service.TicTacGoService.GetPlayers:output_type -> service.Players
-	2,  // 22: service.TicTacGoService.CreateGame:output_type -> service.Game
-	3,  // 23: service.TicTacGoService.GetGames:output_type -> service.Games
-	2,  // 24: service.TicTacGoService.GetGame:output_type -> service.Game
-	2,  // 25: service.TicTacGoService.MakeMove:output_type -> service.Game
-	4,  // 26: service.TicTacGoService.ListenSteps:output_type -> service.Step
-	0,  // 27: service.TicTacGoService.WhoAmI:output_type -> service.Player
-	19, // [19:28] is the sub-list for method output_type
-	10, // [10:19] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	52, // 3: service.Game.updatedOn:type_name -> google.protobuf.Timestamp
+	52, // 4: service.Game.startedOn:type_name -> google.protobuf.Timestamp
+	8,  // 5: service.Game.steps:type_name -> service.Step
+	47, // 6: service.Game.opening:type_name -> service.Opening
+	6,  // 7: service.Game.series:type_name -> service.Series
+	3,  // 8: service.Game.messages:type_name -> service.Message
+	0,  // 9: service.Message.player:type_name -> service.Player
+	52, // 10: service.Message.sentOn:type_name -> google.protobuf.Timestamp
+	2,  // 11: service.Games.games:type_name -> service.Game
+	0,  // 12: service.GetGamesReq.xPlayer:type_name -> service.Player
+	0,  // 13: service.GetGamesReq.oPlayer:type_name -> service.Player
+	0,  // 14: service.LoginResp.Player:type_name -> service.Player
+	52, // 15: service.LoginResp.expiresOn:type_name -> google.protobuf.Timestamp
+	52, // 16: service.LoginResp.refreshExpiresOn:type_name -> google.protobuf.Timestamp
+	52, // 17: service.Session.createdOn:type_name -> google.protobuf.Timestamp
+	52, // 18: service.Session.lastSeenOn:type_name -> google.protobuf.Timestamp
+	52, // 19: service.Session.expiresOn:type_name -> google.protobuf.Timestamp
+	27, // 20: service.Sessions.sessions:type_name -> service.Session
+	32, // 21: service.MoveAnalysis.best:type_name -> service.Evaluation
+	32, // 22: service.MoveAnalysis.played:type_name -> service.Evaluation
+	32, // 23: service.HintMove.outcome:type_name -> service.Evaluation
+	35, // 24: service.Hint.moves:type_name -> service.HintMove
+	37, // 25: service.PuzzleAttempt.reply:type_name -> service.Tile
+	37, // 26: service.PuzzleAttempt.solution:type_name -> service.Tile
+	38, // 27: service.DailyPuzzle.puzzle:type_name -> service.Puzzle
+	0,  // 28: service.LeaderboardEntry.player:type_name -> service.Player
+	45, // 29: service.DailyLeaderboard.entries:type_name -> service.LeaderboardEntry
+	0,  // 30: service.GetOpeningStatsReq.player:type_name -> service.Player
+	47, // 31: service.OpeningStat.opening:type_name -> service.Opening
+	49, // 32: service.OpeningStats.stats:type_name -> service.OpeningStat
+	33, // 33: service.GameAnalysis.moves:type_name -> service.MoveAnalysis
+	18, // 34: service.TicTacGoService.Register:input_type -> service.CredentialsReq
+	18, // 35: service.TicTacGoService.Login:input_type -> service.CredentialsReq
+	22, // 36: service.TicTacGoService.RefreshToken:input_type -> service.RefreshTokenReq
+	23, // 37: service.TicTacGoService.Logout:input_type -> service.LogoutReq
+	24, // 38: service.TicTacGoService.LogoutAll:input_type -> service.LogoutAllReq
+	26, // 39: service.TicTacGoService.ListSessions:input_type -> service.ListSessionsReq
+	20, // 40: service.TicTacGoService.ChangePassword:input_type -> service.ChangePasswordReq
+	21, // 41: service.TicTacGoService.DeleteAccount:input_type -> service.DeleteAccountReq
+	10, // 42: service.TicTacGoService.GetPlayers:input_type -> service.GetPlayersReq
+	12, // 43: service.TicTacGoService.CreateGame:input_type -> service.CreateGameReq
+	9,  // 44: service.TicTacGoService.GetGames:input_type -> service.GetGamesReq
+	11, // 45: service.TicTacGoService.GetGame:input_type -> service.GetGameReq
+	13, // 46: service.TicTacGoService.MakeMove:input_type -> service.MakeMoveReq
+	30, // 47: service.TicTacGoService.ListenSteps:input_type -> service.ListenStepsReq
+	14, // 48: service.TicTacGoService.RequestTakeback:input_type -> service.RequestTakebackReq
+	15, // 49: service.TicTacGoService.RespondTakeback:input_type -> service.RespondTakebackReq
+	16, // 50: service.TicTacGoService.OfferRematch:input_type -> service.OfferRematchReq
+	17, // 51: service.TicTacGoService.RespondRematch:input_type -> service.RespondRematchReq
+	4,  // 52: service.TicTacGoService.SendMessage:input_type -> service.SendMessageReq
+	5,  // 53: service.TicTacGoService.StreamMessages:input_type -> service.StreamMessagesReq
+	29, // 54: service.TicTacGoService.WhoAmI:input_type -> service.WhoAmIReq
+	31, // 55: service.TicTacGoService.AnalyzeGame:input_type -> service.AnalyzeGameReq
+	34, // 56: service.TicTacGoService.GetHint:input_type -> service.GetHintReq
+	39, // 57: service.TicTacGoService.GetPuzzle:input_type -> service.GetPuzzleReq
+	40, // 58: service.TicTacGoService.SubmitPuzzleMove:input_type -> service.SubmitPuzzleMoveReq
+	42, // 59: service.TicTacGoService.GetDailyPuzzle:input_type -> service.GetDailyPuzzleReq
+	44, // 60: service.TicTacGoService.GetDailyLeaderboard:input_type -> service.GetDailyLeaderboardReq
+	48, // 61: service.TicTacGoService.GetOpeningStats:input_type -> service.GetOpeningStatsReq
+	0,  // 62: service.TicTacGoService.Register:output_type -> service.Player
+	19, // 63: service.TicTacGoService.Login:output_type -> service.LoginResp
+	19, // 64: service.TicTacGoService.RefreshToken:output_type -> service.LoginResp
+	25, // 65: service.TicTacGoService.Logout:output_type -> service.LogoutResp
+	25, // 66: service.TicTacGoService.LogoutAll:output_type -> service.LogoutResp
+	28, // 67: service.TicTacGoService.ListSessions:output_type -> service.Sessions
+	25, // 68: service.TicTacGoService.ChangePassword:output_type -> service.LogoutResp
+	25, // 69: service.TicTacGoService.DeleteAccount:output_type -> service.LogoutResp
+	1,  // 70: service.TicTacGoService.GetPlayers:output_type -> service.Players
+	2,  // 71: service.TicTacGoService.CreateGame:output_type -> service.Game
+	7,  // 72: service.TicTacGoService.GetGames:output_type -> service.Games
+	2,  // 73: service.TicTacGoService.GetGame:output_type -> service.Game
+	2,  // 74: service.TicTacGoService.MakeMove:output_type -> service.Game
+	8,  // 75: service.TicTacGoService.ListenSteps:output_type -> service.Step
+	2,  // 76: service.TicTacGoService.RequestTakeback:output_type -> service.Game
+	2,  // 77: service.TicTacGoService.RespondTakeback:output_type -> service.Game
+	2,  // 78: service.TicTacGoService.OfferRematch:output_type -> service.Game
+	2,  // 79: service.TicTacGoService.RespondRematch:output_type -> service.Game
+	3,  // 80: service.TicTacGoService.SendMessage:output_type -> service.Message
+	3,  // 81: service.TicTacGoService.StreamMessages:output_type -> service.Message
+	0,  // 82: service.TicTacGoService.WhoAmI:output_type -> service.Player
+	51, // 83: service.TicTacGoService.AnalyzeGame:output_type -> service.GameAnalysis
+	36, // 84: service.TicTacGoService.GetHint:output_type -> service.Hint
+	38, // 85: service.TicTacGoService.GetPuzzle:output_type -> service.Puzzle
+	41, // 86: service.TicTacGoService.SubmitPuzzleMove:output_type -> service.PuzzleAttempt
+	43, // 87: service.TicTacGoService.GetDailyPuzzle:output_type -> service.DailyPuzzle
+	46, // 88: service.TicTacGoService.GetDailyLeaderboard:output_type -> service.DailyLeaderboard
+	50, // 89: service.TicTacGoService.GetOpeningStats:output_type -> service.OpeningStats
+	62, // [62:90] is the sub-list for method output_type
+	34, // [34:62] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_pb_tictacgo_proto_init() }
@@ -956,13 +3606,14 @@ func file_pb_tictacgo_proto_init() {
 	if File_pb_tictacgo_proto != nil {
 		return
 	}
+	file_pb_tictacgo_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_tictacgo_proto_rawDesc), len(file_pb_tictacgo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,15 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TicTacGoService_Register_FullMethodName    = "/service.TicTacGoService/Register"
-	TicTacGoService_Login_FullMethodName       = "/service.TicTacGoService/Login"
-	TicTacGoService_GetPlayers_FullMethodName  = "/service.TicTacGoService/GetPlayers"
-	TicTacGoService_CreateGame_FullMethodName  = "/service.TicTacGoService/CreateGame"
-	TicTacGoService_GetGames_FullMethodName    = "/service.TicTacGoService/GetGames"
-	TicTacGoService_GetGame_FullMethodName     = "/service.TicTacGoService/GetGame"
-	TicTacGoService_MakeMove_FullMethodName    = "/service.TicTacGoService/MakeMove"
-	TicTacGoService_ListenSteps_FullMethodName = "/service.TicTacGoService/ListenSteps"
-	TicTacGoService_WhoAmI_FullMethodName      = "/service.TicTacGoService/WhoAmI"
+	TicTacGoService_Register_FullMethodName            = "/service.TicTacGoService/Register"
+	TicTacGoService_Login_FullMethodName               = "/service.TicTacGoService/Login"
+	TicTacGoService_RefreshToken_FullMethodName        = "/service.TicTacGoService/RefreshToken"
+	TicTacGoService_Logout_FullMethodName              = "/service.TicTacGoService/Logout"
+	TicTacGoService_LogoutAll_FullMethodName           = "/service.TicTacGoService/LogoutAll"
+	TicTacGoService_ListSessions_FullMethodName        = "/service.TicTacGoService/ListSessions"
+	TicTacGoService_ChangePassword_FullMethodName      = "/service.TicTacGoService/ChangePassword"
+	TicTacGoService_DeleteAccount_FullMethodName       = "/service.TicTacGoService/DeleteAccount"
+	TicTacGoService_GetPlayers_FullMethodName          = "/service.TicTacGoService/GetPlayers"
+	TicTacGoService_CreateGame_FullMethodName          = "/service.TicTacGoService/CreateGame"
+	TicTacGoService_GetGames_FullMethodName            = "/service.TicTacGoService/GetGames"
+	TicTacGoService_GetGame_FullMethodName             = "/service.TicTacGoService/GetGame"
+	TicTacGoService_MakeMove_FullMethodName            = "/service.TicTacGoService/MakeMove"
+	TicTacGoService_ListenSteps_FullMethodName         = "/service.TicTacGoService/ListenSteps"
+	TicTacGoService_RequestTakeback_FullMethodName     = "/service.TicTacGoService/RequestTakeback"
+	TicTacGoService_RespondTakeback_FullMethodName     = "/service.TicTacGoService/RespondTakeback"
+	TicTacGoService_OfferRematch_FullMethodName        = "/service.TicTacGoService/OfferRematch"
+	TicTacGoService_RespondRematch_FullMethodName      = "/service.TicTacGoService/RespondRematch"
+	TicTacGoService_SendMessage_FullMethodName         = "/service.TicTacGoService/SendMessage"
+	TicTacGoService_StreamMessages_FullMethodName      = "/service.TicTacGoService/StreamMessages"
+	TicTacGoService_WhoAmI_FullMethodName              = "/service.TicTacGoService/WhoAmI"
+	TicTacGoService_AnalyzeGame_FullMethodName         = "/service.TicTacGoService/AnalyzeGame"
+	TicTacGoService_GetHint_FullMethodName             = "/service.TicTacGoService/GetHint"
+	TicTacGoService_GetPuzzle_FullMethodName           = "/service.TicTacGoService/GetPuzzle"
+	TicTacGoService_SubmitPuzzleMove_FullMethodName    = "/service.TicTacGoService/SubmitPuzzleMove"
+	TicTacGoService_GetDailyPuzzle_FullMethodName      = "/service.TicTacGoService/GetDailyPuzzle"
+	TicTacGoService_GetDailyLeaderboard_FullMethodName = "/service.TicTacGoService/GetDailyLeaderboard"
+	TicTacGoService_GetOpeningStats_FullMethodName     = "/service.TicTacGoService/GetOpeningStats"
 )
 
 // TicTacGoServiceClient is the client API for TicTacGoService service.
@@ -36,13 +55,32 @@ const (
 type TicTacGoServiceClient interface {
 	Register(ctx context.Context, in *CredentialsReq, opts ...grpc.CallOption) (*Player, error)
 	Login(ctx context.Context, in *CredentialsReq, opts ...grpc.CallOption) (*LoginResp, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*LoginResp, error)
+	Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error)
+	LogoutAll(ctx context.Context, in *LogoutAllReq, opts ...grpc.CallOption) (*LogoutResp, error)
+	ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*Sessions, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordReq, opts ...grpc.CallOption) (*LogoutResp, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountReq, opts ...grpc.CallOption) (*LogoutResp, error)
 	GetPlayers(ctx context.Context, in *GetPlayersReq, opts ...grpc.CallOption) (*Players, error)
 	CreateGame(ctx context.Context, in *CreateGameReq, opts ...grpc.CallOption) (*Game, error)
 	GetGames(ctx context.Context, in *GetGamesReq, opts ...grpc.CallOption) (*Games, error)
 	GetGame(ctx context.Context, in *GetGameReq, opts ...grpc.CallOption) (*Game, error)
 	MakeMove(ctx context.Context, in *MakeMoveReq, opts ...grpc.CallOption) (*Game, error)
 	ListenSteps(ctx context.Context, in *ListenStepsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Step], error)
+	RequestTakeback(ctx context.Context, in *RequestTakebackReq, opts ...grpc.CallOption) (*Game, error)
+	RespondTakeback(ctx context.Context, in *RespondTakebackReq, opts ...grpc.CallOption) (*Game, error)
+	OfferRematch(ctx context.Context, in *OfferRematchReq, opts ...grpc.CallOption) (*Game, error)
+	RespondRematch(ctx context.Context, in *RespondRematchReq, opts ...grpc.CallOption) (*Game, error)
+	SendMessage(ctx context.Context, in *SendMessageReq, opts ...grpc.CallOption) (*Message, error)
+	StreamMessages(ctx context.Context, in *StreamMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
 	WhoAmI(ctx context.Context, in *WhoAmIReq, opts ...grpc.CallOption) (*Player, error)
+	AnalyzeGame(ctx context.Context, in *AnalyzeGameReq, opts ...grpc.CallOption) (*GameAnalysis, error)
+	GetHint(ctx context.Context, in *GetHintReq, opts ...grpc.CallOption) (*Hint, error)
+	GetPuzzle(ctx context.Context, in *GetPuzzleReq, opts ...grpc.CallOption) (*Puzzle, error)
+	SubmitPuzzleMove(ctx context.Context, in *SubmitPuzzleMoveReq, opts ...grpc.CallOption) (*PuzzleAttempt, error)
+	GetDailyPuzzle(ctx context.Context, in *GetDailyPuzzleReq, opts ...grpc.CallOption) (*DailyPuzzle, error)
+	GetDailyLeaderboard(ctx context.Context, in *GetDailyLeaderboardReq, opts ...grpc.CallOption) (*DailyLeaderboard, error)
+	GetOpeningStats(ctx context.Context, in *GetOpeningStatsReq, opts ...grpc.CallOption) (*OpeningStats, error)
 }
 
 type ticTacGoServiceClient struct {
@@ -73,6 +111,66 @@ func (c *ticTacGoServiceClient) Login(ctx context.Context, in *CredentialsReq, o
 	return out, nil
 }
 
+func (c *ticTacGoServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, TicTacGoService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResp)
+	err := c.cc.Invoke(ctx, TicTacGoService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) LogoutAll(ctx context.Context, in *LogoutAllReq, opts ...grpc.CallOption) (*LogoutResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResp)
+	err := c.cc.Invoke(ctx, TicTacGoService_LogoutAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*Sessions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sessions)
+	err := c.cc.Invoke(ctx, TicTacGoService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordReq, opts ...grpc.CallOption) (*LogoutResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResp)
+	err := c.cc.Invoke(ctx, TicTacGoService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountReq, opts ...grpc.CallOption) (*LogoutResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResp)
+	err := c.cc.Invoke(ctx, TicTacGoService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ticTacGoServiceClient) GetPlayers(ctx context.Context, in *GetPlayersReq, opts ...grpc.CallOption) (*Players, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Players)
@@ -142,6 +240,75 @@ func (c *ticTacGoServiceClient) ListenSteps(ctx context.Context, in *ListenSteps
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TicTacGoService_ListenStepsClient = grpc.ServerStreamingClient[Step]
 
+func (c *ticTacGoServiceClient) RequestTakeback(ctx context.Context, in *RequestTakebackReq, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, TicTacGoService_RequestTakeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) RespondTakeback(ctx context.Context, in *RespondTakebackReq, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, TicTacGoService_RespondTakeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) OfferRematch(ctx context.Context, in *OfferRematchReq, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, TicTacGoService_OfferRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) RespondRematch(ctx context.Context, in *RespondRematchReq, opts ...grpc.CallOption) (*Game, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Game)
+	err := c.cc.Invoke(ctx, TicTacGoService_RespondRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) SendMessage(ctx context.Context, in *SendMessageReq, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, TicTacGoService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) StreamMessages(ctx context.Context, in *StreamMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TicTacGoService_ServiceDesc.Streams[1], TicTacGoService_StreamMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamMessagesReq, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicTacGoService_StreamMessagesClient = grpc.ServerStreamingClient[Message]
+
 func (c *ticTacGoServiceClient) WhoAmI(ctx context.Context, in *WhoAmIReq, opts ...grpc.CallOption) (*Player, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Player)
@@ -152,19 +319,108 @@ func (c *ticTacGoServiceClient) WhoAmI(ctx context.Context, in *WhoAmIReq, opts
 	return out, nil
 }
 
+func (c *ticTacGoServiceClient) AnalyzeGame(ctx context.Context, in *AnalyzeGameReq, opts ...grpc.CallOption) (*GameAnalysis, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameAnalysis)
+	err := c.cc.Invoke(ctx, TicTacGoService_AnalyzeGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) GetHint(ctx context.Context, in *GetHintReq, opts ...grpc.CallOption) (*Hint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hint)
+	err := c.cc.Invoke(ctx, TicTacGoService_GetHint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) GetPuzzle(ctx context.Context, in *GetPuzzleReq, opts ...grpc.CallOption) (*Puzzle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Puzzle)
+	err := c.cc.Invoke(ctx, TicTacGoService_GetPuzzle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) SubmitPuzzleMove(ctx context.Context, in *SubmitPuzzleMoveReq, opts ...grpc.CallOption) (*PuzzleAttempt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PuzzleAttempt)
+	err := c.cc.Invoke(ctx, TicTacGoService_SubmitPuzzleMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) GetDailyPuzzle(ctx context.Context, in *GetDailyPuzzleReq, opts ...grpc.CallOption) (*DailyPuzzle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailyPuzzle)
+	err := c.cc.Invoke(ctx, TicTacGoService_GetDailyPuzzle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) GetDailyLeaderboard(ctx context.Context, in *GetDailyLeaderboardReq, opts ...grpc.CallOption) (*DailyLeaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailyLeaderboard)
+	err := c.cc.Invoke(ctx, TicTacGoService_GetDailyLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticTacGoServiceClient) GetOpeningStats(ctx context.Context, in *GetOpeningStatsReq, opts ...grpc.CallOption) (*OpeningStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpeningStats)
+	err := c.cc.Invoke(ctx, TicTacGoService_GetOpeningStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TicTacGoServiceServer is the server API for TicTacGoService service.
 // All implementations must embed UnimplementedTicTacGoServiceServer
 // for forward compatibility.
 type TicTacGoServiceServer interface {
 	Register(context.Context, *CredentialsReq) (*Player, error)
 	Login(context.Context, *CredentialsReq) (*LoginResp, error)
+	RefreshToken(context.Context, *RefreshTokenReq) (*LoginResp, error)
+	Logout(context.Context, *LogoutReq) (*LogoutResp, error)
+	LogoutAll(context.Context, *LogoutAllReq) (*LogoutResp, error)
+	ListSessions(context.Context, *ListSessionsReq) (*Sessions, error)
+	ChangePassword(context.Context, *ChangePasswordReq) (*LogoutResp, error)
+	DeleteAccount(context.Context, *DeleteAccountReq) (*LogoutResp, error)
 	GetPlayers(context.Context, *GetPlayersReq) (*Players, error)
 	CreateGame(context.Context, *CreateGameReq) (*Game, error)
 	GetGames(context.Context, *GetGamesReq) (*Games, error)
 	GetGame(context.Context, *GetGameReq) (*Game, error)
 	MakeMove(context.Context, *MakeMoveReq) (*Game, error)
 	ListenSteps(*ListenStepsReq, grpc.ServerStreamingServer[Step]) error
+	RequestTakeback(context.Context, *RequestTakebackReq) (*Game, error)
+	RespondTakeback(context.Context, *RespondTakebackReq) (*Game, error)
+	OfferRematch(context.Context, *OfferRematchReq) (*Game, error)
+	RespondRematch(context.Context, *RespondRematchReq) (*Game, error)
+	SendMessage(context.Context, *SendMessageReq) (*Message, error)
+	StreamMessages(*StreamMessagesReq, grpc.ServerStreamingServer[Message]) error
 	WhoAmI(context.Context, *WhoAmIReq) (*Player, error)
+	AnalyzeGame(context.Context, *AnalyzeGameReq) (*GameAnalysis, error)
+	GetHint(context.Context, *GetHintReq) (*Hint, error)
+	GetPuzzle(context.Context, *GetPuzzleReq) (*Puzzle, error)
+	SubmitPuzzleMove(context.Context, *SubmitPuzzleMoveReq) (*PuzzleAttempt, error)
+	GetDailyPuzzle(context.Context, *GetDailyPuzzleReq) (*DailyPuzzle, error)
+	GetDailyLeaderboard(context.Context, *GetDailyLeaderboardReq) (*DailyLeaderboard, error)
+	GetOpeningStats(context.Context, *GetOpeningStatsReq) (*OpeningStats, error)
 	mustEmbedUnimplementedTicTacGoServiceServer()
 }
 
@@ -181,6 +437,24 @@ func (UnimplementedTicTacGoServiceServer) Register(context.Context, *Credentials
 func (UnimplementedTicTacGoServiceServer) Login(context.Context, *CredentialsReq) (*LoginResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedTicTacGoServiceServer) RefreshToken(context.Context, *RefreshTokenReq) (*LoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) Logout(context.Context, *LogoutReq) (*LogoutResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) LogoutAll(context.Context, *LogoutAllReq) (*LogoutResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAll not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) ListSessions(context.Context, *ListSessionsReq) (*Sessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) ChangePassword(context.Context, *ChangePasswordReq) (*LogoutResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) DeleteAccount(context.Context, *DeleteAccountReq) (*LogoutResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
 func (UnimplementedTicTacGoServiceServer) GetPlayers(context.Context, *GetPlayersReq) (*Players, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlayers not implemented")
 }
@@ -199,9 +473,48 @@ func (UnimplementedTicTacGoServiceServer) MakeMove(context.Context, *MakeMoveReq
 func (UnimplementedTicTacGoServiceServer) ListenSteps(*ListenStepsReq, grpc.ServerStreamingServer[Step]) error {
 	return status.Errorf(codes.Unimplemented, "method ListenSteps not implemented")
 }
+func (UnimplementedTicTacGoServiceServer) RequestTakeback(context.Context, *RequestTakebackReq) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestTakeback not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) RespondTakeback(context.Context, *RespondTakebackReq) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondTakeback not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) OfferRematch(context.Context, *OfferRematchReq) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferRematch not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) RespondRematch(context.Context, *RespondRematchReq) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondRematch not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) SendMessage(context.Context, *SendMessageReq) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) StreamMessages(*StreamMessagesReq, grpc.ServerStreamingServer[Message]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMessages not implemented")
+}
 func (UnimplementedTicTacGoServiceServer) WhoAmI(context.Context, *WhoAmIReq) (*Player, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WhoAmI not implemented")
 }
+func (UnimplementedTicTacGoServiceServer) AnalyzeGame(context.Context, *AnalyzeGameReq) (*GameAnalysis, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeGame not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) GetHint(context.Context, *GetHintReq) (*Hint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHint not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) GetPuzzle(context.Context, *GetPuzzleReq) (*Puzzle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPuzzle not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) SubmitPuzzleMove(context.Context, *SubmitPuzzleMoveReq) (*PuzzleAttempt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPuzzleMove not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) GetDailyPuzzle(context.Context, *GetDailyPuzzleReq) (*DailyPuzzle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyPuzzle not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) GetDailyLeaderboard(context.Context, *GetDailyLeaderboardReq) (*DailyLeaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyLeaderboard not implemented")
+}
+func (UnimplementedTicTacGoServiceServer) GetOpeningStats(context.Context, *GetOpeningStatsReq) (*OpeningStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpeningStats not implemented")
+}
 func (UnimplementedTicTacGoServiceServer) mustEmbedUnimplementedTicTacGoServiceServer() {}
 func (UnimplementedTicTacGoServiceServer) testEmbeddedByValue()                         {}
 
@@ -259,6 +572,114 @@ func _TicTacGoService_Login_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicTacGoService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).RefreshToken(ctx, req.(*RefreshTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).Logout(ctx, req.(*LogoutReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_LogoutAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).LogoutAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_LogoutAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).LogoutAll(ctx, req.(*LogoutAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).ListSessions(ctx, req.(*ListSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).ChangePassword(ctx, req.(*ChangePasswordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).DeleteAccount(ctx, req.(*DeleteAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TicTacGoService_GetPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPlayersReq)
 	if err := dec(in); err != nil {
@@ -360,6 +781,107 @@ func _TicTacGoService_ListenSteps_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TicTacGoService_ListenStepsServer = grpc.ServerStreamingServer[Step]
 
+func _TicTacGoService_RequestTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTakebackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).RequestTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_RequestTakeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).RequestTakeback(ctx, req.(*RequestTakebackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_RespondTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondTakebackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).RespondTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_RespondTakeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).RespondTakeback(ctx, req.(*RespondTakebackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_OfferRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferRematchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).OfferRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_OfferRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).OfferRematch(ctx, req.(*OfferRematchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_RespondRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondRematchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).RespondRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_RespondRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).RespondRematch(ctx, req.(*RespondRematchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).SendMessage(ctx, req.(*SendMessageReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMessagesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicTacGoServiceServer).StreamMessages(m, &grpc.GenericServerStream[StreamMessagesReq, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicTacGoService_StreamMessagesServer = grpc.ServerStreamingServer[Message]
+
 func _TicTacGoService_WhoAmI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WhoAmIReq)
 	if err := dec(in); err != nil {
@@ -378,6 +900,132 @@ func _TicTacGoService_WhoAmI_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicTacGoService_AnalyzeGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeGameReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).AnalyzeGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_AnalyzeGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).AnalyzeGame(ctx, req.(*AnalyzeGameReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_GetHint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHintReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).GetHint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_GetHint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).GetHint(ctx, req.(*GetHintReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_GetPuzzle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPuzzleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).GetPuzzle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_GetPuzzle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).GetPuzzle(ctx, req.(*GetPuzzleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_SubmitPuzzleMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPuzzleMoveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).SubmitPuzzleMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_SubmitPuzzleMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).SubmitPuzzleMove(ctx, req.(*SubmitPuzzleMoveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_GetDailyPuzzle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyPuzzleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).GetDailyPuzzle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_GetDailyPuzzle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).GetDailyPuzzle(ctx, req.(*GetDailyPuzzleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_GetDailyLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyLeaderboardReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).GetDailyLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_GetDailyLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).GetDailyLeaderboard(ctx, req.(*GetDailyLeaderboardReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicTacGoService_GetOpeningStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpeningStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicTacGoServiceServer).GetOpeningStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicTacGoService_GetOpeningStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicTacGoServiceServer).GetOpeningStats(ctx, req.(*GetOpeningStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TicTacGoService_ServiceDesc is the grpc.ServiceDesc for TicTacGoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -393,6 +1041,30 @@ var TicTacGoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _TicTacGoService_Login_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _TicTacGoService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _TicTacGoService_Logout_Handler,
+		},
+		{
+			MethodName: "LogoutAll",
+			Handler:    _TicTacGoService_LogoutAll_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _TicTacGoService_ListSessions_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _TicTacGoService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _TicTacGoService_DeleteAccount_Handler,
+		},
 		{
 			MethodName: "GetPlayers",
 			Handler:    _TicTacGoService_GetPlayers_Handler,
@@ -413,10 +1085,58 @@ var TicTacGoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MakeMove",
 			Handler:    _TicTacGoService_MakeMove_Handler,
 		},
+		{
+			MethodName: "RequestTakeback",
+			Handler:    _TicTacGoService_RequestTakeback_Handler,
+		},
+		{
+			MethodName: "RespondTakeback",
+			Handler:    _TicTacGoService_RespondTakeback_Handler,
+		},
+		{
+			MethodName: "OfferRematch",
+			Handler:    _TicTacGoService_OfferRematch_Handler,
+		},
+		{
+			MethodName: "RespondRematch",
+			Handler:    _TicTacGoService_RespondRematch_Handler,
+		},
+		{
+			MethodName: "SendMessage",
+			Handler:    _TicTacGoService_SendMessage_Handler,
+		},
 		{
 			MethodName: "WhoAmI",
 			Handler:    _TicTacGoService_WhoAmI_Handler,
 		},
+		{
+			MethodName: "AnalyzeGame",
+			Handler:    _TicTacGoService_AnalyzeGame_Handler,
+		},
+		{
+			MethodName: "GetHint",
+			Handler:    _TicTacGoService_GetHint_Handler,
+		},
+		{
+			MethodName: "GetPuzzle",
+			Handler:    _TicTacGoService_GetPuzzle_Handler,
+		},
+		{
+			MethodName: "SubmitPuzzleMove",
+			Handler:    _TicTacGoService_SubmitPuzzleMove_Handler,
+		},
+		{
+			MethodName: "GetDailyPuzzle",
+			Handler:    _TicTacGoService_GetDailyPuzzle_Handler,
+		},
+		{
+			MethodName: "GetDailyLeaderboard",
+			Handler:    _TicTacGoService_GetDailyLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetOpeningStats",
+			Handler:    _TicTacGoService_GetOpeningStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -424,6 +1144,11 @@ var TicTacGoService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TicTacGoService_ListenSteps_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamMessages",
+			Handler:       _TicTacGoService_StreamMessages_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pb/tictacgo.proto",
 }
@@ -0,0 +1,439 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"TicTacGo/pb"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	RolePlayer    int32 = 0
+	RoleModerator int32 = 1
+	RoleAdmin     int32 = 2
+)
+
+var RoleNames = []string{"player", "moderator", "admin"}
+
+// MethodRoles lists the role each admin method needs. Admin methods that are not listed need an admin, so
+// new methods are closed until added here.
+var MethodRoles = map[string]int32{
+	pb.AdminService_BanPlayer_FullMethodName:        RoleModerator,
+	pb.AdminService_UnbanPlayer_FullMethodName:      RoleModerator,
+	pb.AdminService_DeleteMessage_FullMethodName:    RoleModerator,
+	pb.AdminService_GetRegistrations_FullMethodName: RoleModerator,
+	pb.AdminService_SetRole_FullMethodName:          RoleAdmin,
+	pb.AdminService_ForceEndGame_FullMethodName:     RoleAdmin,
+	pb.AdminService_GetServerStats_FullMethodName:   RoleAdmin,
+	pb.AdminService_QueryAuditLog_FullMethodName:    RoleAdmin,
+}
+
+// RequiredRole returns the role a method needs, methods outside the admin service need none.
+func RequiredRole(method string) (int32, bool) {
+	if role, ok := MethodRoles[method]; ok {
+		return role, true
+	}
+	if strings.HasPrefix(method, "/"+pb.AdminService_ServiceDesc.ServiceName+"/") {
+		return RoleAdmin, true
+	}
+	return RolePlayer, false
+}
+
+type roleKey struct{}
+
+// RoleFromContext returns the signed in player's role, as checked by the role interceptor.
+func RoleFromContext(ctx context.Context) int32 {
+	role, _ := ctx.Value(roleKey{}).(int32)
+	return role
+}
+
+// Authorize checks the signed in player's role against the method's. Only admin methods read the role from
+// the database, so that a demotion or ban takes effect on them at once, other calls trust the access token alone.
+func (s *GrpcServer) Authorize(ctx context.Context, method string) (context.Context, error) {
+	required, ok := RequiredRole(method)
+	if !ok {
+		return ctx, nil
+	}
+
+	player, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.Store.GetPlayerRole(ctx, player.ID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "account no longer exists")
+	}
+	if err != nil {
+		log.Printf("failed to get player role: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get role for player: %d", player.ID)
+	}
+	if row.BannedOn.Valid {
+		return nil, status.Error(codes.PermissionDenied, "player is banned")
+	}
+	if row.Role < required {
+		log.Printf("player: %d with role: %d denied method: %s", player.ID, row.Role, method)
+		return nil, status.Errorf(codes.PermissionDenied, "method: %s requires the %s role", method, RoleNames[required])
+	}
+	return context.WithValue(ctx, roleKey{}, row.Role), nil
+}
+
+func (s *GrpcServer) UnaryRoleInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.Authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *GrpcServer) StreamRoleInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.Authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+// AdminTrans runs an admin action and records it in the audit log in one transaction.
+func (s *GrpcServer) AdminTrans(ctx context.Context, event AuditEvent, action func(ctx context.Context, qtx Store) error) error {
+	err := s.Store.InTx(ctx, event.EventType, func(ctx context.Context, qtx Store) error {
+		if err := action(ctx, qtx); err != nil {
+			return err
+		}
+		return RecordAudit(ctx, qtx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("executed %s transaction by player: %d for target: %d", event.EventType, event.ActorID, event.TargetID)
+	return nil
+}
+
+// CheckOutranks stops moderators from acting on players of the same or a higher role.
+func (s *GrpcServer) CheckOutranks(ctx context.Context, targetID int64) (db.GetPlayerRoleRow, error) {
+	row, err := s.Store.GetPlayerRole(ctx, targetID)
+	if errors.Is(err, ErrNotFound) {
+		return db.GetPlayerRoleRow{}, status.Errorf(codes.NotFound, "player: %d does not exist", targetID)
+	}
+	if err != nil {
+		log.Printf("failed to get player role: %v", err)
+		return db.GetPlayerRoleRow{}, status.Errorf(codes.Internal, "failed to get role for player: %d", targetID)
+	}
+	if row.Role >= RoleFromContext(ctx) {
+		return db.GetPlayerRoleRow{}, status.Errorf(codes.PermissionDenied, "can not act on a player with the %s role", RoleNames[row.Role])
+	}
+	return row, nil
+}
+
+func (s *GrpcServer) BanPlayer(ctx context.Context, in *pb.BanPlayerReq) (*pb.Account, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateBanPlayer(in)
+	if err != nil {
+		log.Printf("failed to validate ban %v: %v", in, err)
+		return nil, err
+	}
+
+	_, err = s.CheckOutranks(ctx, in.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var account db.PlayerAccount
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditBanPlayer, TargetID: in.PlayerId, Payload: map[string]any{"reason": in.Reason}}
+	err = s.AdminTrans(ctx, event, func(ctx context.Context, qtx Store) error {
+		params := db.BanPlayerParams{
+			ID:        in.PlayerId,
+			BannedOn:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			BanReason: in.Reason,
+		}
+		account, err = qtx.BanPlayer(ctx, params)
+		if errors.Is(err, ErrNotFound) {
+			return status.Errorf(codes.FailedPrecondition, "player: %d is already banned", in.PlayerId)
+		}
+		if err != nil {
+			log.Printf("failed to ban player: %v", err)
+			return status.Errorf(codes.Internal, "failed to ban player: %d", in.PlayerId)
+		}
+		// banned players can not refresh their tokens, so they are turned away once their access token expires
+		_, err = qtx.DeletePlayerSessions(ctx, in.PlayerId)
+		if err != nil {
+			log.Printf("failed to delete sessions: %v", err)
+			return status.Errorf(codes.Internal, "failed to delete sessions for player: %d", in.PlayerId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MapAccount(account), nil
+}
+
+func (s *GrpcServer) UnbanPlayer(ctx context.Context, in *pb.UnbanPlayerReq) (*pb.Account, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var account db.PlayerAccount
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditUnbanPlayer, TargetID: in.PlayerId}
+	err = s.AdminTrans(ctx, event, func(ctx context.Context, qtx Store) error {
+		account, err = qtx.UnbanPlayer(ctx, in.PlayerId)
+		if errors.Is(err, ErrNotFound) {
+			return status.Errorf(codes.FailedPrecondition, "player: %d is not banned", in.PlayerId)
+		}
+		if err != nil {
+			log.Printf("failed to unban player: %v", err)
+			return status.Errorf(codes.Internal, "failed to unban player: %d", in.PlayerId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MapAccount(account), nil
+}
+
+func (s *GrpcServer) SetRole(ctx context.Context, in *pb.SetRoleReq) (*pb.Account, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateSetRole(in)
+	if err != nil {
+		log.Printf("failed to validate set role %v: %v", in, err)
+		return nil, err
+	}
+	if in.PlayerId == sessRow.ID {
+		return nil, status.Error(codes.FailedPrecondition, "can not change your own role")
+	}
+
+	row, err := s.CheckOutranks(ctx, in.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var account db.PlayerAccount
+	payload := map[string]any{"from": RoleNames[row.Role], "to": RoleNames[in.Role]}
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditSetRole, TargetID: in.PlayerId, Payload: payload}
+	err = s.AdminTrans(ctx, event, func(ctx context.Context, qtx Store) error {
+		account, err = qtx.SetRole(ctx, db.SetRoleParams{ID: in.PlayerId, Role: in.Role})
+		if errors.Is(err, ErrNotFound) {
+			return status.Errorf(codes.NotFound, "player: %d does not exist", in.PlayerId)
+		}
+		if err != nil {
+			log.Printf("failed to set role: %v", err)
+			return status.Errorf(codes.Internal, "failed to set role for player: %d", in.PlayerId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MapAccount(account), nil
+}
+
+func (s *GrpcServer) ForceEndGame(ctx context.Context, in *pb.ForceEndGameReq) (*pb.Game, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateForceEndGame(in)
+	if err != nil {
+		log.Printf("failed to validate force end %v: %v", in, err)
+		return nil, err
+	}
+
+	payload := map[string]any{"result": in.Result, "reason": in.Reason}
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditForceEndGame, TargetID: in.GameId, Payload: payload}
+	err = s.AdminTrans(ctx, event, func(ctx context.Context, qtx Store) error {
+		params := db.ForceEndGameParams{
+			ID:        in.GameId,
+			Result:    in.Result,
+			UpdatedOn: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			EndedBy:   pgtype.Int8{Int64: sessRow.ID, Valid: true},
+		}
+		result, err := qtx.ForceEndGame(ctx, params)
+		if err != nil {
+			log.Printf("failed to force end game: %v", err)
+			return status.Errorf(codes.Internal, "failed to force end game: %d", in.GameId)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.FailedPrecondition, "game: %d does not exist or is not in progress", in.GameId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetGame(ctx, &pb.GetGameReq{Id: in.GameId})
+}
+
+func (s *GrpcServer) DeleteMessage(ctx context.Context, in *pb.DeleteMessageReq) (*pb.Message, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateDeleteMessage(in)
+	if err != nil {
+		log.Printf("failed to validate delete message %v: %v", in, err)
+		return nil, err
+	}
+
+	messageRow, err := s.Store.GetMessage(ctx, in.MessageId)
+	if errors.Is(err, ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "message: %d does not exist", in.MessageId)
+	}
+	if err != nil {
+		log.Printf("failed to get message: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get message: %d", in.MessageId)
+	}
+
+	// the text is kept in the audit log, so that the deletion can be reviewed
+	payload := map[string]any{
+		"gameId":   messageRow.GameID,
+		"playerId": messageRow.PlayerID,
+		"text":     messageRow.Text,
+		"reason":   in.Reason,
+	}
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditDeleteMessage, TargetID: in.MessageId, Payload: payload}
+	err = s.AdminTrans(ctx, event, func(ctx context.Context, qtx Store) error {
+		result, err := qtx.DeleteMessage(ctx, in.MessageId)
+		if err != nil {
+			log.Printf("failed to delete message: %v", err)
+			return status.Errorf(codes.Internal, "failed to delete message: %d", in.MessageId)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.NotFound, "message: %d does not exist", in.MessageId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MapMessage(db.GetGameMessagesRow(messageRow)), nil
+}
+
+func (s *GrpcServer) GetRegistrations(ctx context.Context, in *pb.GetRegistrationsReq) (*pb.Accounts, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	err := ValidateGetRegistrations(in)
+	if err != nil {
+		log.Printf("failed to validate get registrations %v: %v", in, err)
+		return nil, err
+	}
+
+	params := db.GetRegistrationsParams{
+		Since:  pgtype.Timestamptz{Time: in.Since.AsTime(), Valid: true},
+		Offset: (in.Page - 1) * in.PerPage,
+		Limit:  in.PerPage,
+	}
+	rows, err := s.Store.GetRegistrations(ctx, params)
+	if err != nil {
+		log.Printf("failed to get registrations: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get registrations for params: %+v", params)
+	}
+
+	return MapAccounts(rows), nil
+}
+
+func (s *GrpcServer) GetServerStats(ctx context.Context, in *pb.GetServerStatsReq) (*pb.ServerStats, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	since := time.Now().Add(-time.Hour * 24)
+	if in.Since != nil {
+		since = in.Since.AsTime()
+	}
+
+	row, err := s.Store.GetServerStats(ctx, pgtype.Timestamptz{Time: since, Valid: true})
+	if err != nil {
+		log.Printf("failed to get server stats: %v", err)
+		return nil, status.Error(codes.Internal, "failed to get server stats")
+	}
+
+	stats := pb.ServerStats{
+		Players:       row.Players,
+		BannedPlayers: row.BannedPlayers,
+		Registrations: row.Registrations,
+		Sessions:      row.Sessions,
+		Games:         row.Games,
+		ActiveGames:   row.ActiveGames,
+		Messages:      row.Messages,
+	}
+	log.Printf("successfully fetched server stats: %v", stats.String())
+	return &stats, nil
+}
+
+func (s *GrpcServer) QueryAuditLog(ctx context.Context, in *pb.QueryAuditLogReq) (*pb.AuditEvents, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	err := ValidateQueryAuditLog(in)
+	if err != nil {
+		log.Printf("failed to validate query audit log %v: %v", in, err)
+		return nil, err
+	}
+
+	until := time.Now()
+	if in.Until != nil {
+		until = in.Until.AsTime()
+	}
+	params := db.QueryAuditLogParams{
+		Since:     pgtype.Timestamptz{Time: in.Since.AsTime(), Valid: true},
+		Until:     pgtype.Timestamptz{Time: until, Valid: true},
+		ActorId:   pgtype.Int8{Int64: in.ActorId, Valid: in.ActorId != 0},
+		EventType: pgtype.Text{String: in.EventType, Valid: in.EventType != ""},
+		Offset:    (in.Page - 1) * in.PerPage,
+		Limit:     in.PerPage,
+	}
+	rows, err := s.Store.QueryAuditLog(ctx, params)
+	if err != nil {
+		log.Printf("failed to query audit log: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to query audit log for params: %+v", params)
+	}
+
+	return MapAuditEvents(rows), nil
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"TicTacGo/pb"
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+const (
+	AuditRegister       = "register"
+	AuditLogin          = "login"
+	AuditLoginFailure   = "login_failure"
+	AuditLogout         = "logout"
+	AuditLogoutAll      = "logout_all"
+	AuditChangePassword = "change_password"
+	AuditDeleteAccount  = "delete_account"
+	AuditMove           = "move"
+	AuditBanPlayer      = "ban_player"
+	AuditUnbanPlayer    = "unban_player"
+	AuditSetRole        = "set_role"
+	AuditForceEndGame   = "force_end_game"
+	AuditDeleteMessage  = "delete_message"
+)
+
+// AuditedMethods are recorded by the audit interceptor once they succeed. Methods that need more detail
+// than the interceptor has, or that must be recorded along with their change, record their events
+// themselves.
+var AuditedMethods = map[string]string{
+	pb.TicTacGoService_Logout_FullMethodName:         AuditLogout,
+	pb.TicTacGoService_LogoutAll_FullMethodName:      AuditLogoutAll,
+	pb.TicTacGoService_ChangePassword_FullMethodName: AuditChangePassword,
+	pb.TicTacGoService_DeleteAccount_FullMethodName:  AuditDeleteAccount,
+}
+
+// AuditEvent is an entry of the audit log, the payload is stored as json. The peer and method are taken
+// from the call when left empty.
+type AuditEvent struct {
+	ActorID   int64
+	EventType string
+	TargetID  int64
+	Peer      string
+	Method    string
+	Payload   map[string]any
+}
+
+// RecordAudit appends the event to the audit log, pass the store of a transaction to record the event
+// along with the change it describes.
+func RecordAudit(ctx context.Context, store Store, event AuditEvent) error {
+	if event.Peer == "" {
+		event.Peer, _ = PeerHost(ctx)
+	}
+	if event.Method == "" {
+		event.Method, _ = grpc.Method(ctx)
+	}
+
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		log.Printf("failed to marshal audit payload: %v", err)
+		return status.Errorf(codes.Internal, "failed to marshal audit payload for event: %s", event.EventType)
+	}
+	if event.Payload == nil {
+		payload = []byte("{}")
+	}
+
+	params := db.InsertAuditEventParams{
+		ActorID:   pgtype.Int8{Int64: event.ActorID, Valid: event.ActorID != 0},
+		EventType: event.EventType,
+		TargetID:  pgtype.Int8{Int64: event.TargetID, Valid: event.TargetID != 0},
+		Peer:      event.Peer,
+		Method:    event.Method,
+		Payload:   payload,
+		CreatedOn: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	_, err = store.InsertAuditEvent(ctx, params)
+	if err != nil {
+		log.Printf("failed to insert audit event: %v", err)
+		return status.Errorf(codes.Internal, "failed to insert audit event: %s", event.EventType)
+	}
+	return nil
+}
+
+// Audit records an event that is not part of a transaction. Failing to record is only logged, so the
+// caller still answers the call it audits.
+func (s *GrpcServer) Audit(ctx context.Context, event AuditEvent) {
+	if err := RecordAudit(ctx, s.Store, event); err != nil {
+		log.Printf("failed to record audit event: %s, %v", event.EventType, err)
+	}
+}
+
+// AuditLoginFailure records a failed login, with the account it was made against when the username exists.
+func (s *GrpcServer) AuditLoginFailure(ctx context.Context, playerID int64, username string, reason string) {
+	payload := map[string]any{"username": username, "reason": reason}
+	s.Audit(ctx, AuditEvent{ActorID: playerID, EventType: AuditLoginFailure, TargetID: playerID, Payload: payload})
+}
+
+func (s *GrpcServer) UnaryAuditInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	eventType, ok := AuditedMethods[info.FullMethod]
+	if err != nil || !ok {
+		return resp, err
+	}
+
+	player, _ := PlayerFromContext(ctx)
+	payload := map[string]any{"sessionId": player.SessionID}
+	if logout, ok := resp.(*pb.LogoutResp); ok {
+		payload["sessions"] = logout.Sessions
+	}
+	s.Audit(ctx, AuditEvent{ActorID: player.ID, EventType: eventType, TargetID: player.ID, Payload: payload})
+	return resp, err
+}
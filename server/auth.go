@@ -0,0 +1,188 @@
+package server
+
+import (
+	"TicTacGo/pb"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+	"time"
+)
+
+// DefaultSessionTTL is how long a session stays signed in without refreshing its token, when no ttl is
+// configured.
+const DefaultSessionTTL = time.Hour * 24 * 30
+
+// AuthPolicy decides whether a method needs a signed in player.
+type AuthPolicy int
+
+const (
+	AuthRequired AuthPolicy = iota
+	AuthOptional
+	AuthNone
+)
+
+// MethodPolicies lists the methods that can be called without signing in. Methods that are not listed
+// require a signed in player, so new methods are private until added here.
+var MethodPolicies = map[string]AuthPolicy{
+	pb.TicTacGoService_Register_FullMethodName:            AuthNone,
+	pb.TicTacGoService_Login_FullMethodName:               AuthNone,
+	pb.TicTacGoService_RefreshToken_FullMethodName:        AuthNone,
+	pb.TicTacGoService_GetPlayers_FullMethodName:          AuthNone,
+	pb.TicTacGoService_GetGames_FullMethodName:            AuthNone,
+	pb.TicTacGoService_ListenSteps_FullMethodName:         AuthNone,
+	pb.TicTacGoService_AnalyzeGame_FullMethodName:         AuthNone,
+	pb.TicTacGoService_GetPuzzle_FullMethodName:           AuthNone,
+	pb.TicTacGoService_GetDailyLeaderboard_FullMethodName: AuthNone,
+	pb.TicTacGoService_GetOpeningStats_FullMethodName:     AuthNone,
+	// signing in reveals the players' chat to the players
+	pb.TicTacGoService_GetGame_FullMethodName:        AuthOptional,
+	pb.TicTacGoService_StreamMessages_FullMethodName: AuthOptional,
+}
+
+type playerKey struct{}
+
+// WithPlayer stores the signed in player in the context.
+func WithPlayer(ctx context.Context, player Claims) context.Context {
+	return context.WithValue(ctx, playerKey{}, player)
+}
+
+// PlayerFromContext returns the player signed in by the auth interceptor, if any.
+func PlayerFromContext(ctx context.Context) (Claims, bool) {
+	player, ok := ctx.Value(playerKey{}).(Claims)
+	return player, ok
+}
+
+// RequirePlayer returns the signed in player, or Unauthenticated when the call was not signed in.
+func RequirePlayer(ctx context.Context) (Claims, error) {
+	player, ok := PlayerFromContext(ctx)
+	if !ok {
+		return Claims{}, status.Error(codes.Unauthenticated, "expected a signed in player")
+	}
+	return player, nil
+}
+
+// BearerToken reads the access token from the authorization metadata, accepting both "Bearer <token>"
+// and a bare token. The token is empty when no authorization was sent.
+func BearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	authorization := md["authorization"]
+	if len(authorization) == 0 {
+		return "", nil
+	}
+	if len(authorization) > 1 {
+		log.Printf("expected authorization metadata length 1, got %v", authorization)
+		return "", status.Error(codes.Unauthenticated, "expected metadata 'authorization' to have one value")
+	}
+
+	token := strings.TrimSpace(authorization[0])
+	if scheme, rest, found := strings.Cut(token, " "); found && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(rest)
+	}
+	return token, nil
+}
+
+// Device reads the client's user agent, which names the device a session was started from.
+func Device(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	userAgent := md["user-agent"]
+	if len(userAgent) == 0 {
+		return ""
+	}
+	return userAgent[0]
+}
+
+// AccessTTL returns the configured access token ttl, or the default when none is configured.
+func (s *GrpcServer) AccessTTL() time.Duration {
+	if s.AccessExpiry <= 0 {
+		return DefaultAccessTTL
+	}
+	return s.AccessExpiry
+}
+
+// SessionTTL returns the configured session ttl, or the default when none is configured.
+func (s *GrpcServer) SessionTTL() time.Duration {
+	if s.SessionExpiry <= 0 {
+		return DefaultSessionTTL
+	}
+	return s.SessionExpiry
+}
+
+// Authenticate signs the player of the call into the context according to the method's policy.
+func (s *GrpcServer) Authenticate(ctx context.Context, method string) (context.Context, error) {
+	policy, ok := MethodPolicies[method]
+	if !ok {
+		policy = AuthRequired
+	}
+	if policy == AuthNone {
+		return ctx, nil
+	}
+
+	token, err := BearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		if policy == AuthOptional {
+			return ctx, nil
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "method: %s requires 'authorization' metadata", method)
+	}
+
+	player, err := s.TokenKeys.Verify(token, time.Now())
+	if errors.Is(err, ErrExpiredToken) {
+		log.Printf("expired access token for method: %s", method)
+		return nil, status.Error(codes.Unauthenticated, "access token is expired, refresh it to continue")
+	}
+	if err != nil {
+		log.Printf("invalid access token for method: %s", method)
+		return nil, status.Error(codes.Unauthenticated, "access token is invalid")
+	}
+	return WithPlayer(ctx, player), nil
+}
+
+func (s *GrpcServer) UnaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.Authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStream carries the authenticated context into a stream handler.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authStream) Context() context.Context {
+	return a.ctx
+}
+
+func (s *GrpcServer) StreamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.Authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+// ServerOptions installs the auth, rate limit, role, idempotency and audit interceptors on a grpc server. Calls
+// are authenticated first so that signed in players are limited by their id, and limited before their role is
+// read from the database.
+func (s *GrpcServer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.UnaryAuthInterceptor, s.UnaryRateLimitInterceptor, s.UnaryRoleInterceptor, s.UnaryIdempotencyInterceptor, s.UnaryAuditInterceptor),
+		grpc.ChainStreamInterceptor(s.StreamAuthInterceptor, s.StreamRateLimitInterceptor, s.StreamRoleInterceptor),
+	}
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"TicTacGo/pb"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"log"
+	"time"
+)
+
+// IdempotencyKeyHeader names the metadata a client sets to make retries of a call safe.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// IdempotencyKeyTTL is how long a response is kept for retries with the same key.
+const IdempotencyKeyTTL = time.Hour * 24
+
+const MaxIdempotencyKeyLen = 128
+
+// IdempotentMethods are the mutating methods that honor an idempotency key.
+var IdempotentMethods = map[string]bool{
+	pb.TicTacGoService_CreateGame_FullMethodName:      true,
+	pb.TicTacGoService_MakeMove_FullMethodName:        true,
+	pb.TicTacGoService_SendMessage_FullMethodName:     true,
+	pb.TicTacGoService_RequestTakeback_FullMethodName: true,
+	pb.TicTacGoService_RespondTakeback_FullMethodName: true,
+	pb.TicTacGoService_OfferRematch_FullMethodName:    true,
+	pb.TicTacGoService_RespondRematch_FullMethodName:  true,
+}
+
+// IdempotencyKey returns the key set on the call, if any.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(IdempotencyKeyHeader)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// RequestHash fingerprints a request, so that a key reused with a different payload can be told apart
+// from a retry.
+func RequestHash(method string, req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(method+"\n"), b...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Replay checks a stored key against the retried call, returning the response stored for it.
+func Replay(row db.IdempotencyKey, method string, hash string) (proto.Message, error) {
+	if row.Method != method || row.RequestHash != hash {
+		return nil, status.Error(codes.FailedPrecondition, "idempotency key was already used for a different request")
+	}
+	if row.Response == nil {
+		return nil, status.Error(codes.Aborted, "request with this idempotency key is still in progress")
+	}
+
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(row.ResponseType))
+	if err != nil {
+		log.Printf("failed to find stored response type: %s, %v", row.ResponseType, err)
+		return nil, status.Error(codes.Internal, "failed to replay stored response")
+	}
+	resp := msgType.New().Interface()
+	if err = proto.Unmarshal(row.Response, resp); err != nil {
+		log.Printf("failed to unmarshal stored response: %v", err)
+		return nil, status.Error(codes.Internal, "failed to replay stored response")
+	}
+	return resp, nil
+}
+
+// Idempotent runs the handler once per player and key, storing its response for retries. The key is
+// reserved before the handler runs, so a retry racing the first call is told to try again rather than
+// running twice. Failed calls release the key, since nothing was changed that a retry could repeat.
+func (s *GrpcServer) Idempotent(ctx context.Context, req any, method string, handler grpc.UnaryHandler) (any, error) {
+	key, ok := IdempotencyKey(ctx)
+	player, signedIn := PlayerFromContext(ctx)
+	if !ok || !signedIn || !IdempotentMethods[method] {
+		return handler(ctx, req)
+	}
+	if key == "" || len(key) > MaxIdempotencyKeyLen {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency key must be between 1 and %d chars", MaxIdempotencyKeyLen)
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+	hash, err := RequestHash(method, msg)
+	if err != nil {
+		log.Printf("failed to hash request: %v", err)
+		return nil, status.Error(codes.Internal, "failed to hash request")
+	}
+
+	timeNow := time.Now()
+	reserveParams := db.ReserveIdempotencyKeyParams{
+		PlayerID:    player.ID,
+		Key:         key,
+		Method:      method,
+		RequestHash: hash,
+		CreatedOn:   pgtype.Timestamptz{Time: timeNow, Valid: true},
+		ExpiresOn:   pgtype.Timestamptz{Time: timeNow.Add(IdempotencyKeyTTL), Valid: true},
+	}
+	result, err := s.Store.ReserveIdempotencyKey(ctx, reserveParams)
+	if err != nil {
+		log.Printf("failed to reserve idempotency key: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to reserve idempotency key for player: %d", player.ID)
+	}
+	if result.RowsAffected() == 0 {
+		getParams := db.GetIdempotencyKeyParams{
+			PlayerID:  player.ID,
+			Key:       key,
+			ExpiresOn: pgtype.Timestamptz{Time: timeNow, Valid: true},
+		}
+		row, err := s.Store.GetIdempotencyKey(ctx, getParams)
+		if errors.Is(err, ErrNotFound) {
+			return nil, status.Error(codes.Aborted, "idempotency key was released concurrently, retry the request")
+		}
+		if err != nil {
+			log.Printf("failed to get idempotency key: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to get idempotency key for player: %d", player.ID)
+		}
+		log.Printf("replaying idempotent call: %s for player: %d", method, player.ID)
+		return Replay(row, method, hash)
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		releaseParams := db.ReleaseIdempotencyKeyParams{PlayerID: player.ID, Key: key}
+		if releaseErr := s.Store.ReleaseIdempotencyKey(ctx, releaseParams); releaseErr != nil {
+			log.Printf("failed to release idempotency key: %v", releaseErr)
+		}
+		return resp, err
+	}
+
+	respMsg, ok := resp.(proto.Message)
+	if !ok {
+		return resp, nil
+	}
+	b, err := proto.Marshal(respMsg)
+	if err != nil {
+		log.Printf("failed to marshal response for idempotency key: %v", err)
+		return resp, nil
+	}
+	storeParams := db.StoreIdempotentResponseParams{
+		PlayerID:     player.ID,
+		Key:          key,
+		ResponseType: string(respMsg.ProtoReflect().Descriptor().FullName()),
+		Response:     b,
+	}
+	// the call already succeeded, so failing to store its response only costs retries their replay
+	if err = s.Store.StoreIdempotentResponse(ctx, storeParams); err != nil {
+		log.Printf("failed to store response for idempotency key: %v", err)
+	}
+	return resp, nil
+}
+
+func (s *GrpcServer) UnaryIdempotencyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return s.Idempotent(ctx, req, info.FullMethod, handler)
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoginFreeAttempts is how many failed logins a username may have before each further failure locks it out.
+const LoginFreeAttempts = 3
+
+// LoginFreeAttemptsPerPeer is higher than for a username, since players behind one address share it.
+const LoginFreeAttemptsPerPeer = 20
+
+// LoginBaseLockout is the first lockout, which doubles with every further failure up to LoginMaxLockout.
+const LoginBaseLockout = time.Second
+const LoginMaxLockout = time.Minute * 15
+
+// LoginFailureWindow is how long a failed login is remembered.
+const LoginFailureWindow = time.Hour
+
+// LoginSubject is something failed logins are counted against, a username or the address they came from.
+type LoginSubject struct {
+	Key          string
+	FreeAttempts int32
+}
+
+// dummyHash is compared against for unknown usernames, so that they take as long as a wrong password.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	if err != nil {
+		log.Fatalf("failed to generate dummy password hash: %v", err)
+	}
+	return hash
+})
+
+// UsernameSubject counts failed logins against a username, which is matched regardless of case.
+func UsernameSubject(username string) LoginSubject {
+	return LoginSubject{Key: "user:" + strings.ToUpper(username), FreeAttempts: LoginFreeAttempts}
+}
+
+// LoginSubjects returns the subjects for a login attempt, the username and the peer's address when known.
+func LoginSubjects(ctx context.Context, username string) []LoginSubject {
+	subjects := []LoginSubject{UsernameSubject(username)}
+
+	host, ok := PeerHost(ctx)
+	if !ok {
+		return subjects
+	}
+	return append(subjects, LoginSubject{Key: "peer:" + host, FreeAttempts: LoginFreeAttemptsPerPeer})
+}
+
+// LoginLockout returns how long a subject is locked out after its failures within the window.
+func LoginLockout(failures int32, freeAttempts int32) time.Duration {
+	if failures <= freeAttempts {
+		return 0
+	}
+	lockout := LoginBaseLockout
+	for i := freeAttempts + 1; i < failures && lockout < LoginMaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, LoginMaxLockout)
+}
+
+// CheckLoginLockout returns ResourceExhausted with the time to wait when any of the subjects is locked out.
+func (s *GrpcServer) CheckLoginLockout(ctx context.Context, subjects []LoginSubject) error {
+	var keys []string
+	for _, subject := range subjects {
+		keys = append(keys, subject.Key)
+	}
+
+	lockedUntil, err := s.Store.GetLoginLockout(ctx, keys)
+	if err != nil {
+		log.Printf("failed to get login lockout: %v", err)
+		return status.Error(codes.Internal, "failed to get login lockout")
+	}
+
+	wait := time.Until(lockedUntil.Time)
+	if !lockedUntil.Valid || wait <= 0 {
+		return nil
+	}
+
+	log.Printf("login for subjects: %v is locked out for %v", keys, wait)
+	retryDelay := time.Duration(math.Ceil(wait.Seconds())) * time.Second
+	retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)}
+	st, err := status.New(codes.ResourceExhausted, "too many failed logins, try again later").WithDetails(retry)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// RecordLoginFailure counts a failed login against the subjects, locking out those that ran out of attempts.
+// Failing to record is only logged, so the caller still reports the failed login.
+func (s *GrpcServer) RecordLoginFailure(ctx context.Context, subjects []LoginSubject) {
+	timeNow := time.Now()
+	for _, subject := range subjects {
+		params := db.RecordLoginFailureParams{
+			Subject:     subject.Key,
+			FailedOn:    pgtype.Timestamptz{Time: timeNow, Valid: true},
+			WindowStart: pgtype.Timestamptz{Time: timeNow.Add(-LoginFailureWindow), Valid: true},
+		}
+		failures, err := s.Store.RecordLoginFailure(ctx, params)
+		if err != nil {
+			log.Printf("failed to record login failure for subject: %s, %v", subject.Key, err)
+			continue
+		}
+
+		lockout := LoginLockout(failures, subject.FreeAttempts)
+		if lockout == 0 {
+			continue
+		}
+		lockParams := db.LockLoginParams{
+			Subject:     subject.Key,
+			LockedUntil: pgtype.Timestamptz{Time: timeNow.Add(lockout), Valid: true},
+		}
+		if err = s.Store.LockLogin(ctx, lockParams); err != nil {
+			log.Printf("failed to lock login for subject: %s, %v", subject.Key, err)
+			continue
+		}
+		log.Printf("locked login for subject: %s after %d failures for %v", subject.Key, failures, lockout)
+	}
+}
+
+// ClearLoginFailures forgets the failed logins for the username after it logged in. The peer keeps its
+// failures, since they may have been made against other usernames.
+func (s *GrpcServer) ClearLoginFailures(ctx context.Context, username string) {
+	subject := UsernameSubject(username)
+	if err := s.Store.ClearLoginFailures(ctx, subject.Key); err != nil {
+		log.Printf("failed to clear login failures for subject: %s, %v", subject.Key, err)
+	}
+}
@@ -3,10 +3,22 @@ package server
 import (
 	"TicTacGo/db"
 	"TicTacGo/pb"
+	"TicTacGo/tictactoe"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"strings"
 )
 
+// DeletedPlayerName is shown in place of the name of a player who deleted their account.
+const DeletedPlayerName = "deleted player"
+
+func PlayerName(name pgtype.Text, deleted bool) string {
+	if deleted {
+		return DeletedPlayerName
+	}
+	return name.String
+}
+
 func MapGetGame(gameRow db.GetGameRow, stepRows []db.GameStep) *pb.Game {
 	var steps []*pb.Step
 	for _, stepRow := range stepRows {
@@ -17,7 +29,7 @@ func MapGetGame(gameRow db.GetGameRow, stepRows []db.GameStep) *pb.Game {
 	if gameRow.OPlayer.Valid {
 		secondPlayer = &pb.Player{
 			Id:       gameRow.OPlayer.Int64,
-			Username: gameRow.OPlayerName.String,
+			Username: PlayerName(gameRow.OPlayerName, gameRow.OPlayerDeleted),
 		}
 	}
 
@@ -25,15 +37,25 @@ func MapGetGame(gameRow db.GetGameRow, stepRows []db.GameStep) *pb.Game {
 		Id: gameRow.ID,
 		XPlayer: &pb.Player{
 			Id:       gameRow.XPlayer,
-			Username: gameRow.XPlayerName.String,
+			Username: PlayerName(gameRow.XPlayerName, gameRow.XPlayerDeleted),
 		},
-		OPlayer:    secondPlayer,
-		BoardState: gameRow.BoardState,
-		XTurn:      gameRow.XTurn.Bool,
-		UpdatedOn:  &timestamppb.Timestamp{Seconds: gameRow.UpdatedOn.Time.Unix()},
-		StartedOn:  &timestamppb.Timestamp{Seconds: gameRow.StartedOn.Time.Unix()},
-		Result:     gameRow.Result,
-		Steps:      steps,
+		OPlayer:           secondPlayer,
+		BoardState:        gameRow.BoardState,
+		XTurn:             gameRow.XTurn.Bool,
+		UpdatedOn:         &timestamppb.Timestamp{Seconds: gameRow.UpdatedOn.Time.Unix()},
+		StartedOn:         &timestamppb.Timestamp{Seconds: gameRow.StartedOn.Time.Unix()},
+		Result:            gameRow.Result,
+		Steps:             steps,
+		HintBudget:        gameRow.HintBudget,
+		XHintsUsed:        gameRow.XHintsUsed,
+		OHintsUsed:        gameRow.OHintsUsed,
+		StartState:        gameRow.StartState,
+		Opening:           MapOpening(gameRow.Opening),
+		TakebacksDisabled: gameRow.TakebacksDisabled,
+		TakebackBy:        gameRow.TakebackBy.Int64,
+		RematchOf:         gameRow.RematchOf.Int64,
+		RematchBy:         gameRow.RematchBy.Int64,
+		EndedBy:           gameRow.EndedBy.Int64,
 	}
 }
 
@@ -42,23 +64,37 @@ func MapGetGameWithUpdt(row db.GetGameRow, updt db.UpdateGameParams) *pb.Game {
 	if row.OPlayer.Valid {
 		oPlayer = &pb.Player{
 			Id:       row.OPlayer.Int64,
-			Username: row.OPlayerName.String,
+			Username: PlayerName(row.OPlayerName, row.OPlayerDeleted),
 		}
 	}
 
+	opening := row.Opening
+	if updt.Opening.Valid {
+		opening = updt.Opening.Int32
+	}
+
 	return &pb.Game{
 		Id: row.ID,
 		XPlayer: &pb.Player{
 			Id:       row.XPlayer,
-			Username: row.XPlayerName.String,
+			Username: PlayerName(row.XPlayerName, row.XPlayerDeleted),
 		},
-		OPlayer:    oPlayer,
-		BoardState: updt.BoardState,
-		XTurn:      updt.XTurn.Bool,
-		UpdatedOn:  &timestamppb.Timestamp{Seconds: updt.UpdatedOn.Time.Unix()},
-		StartedOn:  &timestamppb.Timestamp{Seconds: row.StartedOn.Time.Unix()},
-		Result:     updt.Result,
-		Steps:      []*pb.Step{},
+		OPlayer:           oPlayer,
+		BoardState:        updt.BoardState,
+		XTurn:             updt.XTurn.Bool,
+		UpdatedOn:         &timestamppb.Timestamp{Seconds: updt.UpdatedOn.Time.Unix()},
+		StartedOn:         &timestamppb.Timestamp{Seconds: row.StartedOn.Time.Unix()},
+		Result:            updt.Result,
+		Steps:             []*pb.Step{},
+		HintBudget:        row.HintBudget,
+		XHintsUsed:        row.XHintsUsed,
+		OHintsUsed:        row.OHintsUsed,
+		StartState:        row.StartState,
+		Opening:           MapOpening(opening),
+		TakebacksDisabled: row.TakebacksDisabled,
+		RematchOf:         row.RematchOf.Int64,
+		RematchBy:         row.RematchBy.Int64,
+		EndedBy:           row.EndedBy.Int64,
 	}
 }
 
@@ -79,7 +115,7 @@ func MapGetGames(gameRows []db.GetGamesRow, stepRows []db.GameStep) []*pb.Game {
 		if row.OPlayer.Valid {
 			oPlayer = &pb.Player{
 				Id:       row.OPlayer.Int64,
-				Username: row.OPlayerName.String,
+				Username: PlayerName(row.OPlayerName, row.OPlayerDeleted),
 			}
 		}
 		steps, ok := stepsMap[row.ID]
@@ -90,15 +126,25 @@ func MapGetGames(gameRows []db.GetGamesRow, stepRows []db.GameStep) []*pb.Game {
 			Id: row.ID,
 			XPlayer: &pb.Player{
 				Id:       row.XPlayer,
-				Username: row.XPlayerName.String,
+				Username: PlayerName(row.XPlayerName, row.XPlayerDeleted),
 			},
-			OPlayer:    oPlayer,
-			BoardState: row.BoardState,
-			XTurn:      row.XTurn.Bool,
-			UpdatedOn:  &timestamppb.Timestamp{Seconds: row.UpdatedOn.Time.Unix()},
-			StartedOn:  &timestamppb.Timestamp{Seconds: row.StartedOn.Time.Unix()},
-			Result:     row.Result,
-			Steps:      steps,
+			OPlayer:           oPlayer,
+			BoardState:        row.BoardState,
+			XTurn:             row.XTurn.Bool,
+			UpdatedOn:         &timestamppb.Timestamp{Seconds: row.UpdatedOn.Time.Unix()},
+			StartedOn:         &timestamppb.Timestamp{Seconds: row.StartedOn.Time.Unix()},
+			Result:            row.Result,
+			Steps:             steps,
+			HintBudget:        row.HintBudget,
+			XHintsUsed:        row.XHintsUsed,
+			OHintsUsed:        row.OHintsUsed,
+			StartState:        row.StartState,
+			Opening:           MapOpening(row.Opening),
+			TakebacksDisabled: row.TakebacksDisabled,
+			TakebackBy:        row.TakebackBy.Int64,
+			RematchOf:         row.RematchOf.Int64,
+			RematchBy:         row.RematchBy.Int64,
+			EndedBy:           row.EndedBy.Int64,
 		}
 		games = append(games, &game)
 	}
@@ -133,6 +179,265 @@ func MapPlayers(rows []db.GetPlayersRow) []*pb.Player {
 	return players
 }
 
+func MapHint(gameRow db.GetGameRow, solution tictactoe.Solution) *pb.Hint {
+	var moves []*pb.HintMove
+	for _, tile := range solution.Moves {
+		move := &pb.HintMove{
+			Row:     tile.Row,
+			Col:     tile.Col,
+			Outcome: &pb.Evaluation{Result: solution.Result, Distance: solution.Distance},
+		}
+		moves = append(moves, move)
+	}
+
+	hintsUsed := gameRow.OHintsUsed
+	if gameRow.XTurn.Bool {
+		hintsUsed = gameRow.XHintsUsed
+	}
+
+	return &pb.Hint{
+		GameId:     gameRow.ID,
+		Moves:      moves,
+		HintsUsed:  hintsUsed + 1,
+		HintBudget: gameRow.HintBudget,
+	}
+}
+
+func MapPuzzle(row db.Puzzle) *pb.Puzzle {
+	return &pb.Puzzle{
+		Id:         row.ID,
+		BoardState: row.BoardState,
+		XTurn:      row.XTurn,
+		Depth:      row.Depth,
+		Difficulty: row.Difficulty,
+	}
+}
+
+func MapPuzzleAttempt(puzzleRow db.Puzzle, step tictactoe.PuzzleStep, streakRow db.GetPuzzleStreakRow) *pb.PuzzleAttempt {
+	var reply *pb.Tile
+	if step.Reply != nil {
+		reply = &pb.Tile{Row: step.Reply.Row, Col: step.Reply.Col}
+	}
+
+	// the solution is only revealed once the attempt is over
+	var solution *pb.Tile
+	if step.Status != tictactoe.PuzzleSolving {
+		solution = &pb.Tile{Row: puzzleRow.SolutionRow, Col: puzzleRow.SolutionCol}
+	}
+
+	return &pb.PuzzleAttempt{
+		PuzzleId:   puzzleRow.ID,
+		BoardState: tictactoe.BoardToString(step.Board),
+		XTurn:      step.XTurn,
+		MovesLeft:  step.MovesLeft,
+		Status:     step.Status,
+		Reply:      reply,
+		Solution:   solution,
+		Streak:     streakRow.PuzzleStreak,
+		BestStreak: streakRow.BestPuzzleStreak,
+	}
+}
+
+func MapDailyLeaderboard(day string, rows []db.GetDailyLeaderboardRow) *pb.DailyLeaderboard {
+	var entries []*pb.LeaderboardEntry
+	for i, row := range rows {
+		entry := &pb.LeaderboardEntry{
+			Rank: int32(i + 1),
+			Player: &pb.Player{
+				Id:       row.PlayerID,
+				Username: row.Username,
+			},
+			DurationMs: row.CompletedOn.Time.Sub(row.StartedOn.Time).Milliseconds(),
+		}
+		entries = append(entries, entry)
+	}
+
+	return &pb.DailyLeaderboard{Day: day, Entries: entries}
+}
+
+// MapSeries scores the finished games of a series for the players of the game, who swap colors between
+// rematches.
+func MapSeries(gameRow db.GetGameRow, seriesRows []db.GetSeriesRow) *pb.Series {
+	series := &pb.Series{}
+	for _, row := range seriesRows {
+		var winner int64
+		switch row.Result {
+		case tictactoe.Playing:
+			continue
+		case tictactoe.XWon:
+			winner = row.XPlayer
+		case tictactoe.OWon:
+			winner = row.OPlayer.Int64
+		case tictactoe.Draw:
+			series.Draws++
+		}
+		series.Games++
+
+		if winner == 0 {
+			continue
+		}
+		if winner == gameRow.XPlayer {
+			series.XPlayerWins++
+		} else if gameRow.OPlayer.Valid && winner == gameRow.OPlayer.Int64 {
+			series.OPlayerWins++
+		}
+	}
+	return series
+}
+
+func MapSessions(rows []db.GetPlayerSessionsRow, currentID int64) *pb.Sessions {
+	var sessions []*pb.Session
+	for _, row := range rows {
+		session := &pb.Session{
+			Id:         row.ID,
+			Device:     row.Device,
+			CreatedOn:  &timestamppb.Timestamp{Seconds: row.CreatedOn.Time.Unix()},
+			LastSeenOn: &timestamppb.Timestamp{Seconds: row.LastSeenOn.Time.Unix()},
+			ExpiresOn:  &timestamppb.Timestamp{Seconds: row.ExpiresOn.Time.Unix()},
+			Current:    row.ID == currentID,
+		}
+		sessions = append(sessions, session)
+	}
+	return &pb.Sessions{Sessions: sessions}
+}
+
+func MapAccount(row db.PlayerAccount) *pb.Account {
+	account := &pb.Account{
+		Player:       &pb.Player{Id: row.ID, Username: row.Username},
+		Role:         row.Role,
+		RegisteredOn: &timestamppb.Timestamp{Seconds: row.RegisteredOn.Time.Unix()},
+		BanReason:    row.BanReason,
+	}
+	if row.BannedOn.Valid {
+		account.BannedOn = &timestamppb.Timestamp{Seconds: row.BannedOn.Time.Unix()}
+	}
+	return account
+}
+
+func MapAccounts(rows []db.PlayerAccount) *pb.Accounts {
+	var accounts []*pb.Account
+	for _, row := range rows {
+		accounts = append(accounts, MapAccount(row))
+	}
+	return &pb.Accounts{Accounts: accounts}
+}
+
+func MapAuditEvents(rows []db.AuditEvent) *pb.AuditEvents {
+	var events []*pb.AuditEvent
+	for _, row := range rows {
+		event := &pb.AuditEvent{
+			Id:        row.ID,
+			ActorId:   row.ActorID.Int64,
+			EventType: row.EventType,
+			TargetId:  row.TargetID.Int64,
+			Peer:      row.Peer,
+			Method:    row.Method,
+			Payload:   string(row.Payload),
+			CreatedOn: timestamppb.New(row.CreatedOn.Time),
+		}
+		events = append(events, event)
+	}
+	return &pb.AuditEvents{Events: events}
+}
+
+func MapMessage(row db.GetGameMessagesRow) *pb.Message {
+	return &pb.Message{
+		Id:     row.ID,
+		GameId: row.GameID,
+		Player: &pb.Player{
+			Id:       row.PlayerID,
+			Username: row.Username,
+		},
+		Channel: row.Channel,
+		Text:    row.Text,
+		SentOn:  &timestamppb.Timestamp{Seconds: row.SentOn.Time.Unix()},
+	}
+}
+
+// MapMessages keeps the players' channel for the seated players only, spectator chat is public.
+func MapMessages(rows []db.GetGameMessagesRow, seated bool) []*pb.Message {
+	var messages []*pb.Message
+	for _, row := range rows {
+		if row.Channel == ChannelPlayers && !seated {
+			continue
+		}
+		messages = append(messages, MapMessage(row))
+	}
+	return messages
+}
+
+// MapOpening returns nil for games that have no opening yet.
+func MapOpening(id int32) *pb.Opening {
+	opening, ok := tictactoe.GetOpening(id)
+	if !ok {
+		return nil
+	}
+	return &pb.Opening{Id: opening.ID, Name: opening.Name, Family: opening.Family}
+}
+
+// MapOpeningStats totals the game counts per opening and result. Wins and losses are counted for the
+// player when given, otherwise for x.
+func MapOpeningStats(rows []db.GetOpeningStatsRow, filtered bool) *pb.OpeningStats {
+	stats := &pb.OpeningStats{}
+	byOpening := make(map[int32]*pb.OpeningStat)
+	for _, row := range rows {
+		stat, ok := byOpening[row.Opening]
+		if !ok {
+			stat = &pb.OpeningStat{Opening: MapOpening(row.Opening)}
+			byOpening[row.Opening] = stat
+			stats.Stats = append(stats.Stats, stat)
+		}
+		stat.Games += row.Games
+		stats.Games += row.Games
+
+		forX := row.PlayerIsX || !filtered
+		switch {
+		case row.Result == tictactoe.Draw:
+			stat.Draws += row.Games
+		case row.Result == tictactoe.XWon && forX || row.Result == tictactoe.OWon && !forX:
+			stat.Wins += row.Games
+		case row.Result == tictactoe.XWon || row.Result == tictactoe.OWon:
+			stat.Losses += row.Games
+		}
+	}
+
+	for _, stat := range stats.Stats {
+		stat.Frequency = float32(stat.Games) / float32(stats.Games)
+		if decided := stat.Wins + stat.Draws + stat.Losses; decided > 0 {
+			stat.WinRate = float32(stat.Wins) / float32(decided)
+			stat.DrawRate = float32(stat.Draws) / float32(decided)
+			stat.LossRate = float32(stat.Losses) / float32(decided)
+		}
+	}
+	return stats
+}
+
+func MapAnalysis(gameId int64, rows []db.Analysis) *pb.GameAnalysis {
+	var moves []*pb.MoveAnalysis
+	var analyzed []tictactoe.MoveAnalysis
+
+	for _, row := range rows {
+		move := &pb.MoveAnalysis{
+			Ord:        row.Ord,
+			MoveRow:    row.MoveRow,
+			MoveCol:    row.MoveCol,
+			XMoved:     row.XMoved,
+			Best:       &pb.Evaluation{Result: row.BestResult, Distance: row.BestDistance},
+			Played:     &pb.Evaluation{Result: row.PlayedResult, Distance: row.PlayedDistance},
+			Annotation: row.Annotation,
+		}
+		moves = append(moves, move)
+		analyzed = append(analyzed, tictactoe.MoveAnalysis{XMoved: row.XMoved, Annotation: row.Annotation})
+	}
+
+	return &pb.GameAnalysis{
+		GameId:    gameId,
+		Moves:     moves,
+		XAccuracy: tictactoe.Accuracy(analyzed, true),
+		OAccuracy: tictactoe.Accuracy(analyzed, false),
+	}
+}
+
 func GamesString(games []*pb.Game) string {
 	var sb strings.Builder
 	for i, game := range games {
@@ -0,0 +1,1219 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memKey struct {
+	playerID int64
+	key      string
+}
+
+type memAttemptKey struct {
+	puzzleID int64
+	playerID int64
+}
+
+// memData is what a MemStore holds, rows are kept by value so that a row read from a map is a copy until it is
+// put back.
+type memData struct {
+	players       map[int64]db.PlayerAccount
+	sessions      map[int64]db.PlayerSession
+	games         map[int64]db.Game
+	steps         map[int64][]db.GameStep
+	analyses      map[int64][]db.Analysis
+	puzzles       map[int64]db.Puzzle
+	attempts      map[memAttemptKey]db.PuzzleAttempt
+	dailyPuzzles  map[string]int64
+	messages      map[int64]db.GameMessage
+	loginFailures map[string]db.LoginFailure
+	keys          map[memKey]db.IdempotencyKey
+	auditEvents   []db.AuditEvent
+
+	// ids are not handed out again when a transaction fails, as with postgres sequences
+	nextPlayer  int64
+	nextSession int64
+	nextGame    int64
+	nextPuzzle  int64
+	nextMessage int64
+}
+
+// MemStore is the Store kept in memory, for tests and for running the server without postgres. A single
+// lock guards it, and a transaction holds the lock until it is done. Writes made in a transaction remember the
+// rows they replace, which are put back in reverse order if it fails.
+type MemStore struct {
+	mu   *sync.Mutex
+	data *memData
+	inTx bool
+	undo []func()
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		mu: &sync.Mutex{},
+		data: &memData{
+			players:       map[int64]db.PlayerAccount{},
+			sessions:      map[int64]db.PlayerSession{},
+			games:         map[int64]db.Game{},
+			steps:         map[int64][]db.GameStep{},
+			analyses:      map[int64][]db.Analysis{},
+			puzzles:       map[int64]db.Puzzle{},
+			attempts:      map[memAttemptKey]db.PuzzleAttempt{},
+			dailyPuzzles:  map[string]int64{},
+			messages:      map[int64]db.GameMessage{},
+			loginFailures: map[string]db.LoginFailure{},
+			keys:          map[memKey]db.IdempotencyKey{},
+		},
+	}
+}
+
+// lock takes the store's lock unless it is already held by the transaction, returning its release.
+func (m *MemStore) lock() func() {
+	if m.inTx {
+		return func() {}
+	}
+	m.mu.Lock()
+	return m.mu.Unlock
+}
+
+func (m *MemStore) InTx(ctx context.Context, name string, fn func(ctx context.Context, tx Store) error) error {
+	if m.inTx {
+		return fn(ctx, m)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx := &MemStore{mu: m.mu, data: m.data, inTx: true}
+	if err := fn(ctx, tx); err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		return err
+	}
+	return nil
+}
+
+// onUndo remembers how to revert a write when it is made in a transaction.
+func (m *MemStore) onUndo(undo func()) {
+	if m.inTx {
+		m.undo = append(m.undo, undo)
+	}
+}
+
+// memPut stores the row under its key, remembering the row it replaces.
+func memPut[K comparable, V any](m *MemStore, table map[K]V, key K, row V) {
+	memRemember(m, table, key)
+	table[key] = row
+}
+
+// memDelete removes the row under the key, remembering it.
+func memDelete[K comparable, V any](m *MemStore, table map[K]V, key K) {
+	memRemember(m, table, key)
+	delete(table, key)
+}
+
+func memRemember[K comparable, V any](m *MemStore, table map[K]V, key K) {
+	old, existed := table[key]
+	m.onUndo(func() {
+		if existed {
+			table[key] = old
+		} else {
+			delete(table, key)
+		}
+	})
+}
+
+func memTag(op string, rows int) pgconn.CommandTag {
+	return pgconn.NewCommandTag(fmt.Sprintf("%s %d", op, rows))
+}
+
+func uniqueViolation(constraint string) error {
+	return fmt.Errorf("%w: duplicate key value violates unique constraint \"%s\"", ErrConflict, constraint)
+}
+
+func memNow() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now(), Valid: true}
+}
+
+// after compares timestamps the way sql does, a null is never after or before anything.
+func after(a pgtype.Timestamptz, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && a.Time.After(b.Time)
+}
+
+// memPage skips the offset rows and keeps up to the limit of the rest.
+func memPage[T any](rows []T, offset int32, limit int32) []T {
+	if int(offset) >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// memDay is the key of a daily puzzle.
+func memDay(day pgtype.Date) string {
+	return day.Time.Format(time.DateOnly)
+}
+
+func (m *MemStore) InsertPlayer(ctx context.Context, arg db.InsertPlayerParams) (db.InsertPlayerRow, error) {
+	defer m.lock()()
+	for _, player := range m.data.players {
+		if strings.EqualFold(player.Username, arg.Username) {
+			return db.InsertPlayerRow{}, uniqueViolation("player_accounts_names")
+		}
+	}
+	m.data.nextPlayer++
+	player := db.PlayerAccount{
+		ID:           m.data.nextPlayer,
+		Username:     arg.Username,
+		Passwd:       arg.Passwd,
+		Salt:         arg.Salt,
+		Role:         RolePlayer,
+		RegisteredOn: memNow(),
+	}
+	memPut(m, m.data.players, player.ID, player)
+	return db.InsertPlayerRow{ID: player.ID, Username: player.Username}, nil
+}
+
+func (m *MemStore) GetPlayer(ctx context.Context, id int64) (db.GetPlayerRow, error) {
+	defer m.lock()()
+	player, ok := m.data.players[id]
+	if !ok {
+		return db.GetPlayerRow{}, ErrNotFound
+	}
+	return db.GetPlayerRow{ID: player.ID, Username: player.Username}, nil
+}
+
+func (m *MemStore) GetAccount(ctx context.Context, id int64) (db.GetAccountRow, error) {
+	defer m.lock()()
+	player, ok := m.data.players[id]
+	if !ok || player.DeletedOn.Valid {
+		return db.GetAccountRow{}, ErrNotFound
+	}
+	return db.GetAccountRow{ID: player.ID, Username: player.Username, Passwd: player.Passwd}, nil
+}
+
+func (m *MemStore) GetAccountByName(ctx context.Context, upper interface{}) (db.GetAccountByNameRow, error) {
+	defer m.lock()()
+	name := fmt.Sprint(upper)
+	for _, player := range m.data.players {
+		if strings.EqualFold(player.Username, name) && !player.DeletedOn.Valid {
+			return db.GetAccountByNameRow{ID: player.ID, Username: player.Username, Passwd: player.Passwd, BannedOn: player.BannedOn}, nil
+		}
+	}
+	return db.GetAccountByNameRow{}, ErrNotFound
+}
+
+func (m *MemStore) GetPlayers(ctx context.Context, arg db.GetPlayersParams) ([]db.GetPlayersRow, error) {
+	defer m.lock()()
+	timeNow := memNow()
+	var rows []db.GetPlayersRow
+	for _, player := range m.data.players {
+		if player.ID <= arg.ID || player.DeletedOn.Valid {
+			continue
+		}
+		row := db.GetPlayersRow{ID: player.ID, Username: player.Username}
+		for _, session := range m.data.sessions {
+			if session.PlayerID == player.ID && after(session.ExpiresOn, timeNow) {
+				row.Cnt++
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	if len(rows) > int(arg.Limit) {
+		rows = rows[:arg.Limit]
+	}
+	return rows, nil
+}
+
+func (m *MemStore) UpdatePassword(ctx context.Context, arg db.UpdatePasswordParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	player, ok := m.data.players[arg.ID]
+	if !ok || player.DeletedOn.Valid {
+		return memTag("UPDATE", 0), nil
+	}
+	player.Passwd = arg.Passwd
+	memPut(m, m.data.players, player.ID, player)
+	return memTag("UPDATE", 1), nil
+}
+
+func (m *MemStore) AnonymizePlayer(ctx context.Context, arg db.AnonymizePlayerParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	player, ok := m.data.players[arg.ID]
+	if !ok || player.DeletedOn.Valid {
+		return memTag("UPDATE", 0), nil
+	}
+	player.Username = fmt.Sprintf("deleted player %019d", player.ID)
+	player.Passwd = ""
+	player.Salt = ""
+	player.DeletedOn = arg.DeletedOn
+	memPut(m, m.data.players, player.ID, player)
+	return memTag("UPDATE", 1), nil
+}
+
+func (m *MemStore) LockPlayer(ctx context.Context, id int64) (int64, error) {
+	defer m.lock()()
+	if _, ok := m.data.players[id]; !ok {
+		return 0, ErrNotFound
+	}
+	return id, nil
+}
+
+func (m *MemStore) GetPlayerRole(ctx context.Context, id int64) (db.GetPlayerRoleRow, error) {
+	defer m.lock()()
+	player, ok := m.data.players[id]
+	if !ok || player.DeletedOn.Valid {
+		return db.GetPlayerRoleRow{}, ErrNotFound
+	}
+	return db.GetPlayerRoleRow{Role: player.Role, BannedOn: player.BannedOn}, nil
+}
+
+// updatePlayer applies the change to the player if they exist and match, returning the changed row.
+func (m *MemStore) updatePlayer(id int64, match func(player db.PlayerAccount) bool, change func(player *db.PlayerAccount)) (db.PlayerAccount, error) {
+	player, ok := m.data.players[id]
+	if !ok || !match(player) {
+		return db.PlayerAccount{}, ErrNotFound
+	}
+	change(&player)
+	memPut(m, m.data.players, id, player)
+	return player, nil
+}
+
+func (m *MemStore) SetRole(ctx context.Context, arg db.SetRoleParams) (db.PlayerAccount, error) {
+	defer m.lock()()
+	return m.updatePlayer(arg.ID, func(player db.PlayerAccount) bool { return !player.DeletedOn.Valid }, func(player *db.PlayerAccount) {
+		player.Role = arg.Role
+	})
+}
+
+func (m *MemStore) BanPlayer(ctx context.Context, arg db.BanPlayerParams) (db.PlayerAccount, error) {
+	defer m.lock()()
+	return m.updatePlayer(arg.ID, func(player db.PlayerAccount) bool {
+		return !player.DeletedOn.Valid && !player.BannedOn.Valid
+	}, func(player *db.PlayerAccount) {
+		player.BannedOn = arg.BannedOn
+		player.BanReason = arg.BanReason
+	})
+}
+
+func (m *MemStore) UnbanPlayer(ctx context.Context, id int64) (db.PlayerAccount, error) {
+	defer m.lock()()
+	return m.updatePlayer(id, func(player db.PlayerAccount) bool { return player.BannedOn.Valid }, func(player *db.PlayerAccount) {
+		player.BannedOn = pgtype.Timestamptz{}
+		player.BanReason = ""
+	})
+}
+
+func (m *MemStore) GetRegistrations(ctx context.Context, arg db.GetRegistrationsParams) ([]db.PlayerAccount, error) {
+	defer m.lock()()
+	var rows []db.PlayerAccount
+	for _, player := range m.data.players {
+		if !player.DeletedOn.Valid && player.RegisteredOn.Valid && arg.Since.Valid && !after(arg.Since, player.RegisteredOn) {
+			rows = append(rows, player)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID > rows[j].ID })
+	return memPage(rows, arg.Offset, arg.Limit), nil
+}
+
+func (m *MemStore) InsertSession(ctx context.Context, arg db.InsertSessionParams) (int64, error) {
+	defer m.lock()()
+	for _, session := range m.data.sessions {
+		if session.TokenHash == arg.TokenHash {
+			return 0, uniqueViolation("player_sessions_pkey")
+		}
+	}
+	m.data.nextSession++
+	memPut(m, m.data.sessions, m.data.nextSession, db.PlayerSession{
+		ID:         m.data.nextSession,
+		TokenHash:  arg.TokenHash,
+		PlayerID:   arg.PlayerID,
+		Device:     arg.Device,
+		CreatedOn:  arg.CreatedOn,
+		LastSeenOn: arg.LastSeenOn,
+		ExpiresOn:  arg.ExpiresOn,
+	})
+	return m.data.nextSession, nil
+}
+
+func (m *MemStore) GetSession(ctx context.Context, tokenHash string) (db.GetSessionRow, error) {
+	defer m.lock()()
+	timeNow := memNow()
+	for _, session := range m.data.sessions {
+		if session.TokenHash != tokenHash || !after(session.ExpiresOn, timeNow) {
+			continue
+		}
+		player, ok := m.data.players[session.PlayerID]
+		if !ok || player.BannedOn.Valid {
+			continue
+		}
+		return db.GetSessionRow{ID: player.ID, Username: player.Username, SessionID: session.ID}, nil
+	}
+	return db.GetSessionRow{}, ErrNotFound
+}
+
+func (m *MemStore) RotateSession(ctx context.Context, arg db.RotateSessionParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	session, ok := m.data.sessions[arg.ID]
+	if !ok || session.TokenHash != arg.TokenHash {
+		return memTag("UPDATE", 0), nil
+	}
+	session.TokenHash = arg.NewTokenHash
+	session.LastSeenOn = arg.LastSeenOn
+	session.ExpiresOn = arg.ExpiresOn
+	memPut(m, m.data.sessions, session.ID, session)
+	return memTag("UPDATE", 1), nil
+}
+
+func (m *MemStore) GetPlayerSessions(ctx context.Context, playerID int64) ([]db.GetPlayerSessionsRow, error) {
+	defer m.lock()()
+	timeNow := memNow()
+	var rows []db.GetPlayerSessionsRow
+	for _, session := range m.data.sessions {
+		if session.PlayerID != playerID || !after(session.ExpiresOn, timeNow) {
+			continue
+		}
+		rows = append(rows, db.GetPlayerSessionsRow{
+			ID:         session.ID,
+			Device:     session.Device,
+			CreatedOn:  session.CreatedOn,
+			LastSeenOn: session.LastSeenOn,
+			ExpiresOn:  session.ExpiresOn,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].LastSeenOn.Time.After(rows[j].LastSeenOn.Time) })
+	return rows, nil
+}
+
+// deleteSessions removes the matching sessions, returning how many were removed.
+func (m *MemStore) deleteSessions(match func(session db.PlayerSession) bool) pgconn.CommandTag {
+	deleted := 0
+	for id, session := range m.data.sessions {
+		if match(session) {
+			memDelete(m, m.data.sessions, id)
+			deleted++
+		}
+	}
+	return memTag("DELETE", deleted)
+}
+
+func (m *MemStore) DeleteSession(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.deleteSessions(func(session db.PlayerSession) bool { return session.ID == id }), nil
+}
+
+func (m *MemStore) DeletePlayerSessions(ctx context.Context, playerID int64) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.deleteSessions(func(session db.PlayerSession) bool { return session.PlayerID == playerID }), nil
+}
+
+func (m *MemStore) DeleteOtherSessions(ctx context.Context, arg db.DeleteOtherSessionsParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.deleteSessions(func(session db.PlayerSession) bool {
+		return session.PlayerID == arg.PlayerID && session.ID != arg.ID
+	}), nil
+}
+
+func (m *MemStore) PurgeExpiredSessions(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.deleteSessions(func(session db.PlayerSession) bool { return !after(session.ExpiresOn, expiresOn) }), nil
+}
+
+func (m *MemStore) InsertGame(ctx context.Context, arg db.InsertGameParams) (int64, error) {
+	defer m.lock()()
+	if arg.RematchOf.Valid {
+		for _, game := range m.data.games {
+			if game.RematchOf.Valid && game.RematchOf.Int64 == arg.RematchOf.Int64 {
+				return 0, uniqueViolation("games_rematch_of")
+			}
+		}
+	}
+	m.data.nextGame++
+	memPut(m, m.data.games, m.data.nextGame, db.Game{
+		ID:                m.data.nextGame,
+		XPlayer:           arg.XPlayer,
+		OPlayer:           arg.OPlayer,
+		BoardState:        arg.BoardState,
+		StartState:        arg.StartState,
+		XTurn:             arg.XTurn,
+		UpdatedOn:         arg.UpdatedOn,
+		StartedOn:         arg.StartedOn,
+		HintBudget:        arg.HintBudget,
+		TakebacksDisabled: arg.TakebacksDisabled,
+		RematchOf:         arg.RematchOf,
+	})
+	return m.data.nextGame, nil
+}
+
+// playerName joins a game's player to their account, as the left joins of the game queries do.
+func (m *MemStore) playerName(id pgtype.Int8) (pgtype.Text, bool) {
+	if !id.Valid {
+		return pgtype.Text{}, false
+	}
+	player, ok := m.data.players[id.Int64]
+	if !ok {
+		return pgtype.Text{}, false
+	}
+	return pgtype.Text{String: player.Username, Valid: true}, player.DeletedOn.Valid
+}
+
+func (m *MemStore) gameRow(game db.Game) db.GetGameRow {
+	xName, xDeleted := m.playerName(pgtype.Int8{Int64: game.XPlayer, Valid: true})
+	oName, oDeleted := m.playerName(game.OPlayer)
+	return db.GetGameRow{
+		ID:                game.ID,
+		XPlayer:           game.XPlayer,
+		OPlayer:           game.OPlayer,
+		BoardState:        game.BoardState,
+		StartState:        game.StartState,
+		XTurn:             game.XTurn,
+		UpdatedOn:         game.UpdatedOn,
+		StartedOn:         game.StartedOn,
+		Result:            game.Result,
+		HintBudget:        game.HintBudget,
+		XHintsUsed:        game.XHintsUsed,
+		OHintsUsed:        game.OHintsUsed,
+		Opening:           game.Opening,
+		TakebacksDisabled: game.TakebacksDisabled,
+		TakebackBy:        game.TakebackBy,
+		RematchOf:         game.RematchOf,
+		RematchBy:         game.RematchBy,
+		EndedBy:           game.EndedBy,
+		Version:           game.Version,
+		XPlayerName:       xName,
+		OPlayerName:       oName,
+		XPlayerDeleted:    xDeleted,
+		OPlayerDeleted:    oDeleted,
+	}
+}
+
+// sortedGames returns the games after the id in id order, up to the limit.
+func (m *MemStore) sortedGames(id int64, limit int32, match func(game db.Game) bool) []db.Game {
+	var games []db.Game
+	for _, game := range m.data.games {
+		if game.ID > id && match(game) {
+			games = append(games, game)
+		}
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+	if len(games) > int(limit) {
+		games = games[:limit]
+	}
+	return games
+}
+
+func (m *MemStore) GetGame(ctx context.Context, id int64) (db.GetGameRow, error) {
+	defer m.lock()()
+	game, ok := m.data.games[id]
+	if !ok {
+		return db.GetGameRow{}, ErrNotFound
+	}
+	return m.gameRow(game), nil
+}
+
+func (m *MemStore) GetGames(ctx context.Context, arg db.GetGamesParams) ([]db.GetGamesRow, error) {
+	defer m.lock()()
+	games := m.sortedGames(arg.ID, arg.Limit, func(game db.Game) bool {
+		return (!arg.XPlayer.Valid || game.XPlayer == arg.XPlayer.Int64) &&
+			(!arg.OPlayer.Valid || (game.OPlayer.Valid && game.OPlayer.Int64 == arg.OPlayer.Int64))
+	})
+	var rows []db.GetGamesRow
+	for _, game := range games {
+		row := m.gameRow(game)
+		rows = append(rows, db.GetGamesRow{
+			ID:                row.ID,
+			XPlayer:           row.XPlayer,
+			OPlayer:           row.OPlayer,
+			BoardState:        row.BoardState,
+			StartState:        row.StartState,
+			XTurn:             row.XTurn,
+			UpdatedOn:         row.UpdatedOn,
+			StartedOn:         row.StartedOn,
+			Result:            row.Result,
+			HintBudget:        row.HintBudget,
+			XHintsUsed:        row.XHintsUsed,
+			OHintsUsed:        row.OHintsUsed,
+			Opening:           row.Opening,
+			TakebacksDisabled: row.TakebacksDisabled,
+			TakebackBy:        row.TakebackBy,
+			RematchOf:         row.RematchOf,
+			RematchBy:         row.RematchBy,
+			EndedBy:           row.EndedBy,
+			XPlayerName:       row.XPlayerName,
+			OPlayerName:       row.OPlayerName,
+			XPlayerDeleted:    row.XPlayerDeleted,
+			OPlayerDeleted:    row.OPlayerDeleted,
+		})
+	}
+	return rows, nil
+}
+
+func (m *MemStore) GetGamePositions(ctx context.Context, arg db.GetGamePositionsParams) ([]db.GetGamePositionsRow, error) {
+	defer m.lock()()
+	var rows []db.GetGamePositionsRow
+	for _, game := range m.sortedGames(arg.ID, arg.Limit, func(game db.Game) bool { return true }) {
+		rows = append(rows, db.GetGamePositionsRow{
+			ID:         game.ID,
+			BoardState: game.BoardState,
+			XTurn:      game.XTurn,
+			Result:     game.Result,
+			EndedBy:    game.EndedBy,
+		})
+	}
+	return rows, nil
+}
+
+// updateGame applies the change to the game if it exists and matches, as a single row update would.
+func (m *MemStore) updateGame(id int64, match func(game db.Game) bool, change func(game *db.Game)) pgconn.CommandTag {
+	game, ok := m.data.games[id]
+	if !ok || !match(game) {
+		return memTag("UPDATE", 0)
+	}
+	change(&game)
+	memPut(m, m.data.games, id, game)
+	return memTag("UPDATE", 1)
+}
+
+func (m *MemStore) UpdateGame(ctx context.Context, arg db.UpdateGameParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool { return game.Version == arg.Version }, func(game *db.Game) {
+		game.BoardState = arg.BoardState
+		game.XTurn = arg.XTurn
+		game.UpdatedOn = arg.UpdatedOn
+		game.Result = arg.Result
+		if arg.Opening.Valid {
+			game.Opening = arg.Opening.Int32
+		}
+		game.TakebackBy = pgtype.Int8{}
+		game.Version++
+	}), nil
+}
+
+func (m *MemStore) UseHint(ctx context.Context, arg db.UseHintParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool {
+		if arg.XTurn {
+			return game.XHintsUsed < game.HintBudget
+		}
+		return game.OHintsUsed < game.HintBudget
+	}, func(game *db.Game) {
+		if arg.XTurn {
+			game.XHintsUsed++
+		} else {
+			game.OHintsUsed++
+		}
+	}), nil
+}
+
+func (m *MemStore) RequestTakeback(ctx context.Context, arg db.RequestTakebackParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool { return !game.TakebackBy.Valid }, func(game *db.Game) {
+		game.TakebackBy = arg.TakebackBy
+	}), nil
+}
+
+func (m *MemStore) ClearTakeback(ctx context.Context, arg db.ClearTakebackParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool {
+		return game.TakebackBy.Valid && arg.TakebackBy.Valid && game.TakebackBy.Int64 == arg.TakebackBy.Int64
+	}, func(game *db.Game) {
+		game.TakebackBy = pgtype.Int8{}
+	}), nil
+}
+
+func (m *MemStore) OfferRematch(ctx context.Context, arg db.OfferRematchParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool { return !game.RematchBy.Valid }, func(game *db.Game) {
+		game.RematchBy = arg.RematchBy
+	}), nil
+}
+
+func (m *MemStore) ClearRematch(ctx context.Context, arg db.ClearRematchParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool {
+		return game.RematchBy.Valid && arg.RematchBy.Valid && game.RematchBy.Int64 == arg.RematchBy.Int64
+	}, func(game *db.Game) {
+		game.RematchBy = pgtype.Int8{}
+	}), nil
+}
+
+func (m *MemStore) GetRematch(ctx context.Context, rematchOf pgtype.Int8) (int64, error) {
+	defer m.lock()()
+	for _, game := range m.data.games {
+		if game.RematchOf.Valid && rematchOf.Valid && game.RematchOf.Int64 == rematchOf.Int64 {
+			return game.ID, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+func (m *MemStore) GetSeries(ctx context.Context, id int64) ([]db.GetSeriesRow, error) {
+	defer m.lock()()
+	var rows []db.GetSeriesRow
+	game, ok := m.data.games[id]
+	for ok {
+		rows = append(rows, db.GetSeriesRow{ID: game.ID, XPlayer: game.XPlayer, OPlayer: game.OPlayer, Result: game.Result})
+		if !game.RematchOf.Valid {
+			break
+		}
+		game, ok = m.data.games[game.RematchOf.Int64]
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows, nil
+}
+
+func (m *MemStore) ForceEndGame(ctx context.Context, arg db.ForceEndGameParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	return m.updateGame(arg.ID, func(game db.Game) bool { return game.Result == 0 }, func(game *db.Game) {
+		game.Result = arg.Result
+		game.UpdatedOn = arg.UpdatedOn
+		game.EndedBy = arg.EndedBy
+		game.TakebackBy = pgtype.Int8{}
+		game.RematchBy = pgtype.Int8{}
+		game.Version++
+	}), nil
+}
+
+func (m *MemStore) GetOpeningStats(ctx context.Context, player pgtype.Int8) ([]db.GetOpeningStatsRow, error) {
+	defer m.lock()()
+	counts := map[db.GetOpeningStatsRow]int64{}
+	for _, game := range m.data.games {
+		if game.Opening == 0 {
+			continue
+		}
+		playerIsX := player.Valid && game.XPlayer == player.Int64
+		playerIsO := player.Valid && game.OPlayer.Valid && game.OPlayer.Int64 == player.Int64
+		if player.Valid && !playerIsX && !playerIsO {
+			continue
+		}
+		counts[db.GetOpeningStatsRow{Opening: game.Opening, Result: game.Result, PlayerIsX: playerIsX}]++
+	}
+	rows := make([]db.GetOpeningStatsRow, 0, len(counts))
+	for row, games := range counts {
+		row.Games = games
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Opening != rows[j].Opening {
+			return rows[i].Opening < rows[j].Opening
+		}
+		if rows[i].Result != rows[j].Result {
+			return rows[i].Result < rows[j].Result
+		}
+		return !rows[i].PlayerIsX && rows[j].PlayerIsX
+	})
+	return rows, nil
+}
+
+func (m *MemStore) GetServerStats(ctx context.Context, since pgtype.Timestamptz) (db.GetServerStatsRow, error) {
+	defer m.lock()()
+	timeNow := memNow()
+	var row db.GetServerStatsRow
+	for _, player := range m.data.players {
+		if !player.DeletedOn.Valid {
+			row.Players++
+		}
+		if player.BannedOn.Valid {
+			row.BannedPlayers++
+		}
+		if player.RegisteredOn.Valid && since.Valid && !after(since, player.RegisteredOn) {
+			row.Registrations++
+		}
+	}
+	for _, session := range m.data.sessions {
+		if after(session.ExpiresOn, timeNow) {
+			row.Sessions++
+		}
+	}
+	for _, game := range m.data.games {
+		row.Games++
+		if game.Result == 0 {
+			row.ActiveGames++
+		}
+	}
+	row.Messages = int64(len(m.data.messages))
+	return row, nil
+}
+
+func (m *MemStore) InsertStep(ctx context.Context, arg db.InsertStepParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	steps := m.data.steps[arg.GameID]
+	ord := int32(0)
+	if len(steps) > 0 {
+		ord = steps[len(steps)-1].Ord + 1
+	}
+	memPut(m, m.data.steps, arg.GameID, append(steps, db.GameStep{
+		GameID:  arg.GameID,
+		Ord:     ord,
+		MoveRow: arg.MoveRow,
+		MoveCol: arg.MoveCol,
+		Board:   arg.Board,
+		XTurn:   arg.XTurn,
+		Result:  arg.Result,
+		MadeOn:  memNow(),
+	}))
+	return memTag("INSERT 0", 1), nil
+}
+
+func (m *MemStore) GetGameSteps(ctx context.Context, gameID int64) ([]db.GameStep, error) {
+	defer m.lock()()
+	return append([]db.GameStep(nil), m.data.steps[gameID]...), nil
+}
+
+func (m *MemStore) GetGamesSteps(ctx context.Context, gameids []int64) ([]db.GameStep, error) {
+	defer m.lock()()
+	ids := append([]int64(nil), gameids...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var steps []db.GameStep
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+		steps = append(steps, m.data.steps[id]...)
+	}
+	return steps, nil
+}
+
+func (m *MemStore) GetLastStep(ctx context.Context, gameID int64) (db.GameStep, error) {
+	defer m.lock()()
+	steps := m.data.steps[gameID]
+	if len(steps) == 0 {
+		return db.GameStep{}, ErrNotFound
+	}
+	return steps[len(steps)-1], nil
+}
+
+func (m *MemStore) DeleteStepsFrom(ctx context.Context, arg db.DeleteStepsFromParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	steps := m.data.steps[arg.GameID]
+	kept := steps[:0:0]
+	for _, step := range steps {
+		if step.Ord < arg.Ord {
+			kept = append(kept, step)
+		}
+	}
+	memPut(m, m.data.steps, arg.GameID, kept)
+	return memTag("DELETE", len(steps)-len(kept)), nil
+}
+
+func (m *MemStore) GetAnalyses(ctx context.Context, gameID int64) ([]db.Analysis, error) {
+	defer m.lock()()
+	return append([]db.Analysis(nil), m.data.analyses[gameID]...), nil
+}
+
+func (m *MemStore) InsertAnalysis(ctx context.Context, arg db.InsertAnalysisParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	analyses := m.data.analyses[arg.GameID]
+	i := sort.Search(len(analyses), func(i int) bool { return analyses[i].Ord >= arg.Ord })
+	if i < len(analyses) && analyses[i].Ord == arg.Ord {
+		return memTag("INSERT 0", 0), nil
+	}
+	kept := make([]db.Analysis, 0, len(analyses)+1)
+	kept = append(kept, analyses[:i]...)
+	kept = append(kept, db.Analysis{
+		GameID:         arg.GameID,
+		Ord:            arg.Ord,
+		MoveRow:        arg.MoveRow,
+		MoveCol:        arg.MoveCol,
+		XMoved:         arg.XMoved,
+		BestResult:     arg.BestResult,
+		BestDistance:   arg.BestDistance,
+		PlayedResult:   arg.PlayedResult,
+		PlayedDistance: arg.PlayedDistance,
+		Annotation:     arg.Annotation,
+		AnalyzedOn:     memNow(),
+	})
+	kept = append(kept, analyses[i:]...)
+	memPut(m, m.data.analyses, arg.GameID, kept)
+	return memTag("INSERT 0", 1), nil
+}
+
+func (m *MemStore) GetPuzzle(ctx context.Context, id int64) (db.Puzzle, error) {
+	defer m.lock()()
+	puzzle, ok := m.data.puzzles[id]
+	if !ok {
+		return db.Puzzle{}, ErrNotFound
+	}
+	return puzzle, nil
+}
+
+func (m *MemStore) InsertPuzzle(ctx context.Context, arg db.InsertPuzzleParams) (int64, error) {
+	defer m.lock()()
+	m.data.nextPuzzle++
+	memPut(m, m.data.puzzles, m.data.nextPuzzle, db.Puzzle{
+		ID:          m.data.nextPuzzle,
+		BoardState:  arg.BoardState,
+		XTurn:       arg.XTurn,
+		Depth:       arg.Depth,
+		SolutionRow: arg.SolutionRow,
+		SolutionCol: arg.SolutionCol,
+		Difficulty:  arg.Difficulty,
+		CreatedOn:   memNow(),
+	})
+	return m.data.nextPuzzle, nil
+}
+
+func (m *MemStore) GetPuzzleAttempt(ctx context.Context, arg db.GetPuzzleAttemptParams) (db.PuzzleAttempt, error) {
+	defer m.lock()()
+	attempt, ok := m.data.attempts[memAttemptKey{arg.PuzzleID, arg.PlayerID}]
+	if !ok {
+		return db.PuzzleAttempt{}, ErrNotFound
+	}
+	return attempt, nil
+}
+
+func (m *MemStore) InsertPuzzleAttempt(ctx context.Context, arg db.InsertPuzzleAttemptParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	key := memAttemptKey{arg.PuzzleID, arg.PlayerID}
+	if _, ok := m.data.attempts[key]; ok {
+		return memTag("INSERT 0", 0), nil
+	}
+	timeNow := memNow()
+	memPut(m, m.data.attempts, key, db.PuzzleAttempt{
+		PuzzleID:   arg.PuzzleID,
+		PlayerID:   arg.PlayerID,
+		BoardState: arg.BoardState,
+		XTurn:      arg.XTurn,
+		MovesLeft:  arg.MovesLeft,
+		StartedOn:  timeNow,
+		UpdatedOn:  timeNow,
+	})
+	return memTag("INSERT 0", 1), nil
+}
+
+func (m *MemStore) UpdatePuzzleAttempt(ctx context.Context, arg db.UpdatePuzzleAttemptParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	key := memAttemptKey{arg.PuzzleID, arg.PlayerID}
+	attempt, ok := m.data.attempts[key]
+	if !ok || attempt.BoardState != arg.PrevBoardState {
+		return memTag("UPDATE", 0), nil
+	}
+	attempt.BoardState = arg.BoardState
+	attempt.XTurn = arg.XTurn
+	attempt.MovesLeft = arg.MovesLeft
+	attempt.Status = arg.Status
+	attempt.UpdatedOn = arg.UpdatedOn
+	attempt.CompletedOn = arg.CompletedOn
+	memPut(m, m.data.attempts, key, attempt)
+	return memTag("UPDATE", 1), nil
+}
+
+func (m *MemStore) GetPuzzleStreak(ctx context.Context, id int64) (db.GetPuzzleStreakRow, error) {
+	defer m.lock()()
+	player, ok := m.data.players[id]
+	if !ok {
+		return db.GetPuzzleStreakRow{}, ErrNotFound
+	}
+	return db.GetPuzzleStreakRow{PuzzleStreak: player.PuzzleStreak, BestPuzzleStreak: player.BestPuzzleStreak}, nil
+}
+
+func (m *MemStore) UpdatePuzzleStreak(ctx context.Context, arg db.UpdatePuzzleStreakParams) (db.UpdatePuzzleStreakRow, error) {
+	defer m.lock()()
+	player, err := m.updatePlayer(arg.ID, func(player db.PlayerAccount) bool { return true }, func(player *db.PlayerAccount) {
+		if arg.Solved {
+			player.PuzzleStreak++
+		} else {
+			player.PuzzleStreak = 0
+		}
+		player.BestPuzzleStreak = max(player.BestPuzzleStreak, player.PuzzleStreak)
+	})
+	return db.UpdatePuzzleStreakRow{PuzzleStreak: player.PuzzleStreak, BestPuzzleStreak: player.BestPuzzleStreak}, err
+}
+
+func (m *MemStore) GetDailyPuzzle(ctx context.Context, day pgtype.Date) (db.Puzzle, error) {
+	defer m.lock()()
+	puzzle, ok := m.data.puzzles[m.data.dailyPuzzles[memDay(day)]]
+	if !ok {
+		return db.Puzzle{}, ErrNotFound
+	}
+	return puzzle, nil
+}
+
+func (m *MemStore) InsertDailyPuzzle(ctx context.Context, arg db.InsertDailyPuzzleParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	key := memDay(arg.Day)
+	if _, ok := m.data.dailyPuzzles[key]; ok {
+		return memTag("INSERT 0", 0), nil
+	}
+	memPut(m, m.data.dailyPuzzles, key, arg.PuzzleID)
+	return memTag("INSERT 0", 1), nil
+}
+
+func (m *MemStore) GetDailyLeaderboard(ctx context.Context, arg db.GetDailyLeaderboardParams) ([]db.GetDailyLeaderboardRow, error) {
+	defer m.lock()()
+	puzzleID, ok := m.data.dailyPuzzles[memDay(arg.Day)]
+	if !ok {
+		return nil, nil
+	}
+	var rows []db.GetDailyLeaderboardRow
+	for _, attempt := range m.data.attempts {
+		player, ok := m.data.players[attempt.PlayerID]
+		if attempt.PuzzleID != puzzleID || attempt.Status != arg.Status || !ok {
+			continue
+		}
+		rows = append(rows, db.GetDailyLeaderboardRow{
+			PlayerID:    attempt.PlayerID,
+			Username:    player.Username,
+			StartedOn:   attempt.StartedOn,
+			CompletedOn: attempt.CompletedOn,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		took := rows[i].CompletedOn.Time.Sub(rows[i].StartedOn.Time)
+		otherTook := rows[j].CompletedOn.Time.Sub(rows[j].StartedOn.Time)
+		if took != otherTook {
+			return took < otherTook
+		}
+		return rows[i].PlayerID < rows[j].PlayerID
+	})
+	return memPage(rows, 0, arg.Limit), nil
+}
+
+func (m *MemStore) InsertMessage(ctx context.Context, arg db.InsertMessageParams) (int64, error) {
+	defer m.lock()()
+	m.data.nextMessage++
+	memPut(m, m.data.messages, m.data.nextMessage, db.GameMessage{
+		ID:       m.data.nextMessage,
+		GameID:   arg.GameID,
+		PlayerID: arg.PlayerID,
+		Channel:  arg.Channel,
+		Text:     arg.Text,
+		SentOn:   arg.SentOn,
+	})
+	return m.data.nextMessage, nil
+}
+
+// sortedMessages returns the matching messages joined to their players in id order.
+func (m *MemStore) sortedMessages(match func(message db.GameMessage) bool) []db.GetMessageRow {
+	var rows []db.GetMessageRow
+	for _, message := range m.data.messages {
+		player, ok := m.data.players[message.PlayerID]
+		if !ok || !match(message) {
+			continue
+		}
+		rows = append(rows, db.GetMessageRow{
+			ID:       message.ID,
+			GameID:   message.GameID,
+			PlayerID: message.PlayerID,
+			Channel:  message.Channel,
+			Text:     message.Text,
+			SentOn:   message.SentOn,
+			Username: player.Username,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	return rows
+}
+
+func (m *MemStore) GetGameMessages(ctx context.Context, gameID int64) ([]db.GetGameMessagesRow, error) {
+	defer m.lock()()
+	var rows []db.GetGameMessagesRow
+	for _, row := range m.sortedMessages(func(message db.GameMessage) bool { return message.GameID == gameID }) {
+		rows = append(rows, db.GetGameMessagesRow(row))
+	}
+	return rows, nil
+}
+
+func (m *MemStore) GetMessage(ctx context.Context, id int64) (db.GetMessageRow, error) {
+	defer m.lock()()
+	rows := m.sortedMessages(func(message db.GameMessage) bool { return message.ID == id })
+	if len(rows) == 0 {
+		return db.GetMessageRow{}, ErrNotFound
+	}
+	return rows[0], nil
+}
+
+func (m *MemStore) GetMessagesAfter(ctx context.Context, arg db.GetMessagesAfterParams) ([]db.GetMessagesAfterRow, error) {
+	defer m.lock()()
+	var rows []db.GetMessagesAfterRow
+	messages := m.sortedMessages(func(message db.GameMessage) bool {
+		return message.GameID == arg.GameID && message.Channel == arg.Channel && message.ID > arg.ID
+	})
+	for _, row := range memPage(messages, 0, arg.Limit) {
+		rows = append(rows, db.GetMessagesAfterRow(row))
+	}
+	return rows, nil
+}
+
+func (m *MemStore) DeleteMessage(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	if _, ok := m.data.messages[id]; !ok {
+		return memTag("DELETE", 0), nil
+	}
+	memDelete(m, m.data.messages, id)
+	return memTag("DELETE", 1), nil
+}
+
+func (m *MemStore) CountRecentMessages(ctx context.Context, arg db.CountRecentMessagesParams) (int64, error) {
+	defer m.lock()()
+	var count int64
+	for _, message := range m.data.messages {
+		if message.PlayerID == arg.PlayerID && after(message.SentOn, arg.SentOn) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MemStore) GetLoginLockout(ctx context.Context, subjects []string) (pgtype.Timestamptz, error) {
+	defer m.lock()()
+	var lockedUntil pgtype.Timestamptz
+	for _, subject := range subjects {
+		failure, ok := m.data.loginFailures[subject]
+		if ok && (!lockedUntil.Valid || after(failure.LockedUntil, lockedUntil)) {
+			lockedUntil = failure.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+func (m *MemStore) RecordLoginFailure(ctx context.Context, arg db.RecordLoginFailureParams) (int32, error) {
+	defer m.lock()()
+	failure, ok := m.data.loginFailures[arg.Subject]
+	if !ok {
+		failure = db.LoginFailure{Subject: arg.Subject, LockedUntil: arg.FailedOn}
+	}
+	if ok && after(failure.FailedOn, arg.WindowStart) {
+		failure.Failures++
+	} else {
+		failure.Failures = 1
+	}
+	failure.FailedOn = arg.FailedOn
+	memPut(m, m.data.loginFailures, arg.Subject, failure)
+	return failure.Failures, nil
+}
+
+func (m *MemStore) LockLogin(ctx context.Context, arg db.LockLoginParams) error {
+	defer m.lock()()
+	failure, ok := m.data.loginFailures[arg.Subject]
+	if ok {
+		failure.LockedUntil = arg.LockedUntil
+		memPut(m, m.data.loginFailures, arg.Subject, failure)
+	}
+	return nil
+}
+
+func (m *MemStore) ClearLoginFailures(ctx context.Context, subject string) error {
+	defer m.lock()()
+	memDelete(m, m.data.loginFailures, subject)
+	return nil
+}
+
+func (m *MemStore) PurgeLoginFailures(ctx context.Context, arg db.PurgeLoginFailuresParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	deleted := 0
+	for subject, failure := range m.data.loginFailures {
+		if !after(failure.FailedOn, arg.WindowStart) && !after(failure.LockedUntil, arg.Now) {
+			memDelete(m, m.data.loginFailures, subject)
+			deleted++
+		}
+	}
+	return memTag("DELETE", deleted), nil
+}
+
+func (m *MemStore) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	defer m.lock()()
+	row, ok := m.data.keys[memKey{arg.PlayerID, arg.Key}]
+	if !ok || !after(row.ExpiresOn, arg.ExpiresOn) {
+		return db.IdempotencyKey{}, ErrNotFound
+	}
+	return row, nil
+}
+
+func (m *MemStore) ReserveIdempotencyKey(ctx context.Context, arg db.ReserveIdempotencyKeyParams) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	key := memKey{arg.PlayerID, arg.Key}
+	if row, ok := m.data.keys[key]; ok && after(row.ExpiresOn, arg.CreatedOn) {
+		return memTag("INSERT 0", 0), nil
+	}
+	memPut(m, m.data.keys, key, db.IdempotencyKey{
+		PlayerID:    arg.PlayerID,
+		Key:         arg.Key,
+		Method:      arg.Method,
+		RequestHash: arg.RequestHash,
+		CreatedOn:   arg.CreatedOn,
+		ExpiresOn:   arg.ExpiresOn,
+	})
+	return memTag("INSERT 0", 1), nil
+}
+
+func (m *MemStore) StoreIdempotentResponse(ctx context.Context, arg db.StoreIdempotentResponseParams) error {
+	defer m.lock()()
+	key := memKey{arg.PlayerID, arg.Key}
+	if row, ok := m.data.keys[key]; ok {
+		row.ResponseType = arg.ResponseType
+		row.Response = arg.Response
+		memPut(m, m.data.keys, key, row)
+	}
+	return nil
+}
+
+func (m *MemStore) ReleaseIdempotencyKey(ctx context.Context, arg db.ReleaseIdempotencyKeyParams) error {
+	defer m.lock()()
+	key := memKey{arg.PlayerID, arg.Key}
+	if row, ok := m.data.keys[key]; ok && row.Response == nil {
+		memDelete(m, m.data.keys, key)
+	}
+	return nil
+}
+
+func (m *MemStore) PurgeIdempotencyKeys(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	defer m.lock()()
+	deleted := 0
+	for key, row := range m.data.keys {
+		if !after(row.ExpiresOn, expiresOn) {
+			memDelete(m, m.data.keys, key)
+			deleted++
+		}
+	}
+	return memTag("DELETE", deleted), nil
+}
+
+func (m *MemStore) InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) (int64, error) {
+	defer m.lock()()
+	id := int64(len(m.data.auditEvents) + 1)
+	m.onUndo(func() {
+		m.data.auditEvents = m.data.auditEvents[:id-1]
+	})
+	m.data.auditEvents = append(m.data.auditEvents, db.AuditEvent{
+		ID:        id,
+		ActorID:   arg.ActorID,
+		EventType: arg.EventType,
+		TargetID:  arg.TargetID,
+		Peer:      arg.Peer,
+		Method:    arg.Method,
+		Payload:   arg.Payload,
+		CreatedOn: arg.CreatedOn,
+	})
+	return id, nil
+}
+
+func (m *MemStore) QueryAuditLog(ctx context.Context, arg db.QueryAuditLogParams) ([]db.AuditEvent, error) {
+	defer m.lock()()
+	var rows []db.AuditEvent
+	for i := len(m.data.auditEvents) - 1; i >= 0; i-- {
+		event := m.data.auditEvents[i]
+		if after(arg.Since, event.CreatedOn) || !after(arg.Until, event.CreatedOn) {
+			continue
+		}
+		if arg.ActorId.Valid && (!event.ActorID.Valid || event.ActorID.Int64 != arg.ActorId.Int64) {
+			continue
+		}
+		if arg.EventType.Valid && event.EventType != arg.EventType.String {
+			continue
+		}
+		rows = append(rows, event)
+	}
+	return memPage(rows, arg.Offset, arg.Limit), nil
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"TicTacGo/pb"
+	"context"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket that refills at Rate tokens a second and holds at most Burst tokens.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// DefaultRateLimit applies to methods without a limit of their own.
+var DefaultRateLimit = RateLimit{Rate: 10, Burst: 40}
+
+// DefaultRateLimits are the per-method limits used when none are configured.
+var DefaultRateLimits = map[string]RateLimit{
+	pb.TicTacGoService_Register_FullMethodName: {Rate: 1.0 / 60, Burst: 3},
+	pb.TicTacGoService_Login_FullMethodName:    {Rate: 1.0 / 6, Burst: 10},
+	pb.TicTacGoService_MakeMove_FullMethodName: {Rate: 2, Burst: 10},
+	pb.TicTacGoService_GetGames_FullMethodName: {Rate: 2, Burst: 20},
+}
+
+// RateLimitSweepInterval is how often buckets that have refilled are dropped, since a full bucket is the
+// same as none.
+const RateLimitSweepInterval = time.Minute * 5
+
+// ParseRateLimits reads a comma separated list of "Method=rate/burst" limits, where rate is tokens a second
+// and Method is the name of a game or admin method without its service, or "*" for the default. Configured limits are laid
+// over the defaults.
+func ParseRateLimits(s string) (map[string]RateLimit, RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for method, limit := range DefaultRateLimits {
+		limits[method] = limit
+	}
+	fallback := DefaultRateLimit
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, found := strings.Cut(entry, "=")
+		rate, burst, slash := strings.Cut(value, "/")
+		if !found || !slash {
+			return nil, RateLimit{}, fmt.Errorf("expected rate limit as 'Method=rate/burst', got: %q", entry)
+		}
+		limit := RateLimit{}
+		var err error
+		if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil || limit.Rate <= 0 {
+			return nil, RateLimit{}, fmt.Errorf("expected a positive rate in rate limit: %q", entry)
+		}
+		if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst < 1 {
+			return nil, RateLimit{}, fmt.Errorf("expected a burst of at least 1 in rate limit: %q", entry)
+		}
+
+		if method == "*" {
+			fallback = limit
+			continue
+		}
+		fullMethod, ok := fullMethodName(method)
+		if !ok {
+			return nil, RateLimit{}, fmt.Errorf("unknown method in rate limit: %q", entry)
+		}
+		limits[fullMethod] = limit
+	}
+	return limits, fallback, nil
+}
+
+// fullMethodName finds the method of either service by its name, the services share no method names.
+func fullMethodName(name string) (string, bool) {
+	for _, service := range []grpc.ServiceDesc{pb.TicTacGoService_ServiceDesc, pb.AdminService_ServiceDesc} {
+		for _, method := range service.Methods {
+			if method.MethodName == name {
+				return "/" + service.ServiceName + "/" + name, true
+			}
+		}
+		for _, stream := range service.Streams {
+			if stream.StreamName == name {
+				return "/" + service.ServiceName + "/" + name, true
+			}
+		}
+	}
+	return "", false
+}
+
+type bucketKey struct {
+	caller string
+	method string
+}
+
+type bucket struct {
+	tokens    float64
+	updatedOn time.Time
+}
+
+// RateLimiter keeps a token bucket per caller and method. The buckets live in memory, so each server
+// instance limits the calls it receives.
+type RateLimiter struct {
+	mu       sync.Mutex
+	limits   map[string]RateLimit
+	fallback RateLimit
+	buckets  map[bucketKey]*bucket
+	sweptOn  time.Time
+	now      func() time.Time
+}
+
+func NewRateLimiter(limits map[string]RateLimit, fallback RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limits:   limits,
+		fallback: fallback,
+		buckets:  make(map[bucketKey]*bucket),
+		sweptOn:  time.Now(),
+		now:      time.Now,
+	}
+}
+
+func (r *RateLimiter) limit(method string) RateLimit {
+	limit, ok := r.limits[method]
+	if !ok {
+		return r.fallback
+	}
+	return limit
+}
+
+// Allow takes a token from the caller's bucket for the method, or returns how long until one is available.
+func (r *RateLimiter) Allow(caller string, method string) (bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	timeNow := r.now()
+	if timeNow.Sub(r.sweptOn) > RateLimitSweepInterval {
+		r.sweep(timeNow)
+	}
+
+	limit := r.limit(method)
+	key := bucketKey{caller: caller, method: method}
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedOn: timeNow}
+		r.buckets[key] = b
+	}
+
+	b.tokens = min(float64(limit.Burst), b.tokens+timeNow.Sub(b.updatedOn).Seconds()*limit.Rate)
+	b.updatedOn = timeNow
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	return false, wait
+}
+
+func (r *RateLimiter) sweep(timeNow time.Time) {
+	for key, b := range r.buckets {
+		limit := r.limit(key.method)
+		if b.tokens+timeNow.Sub(b.updatedOn).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(r.buckets, key)
+		}
+	}
+	r.sweptOn = timeNow
+}
+
+// PeerHost returns the host of the address the call came from.
+func PeerHost(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String(), true
+	}
+	return host, true
+}
+
+// Caller keys the rate limit by the signed in player, or by the peer's address for anonymous calls.
+func Caller(ctx context.Context) string {
+	if player, ok := PlayerFromContext(ctx); ok {
+		return fmt.Sprintf("player:%d", player.ID)
+	}
+	host, _ := PeerHost(ctx)
+	return "peer:" + host
+}
+
+// CheckRateLimit returns ResourceExhausted with the time to wait when the caller ran out of calls to the
+// method.
+func (s *GrpcServer) CheckRateLimit(ctx context.Context, method string) error {
+	if s.RateLimiter == nil {
+		return nil
+	}
+	caller := Caller(ctx)
+	allowed, wait := s.RateLimiter.Allow(caller, method)
+	if allowed {
+		return nil
+	}
+
+	log.Printf("rate limited caller: %s for method: %s, retry in %v", caller, method, wait)
+	retryDelay := (wait + time.Millisecond - 1).Truncate(time.Millisecond)
+	retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)}
+	st, err := status.New(codes.ResourceExhausted, "too many calls, try again later").WithDetails(retry)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func (s *GrpcServer) UnaryRateLimitInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.CheckRateLimit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *GrpcServer) StreamRateLimitInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.CheckRateLimit(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
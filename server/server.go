@@ -2,13 +2,10 @@ package server
 
 import (
 	"context"
-	"database/sql"
 	"errors"
-	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgconn"
 	"golang.org/x/crypto/bcrypt"
-	"google.golang.org/grpc/metadata"
 	"log"
+	"strings"
 	"time"
 
 	"TicTacGo/db"
@@ -16,7 +13,6 @@ import (
 	"TicTacGo/tictactoe"
 
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -26,25 +22,15 @@ import (
 
 type GrpcServer struct {
 	pb.UnimplementedTicTacGoServiceServer
-	Queries *db.Queries
-	Pool    *pgxpool.Pool
-}
-
-type SideChannels struct {
-	Authorization string
-}
-
-func GetSideChannelInfo(ctx context.Context) (SideChannels, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return SideChannels{}, status.Error(codes.Internal, "failed to get metadata from incoming context")
-	}
-	authorization := md["authorization"]
-	if len(authorization) != 1 {
-		log.Printf("expected authorization metadata length 1, got %v", authorization)
-		return SideChannels{}, status.Error(codes.Internal, "expected metadata 'authorization' to have one value")
-	}
-	return SideChannels{Authorization: authorization[0]}, nil
+	pb.UnimplementedAdminServiceServer
+	Store         Store
+	BlockedWords  []string
+	TokenKeys     TokenKeys
+	AccessExpiry  time.Duration
+	SessionExpiry time.Duration
+	ChatLimit     int64
+	ChatWindow    time.Duration
+	RateLimiter   *RateLimiter
 }
 
 func (s *GrpcServer) Register(ctx context.Context, in *pb.CredentialsReq) (*pb.Player, error) {
@@ -69,14 +55,11 @@ func (s *GrpcServer) Register(ctx context.Context, in *pb.CredentialsReq) (*pb.P
 		Passwd:   string(hashedPassword),
 	}
 
-	row, playerErr := s.Queries.InsertPlayer(ctx, params)
+	row, playerErr := s.Store.InsertPlayer(ctx, params)
 	if playerErr != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(playerErr, &pgErr) {
-			if pgErr.Code == "23505" {
-				log.Printf("player already exists: %v", pgErr)
-				return nil, status.Errorf(codes.AlreadyExists, "player already exists: %s", in.Username)
-			}
+		if errors.Is(playerErr, ErrConflict) {
+			log.Printf("player already exists: %v", playerErr)
+			return nil, status.Errorf(codes.AlreadyExists, "player already exists: %s", in.Username)
 		}
 		log.Printf("failed to insert player: %v", playerErr)
 		return nil, status.Errorf(codes.Internal, "failed to insert player for username: %s", in.Username)
@@ -86,6 +69,7 @@ func (s *GrpcServer) Register(ctx context.Context, in *pb.CredentialsReq) (*pb.P
 		Id:       row.ID,
 		Username: row.Username,
 	}
+	s.Audit(ctx, AuditEvent{ActorID: row.ID, EventType: AuditRegister, TargetID: row.ID, Payload: map[string]any{"username": row.Username}})
 
 	log.Printf("successfully created player with resp: %v", player.String())
 
@@ -97,34 +81,236 @@ func (s *GrpcServer) Login(ctx context.Context, in *pb.CredentialsReq) (*pb.Logi
 		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
 	}
 
-	row, playerErr := s.Queries.GetAccountByName(ctx, in.Username)
+	subjects := LoginSubjects(ctx, in.Username)
+	err := s.CheckLoginLockout(ctx, subjects)
+	if err != nil {
+		s.AuditLoginFailure(ctx, 0, in.Username, "locked out")
+		return nil, err
+	}
+
+	row, playerErr := s.Store.GetAccountByName(ctx, in.Username)
+	if errors.Is(playerErr, ErrNotFound) {
+		// compare against a dummy hash so that unknown usernames take as long as a wrong password
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(in.Password))
+		s.RecordLoginFailure(ctx, subjects)
+		s.AuditLoginFailure(ctx, 0, in.Username, "unknown username")
+		return nil, status.Errorf(codes.PermissionDenied, "authorization credentials are invalid or missing")
+	}
 	if playerErr != nil {
-		return nil, status.Errorf(codes.PermissionDenied, "failed to get player for username: %+v", in.Username)
+		log.Printf("failed to get account: %v", playerErr)
+		return nil, status.Errorf(codes.Internal, "failed to get player for username: %s", in.Username)
 	}
 
-	err := bcrypt.CompareHashAndPassword([]byte(row.Passwd), []byte(in.Password))
+	err = bcrypt.CompareHashAndPassword([]byte(row.Passwd), []byte(in.Password))
 	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		s.RecordLoginFailure(ctx, subjects)
+		s.AuditLoginFailure(ctx, row.ID, in.Username, "wrong password")
 		return nil, status.Errorf(codes.PermissionDenied, "authorization credentials are invalid or missing")
 	}
 	if err != nil {
 		log.Printf("failed to generate hashed password: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to generate hashed password from username: %s", in.Username)
 	}
+	s.ClearLoginFailures(ctx, in.Username)
+	if row.BannedOn.Valid {
+		log.Printf("banned player: %d tried to login", row.ID)
+		s.AuditLoginFailure(ctx, row.ID, in.Username, "banned")
+		return nil, status.Error(codes.PermissionDenied, "player is banned")
+	}
 
-	token := uuid.New().String()
+	refreshToken, err := NewRefreshToken()
+	if err != nil {
+		log.Printf("failed to generate refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "failed to generate refresh token")
+	}
+
+	timeNow := time.Now()
 	session := db.InsertSessionParams{
-		Token:    token,
-		PlayerID: row.ID,
+		TokenHash:  HashToken(refreshToken),
+		PlayerID:   row.ID,
+		Device:     Device(ctx),
+		CreatedOn:  pgtype.Timestamptz{Time: timeNow, Valid: true},
+		LastSeenOn: pgtype.Timestamptz{Time: timeNow, Valid: true},
+		ExpiresOn:  pgtype.Timestamptz{Time: timeNow.Add(s.SessionTTL()), Valid: true},
 	}
 
-	_, sessErr := s.Queries.InsertSession(ctx, session)
+	sessionID, sessErr := s.Store.InsertSession(ctx, session)
 	if sessErr != nil {
 		log.Printf("failed to insert session: %v", sessErr)
-		return nil, status.Errorf(codes.Internal, "failed to insert session for params: %+v", session)
+		return nil, status.Errorf(codes.Internal, "failed to insert session for player: %d", row.ID)
 	}
 
-	resp := pb.LoginResp{Token: token, Player: &pb.Player{Id: row.ID, Username: in.Username}}
-	return &resp, nil
+	s.Audit(ctx, AuditEvent{ActorID: row.ID, EventType: AuditLogin, TargetID: sessionID, Payload: map[string]any{"device": session.Device}})
+
+	claims := Claims{ID: row.ID, Username: row.Username, SessionID: sessionID}
+	return s.IssueTokens(claims, refreshToken, timeNow)
+}
+
+func (s *GrpcServer) RefreshToken(ctx context.Context, in *pb.RefreshTokenReq) (*pb.LoginResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	tokenHash := HashToken(in.RefreshToken)
+	sessRow, err := s.Store.GetSession(ctx, tokenHash)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("no session found for refresh token")
+		return nil, status.Error(codes.Unauthenticated, "refresh token is invalid or expired")
+	}
+	if err != nil {
+		log.Printf("failed to get session: %v", err)
+		return nil, status.Error(codes.Internal, "failed to get session")
+	}
+
+	refreshToken, err := NewRefreshToken()
+	if err != nil {
+		log.Printf("failed to generate refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "failed to generate refresh token")
+	}
+
+	// every refresh rotates the refresh token and slides the session's expiry, the old token stops working
+	timeNow := time.Now()
+	params := db.RotateSessionParams{
+		ID:           sessRow.SessionID,
+		TokenHash:    tokenHash,
+		NewTokenHash: HashToken(refreshToken),
+		LastSeenOn:   pgtype.Timestamptz{Time: timeNow, Valid: true},
+		ExpiresOn:    pgtype.Timestamptz{Time: timeNow.Add(s.SessionTTL()), Valid: true},
+	}
+	result, err := s.Store.RotateSession(ctx, params)
+	if err != nil {
+		log.Printf("failed to rotate session: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to rotate session: %d", sessRow.SessionID)
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("session: %d was rotated concurrently", sessRow.SessionID)
+		return nil, status.Error(codes.Unauthenticated, "refresh token is invalid or expired")
+	}
+
+	claims := Claims{ID: sessRow.ID, Username: sessRow.Username, SessionID: sessRow.SessionID}
+	return s.IssueTokens(claims, refreshToken, timeNow)
+}
+
+func (s *GrpcServer) Logout(ctx context.Context, in *pb.LogoutReq) (*pb.LogoutResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.Store.DeleteSession(ctx, sessRow.SessionID)
+	if err != nil {
+		log.Printf("failed to delete session: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to delete session: %d", sessRow.SessionID)
+	}
+
+	log.Printf("player: %d logged out of session: %d", sessRow.ID, sessRow.SessionID)
+	return &pb.LogoutResp{Sessions: result.RowsAffected()}, nil
+}
+
+func (s *GrpcServer) LogoutAll(ctx context.Context, in *pb.LogoutAllReq) (*pb.LogoutResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.Store.DeletePlayerSessions(ctx, sessRow.ID)
+	if err != nil {
+		log.Printf("failed to delete sessions: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to delete sessions for player: %d", sessRow.ID)
+	}
+
+	log.Printf("player: %d logged out of %d sessions", sessRow.ID, result.RowsAffected())
+	return &pb.LogoutResp{Sessions: result.RowsAffected()}, nil
+}
+
+func (s *GrpcServer) ListSessions(ctx context.Context, in *pb.ListSessionsReq) (*pb.Sessions, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Store.GetPlayerSessions(ctx, sessRow.ID)
+	if err != nil {
+		log.Printf("failed to get sessions: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get sessions for player: %d", sessRow.ID)
+	}
+
+	sessions := MapSessions(rows, sessRow.SessionID)
+	log.Printf("successfully fetched sessions for player: %d, %v", sessRow.ID, sessions.String())
+
+	return sessions, nil
+}
+
+func (s *GrpcServer) ChangePassword(ctx context.Context, in *pb.ChangePasswordReq) (*pb.LogoutResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateChangePassword(in)
+	if err != nil {
+		log.Printf("failed to validate password change for player: %d: %v", sessRow.ID, err)
+		return nil, err
+	}
+
+	_, err = s.CheckPassword(ctx, sessRow.ID, in.OldPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, hashErr := bcrypt.GenerateFromPassword([]byte(in.NewPassword), bcrypt.DefaultCost)
+	if hashErr != nil {
+		log.Printf("failed to generate hashed password: %v", hashErr)
+		return nil, status.Errorf(codes.Internal, "failed to generate hashed password for player: %d", sessRow.ID)
+	}
+
+	sessions, err := s.ChangePasswordTrans(ctx, sessRow, string(hashedPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("player: %d changed their password, ending %d other sessions", sessRow.ID, sessions)
+	return &pb.LogoutResp{Sessions: sessions}, nil
+}
+
+func (s *GrpcServer) DeleteAccount(ctx context.Context, in *pb.DeleteAccountReq) (*pb.LogoutResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.CheckPassword(ctx, sessRow.ID, in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.DeleteAccountTrans(ctx, sessRow.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("player: %d deleted their account, ending %d sessions", sessRow.ID, sessions)
+	return &pb.LogoutResp{Sessions: sessions}, nil
 }
 
 func (s *GrpcServer) GetPlayers(ctx context.Context, in *pb.GetPlayersReq) (*pb.Players, error) {
@@ -137,7 +323,7 @@ func (s *GrpcServer) GetPlayers(ctx context.Context, in *pb.GetPlayersReq) (*pb.
 		Limit: in.PerPage,
 	}
 
-	rows, err := s.Queries.GetPlayers(ctx, params)
+	rows, err := s.Store.GetPlayers(ctx, params)
 	if err != nil {
 		log.Printf("failed to get players: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to get players for params: %+v", params)
@@ -154,31 +340,42 @@ func (s *GrpcServer) CreateGame(ctx context.Context, in *pb.CreateGameReq) (*pb.
 		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
 	}
 
-	md, err := GetSideChannelInfo(ctx)
+	err := ValidateCreateGame(in)
 	if err != nil {
+		log.Printf("failed to validate create game %v: %v", in, err)
 		return nil, err
 	}
-	log.Printf("called CreateGame with token: %v", md.Authorization)
 
-	sessRow, err := s.Queries.GetSession(ctx, md.Authorization)
+	sessRow, err := RequirePlayer(ctx)
 	if err != nil {
-		log.Printf("failed to retrieve session: %v", err)
-		return nil, status.Errorf(codes.PermissionDenied, "failed to retrieve session for token: %v", md.Authorization)
+		return nil, err
 	}
+	log.Printf("called CreateGame with player: %d", sessRow.ID)
 
-	// insert the newly constructed game
+	// insert the newly constructed game, from the requested position if there is one
 	timeNow := time.Now()
 	board, turn := tictactoe.NewGame()
+	if in.BoardState != "" {
+		board, err = tictactoe.ParseBoard(in.BoardState)
+		if err != nil {
+			log.Printf("error converting board from string: %v", err)
+			return nil, status.Error(codes.Internal, "error converting board from string")
+		}
+		turn = in.XTurn
+	}
 
 	params := db.InsertGameParams{
-		XPlayer:    sessRow.ID,
-		BoardState: tictactoe.BoardToString(board),
-		XTurn:      pgtype.Bool{Bool: turn, Valid: true},
-		UpdatedOn:  pgtype.Timestamptz{Time: timeNow, Valid: true},
-		StartedOn:  pgtype.Timestamptz{Time: timeNow, Valid: true},
+		XPlayer:           sessRow.ID,
+		BoardState:        tictactoe.BoardToString(board),
+		StartState:        tictactoe.BoardToString(board),
+		XTurn:             pgtype.Bool{Bool: turn, Valid: true},
+		UpdatedOn:         pgtype.Timestamptz{Time: timeNow, Valid: true},
+		StartedOn:         pgtype.Timestamptz{Time: timeNow, Valid: true},
+		HintBudget:        in.HintBudget,
+		TakebacksDisabled: in.DisableTakebacks,
 	}
 
-	gameId, err := s.Queries.InsertGame(ctx, params)
+	gameId, err := s.Store.InsertGame(ctx, params)
 	if err != nil {
 		log.Printf("failed to insert game: %v", err)
 		return nil, status.Errorf(codes.Internal, "failed to insert game for params: %+v", params)
@@ -191,11 +388,14 @@ func (s *GrpcServer) CreateGame(ctx context.Context, in *pb.CreateGameReq) (*pb.
 			Id:       sessRow.ID,
 			Username: sessRow.Username,
 		},
-		BoardState: params.BoardState,
-		XTurn:      params.XTurn.Bool,
-		UpdatedOn:  &timestamppb.Timestamp{Seconds: timeNow.Unix()},
-		StartedOn:  &timestamppb.Timestamp{Seconds: timeNow.Unix()},
-		Steps:      []*pb.Step{},
+		BoardState:        params.BoardState,
+		XTurn:             params.XTurn.Bool,
+		UpdatedOn:         &timestamppb.Timestamp{Seconds: timeNow.Unix()},
+		StartedOn:         &timestamppb.Timestamp{Seconds: timeNow.Unix()},
+		Steps:             []*pb.Step{},
+		HintBudget:        params.HintBudget,
+		StartState:        params.StartState,
+		TakebacksDisabled: params.TakebacksDisabled,
 	}
 
 	log.Printf("successfully created game: %+v, board: %s", game.String(), tictactoe.FmtBoard(board))
@@ -208,15 +408,17 @@ func (s *GrpcServer) GetGame(ctx context.Context, in *pb.GetGameReq) (*pb.Game,
 		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
 	}
 
-	// fetch game and steps from the database at the same time
+	// fetch game, steps, chat and the series leading up to the game from the database at the same time
 	var eg *errgroup.Group
 	eg, ctx = errgroup.WithContext(ctx)
 
 	var gameRow db.GetGameRow
 	var stepRows []db.GameStep
+	var seriesRows []db.GetSeriesRow
+	var messageRows []db.GetGameMessagesRow
 
 	eg.Go(func() error {
-		row, err := s.Queries.GetGame(ctx, in.Id)
+		row, err := s.Store.GetGame(ctx, in.Id)
 		if err != nil {
 			log.Printf("failed to get game: %v", err)
 			return status.Errorf(codes.NotFound, "failed to get games for id: %v", in.Id)
@@ -225,7 +427,7 @@ func (s *GrpcServer) GetGame(ctx context.Context, in *pb.GetGameReq) (*pb.Game,
 		return nil
 	})
 	eg.Go(func() error {
-		rows, err := s.Queries.GetGameSteps(ctx, in.Id)
+		rows, err := s.Store.GetGameSteps(ctx, in.Id)
 		if err != nil {
 			log.Printf("failed to get steps: %v", err)
 			return status.Errorf(codes.NotFound, "failed to get steps for id: %v", in.Id)
@@ -233,6 +435,24 @@ func (s *GrpcServer) GetGame(ctx context.Context, in *pb.GetGameReq) (*pb.Game,
 		stepRows = rows
 		return nil
 	})
+	eg.Go(func() error {
+		rows, err := s.Store.GetSeries(ctx, in.Id)
+		if err != nil {
+			log.Printf("failed to get series: %v", err)
+			return status.Errorf(codes.Internal, "failed to get series for id: %v", in.Id)
+		}
+		seriesRows = rows
+		return nil
+	})
+	eg.Go(func() error {
+		rows, err := s.Store.GetGameMessages(ctx, in.Id)
+		if err != nil {
+			log.Printf("failed to get messages: %v", err)
+			return status.Errorf(codes.Internal, "failed to get messages for id: %v", in.Id)
+		}
+		messageRows = rows
+		return nil
+	})
 
 	if err := eg.Wait(); err != nil {
 		log.Printf("failed to wait for data with err: %v", err)
@@ -240,6 +460,12 @@ func (s *GrpcServer) GetGame(ctx context.Context, in *pb.GetGameReq) (*pb.Game,
 	}
 
 	game := MapGetGame(gameRow, stepRows)
+	// signing in is optional, it only reveals the players' chat to the players
+	viewer, _ := PlayerFromContext(ctx)
+	game.Messages = MapMessages(messageRows, IsSeated(gameRow, viewer.ID))
+	if gameRow.RematchOf.Valid {
+		game.Series = MapSeries(gameRow, seriesRows)
+	}
 	log.Printf("successfully fetched game: %v", game.String())
 
 	return game, nil
@@ -285,7 +511,7 @@ func (s *GrpcServer) GetGames(ctx context.Context, in *pb.GetGamesReq) (*pb.Game
 	eg.Go(func() error {
 		log.Printf("fetching games for params: %+v", params)
 		var err error
-		gameRows, err = s.Queries.GetGames(egCtx, params)
+		gameRows, err = s.Store.GetGames(egCtx, params)
 		if err != nil {
 			log.Printf("failed to get games: %v", err)
 			return status.Errorf(codes.Internal, "failed to get games for params: %+v", params)
@@ -295,7 +521,7 @@ func (s *GrpcServer) GetGames(ctx context.Context, in *pb.GetGamesReq) (*pb.Game
 	eg.Go(func() error {
 		var err error
 		log.Printf("fetching game steps for for ids: %+v", gameIds)
-		stepRows, err = s.Queries.GetGamesSteps(egCtx, gameIds)
+		stepRows, err = s.Store.GetGamesSteps(egCtx, gameIds)
 		if err != nil {
 			log.Printf("failed to get steps: %v", err)
 			return status.Errorf(codes.Internal, "failed to get steps for ids: %+v", gameIds)
@@ -317,16 +543,27 @@ func (s *GrpcServer) GetGames(ctx context.Context, in *pb.GetGamesReq) (*pb.Game
 func (s *GrpcServer) ListenSteps(in *pb.ListenStepsReq, stream grpc.ServerStreamingServer[pb.Step]) error {
 	ctx := stream.Context()
 
+	// a takeback deletes steps, which shows up as the last step going back or being replaced by another
+	var lastStep *db.GameStep
+
 	ticker := time.NewTicker(time.Second * 2)
 	for t := range ticker.C {
-		row, err := s.Queries.GetLastStep(ctx, in.Id)
-		if errors.Is(err, sql.ErrNoRows) {
+		row, err := s.Store.GetLastStep(ctx, in.Id)
+		if errors.Is(err, ErrNotFound) {
+			if lastStep != nil {
+				if err = s.SendStartRollback(ctx, in.Id, stream); err != nil {
+					return err
+				}
+				lastStep = nil
+			}
 			continue
 		}
 		if err != nil {
 			log.Printf("failed to get last step: %v", err)
 			return status.Errorf(codes.Internal, "failed to listen steps for game id: %d", in.Id)
 		}
+		rollback := lastStep != nil && (row.Ord < lastStep.Ord || row.Ord == lastStep.Ord && !row.MadeOn.Time.Equal(lastStep.MadeOn.Time))
+		lastStep = &row
 
 		board, err := tictactoe.ParseBoard(row.Board)
 		if err != nil {
@@ -335,6 +572,7 @@ func (s *GrpcServer) ListenSteps(in *pb.ListenStepsReq, stream grpc.ServerStream
 		}
 
 		step := MapStep(row)
+		step.Rollback = rollback
 		log.Printf("recieved step at time: %s, with value: %s, board: %v", t, step.String(), tictactoe.FmtBoard(board))
 
 		if err = stream.Send(step); err != nil {
@@ -349,21 +587,125 @@ func (s *GrpcServer) ListenSteps(in *pb.ListenStepsReq, stream grpc.ServerStream
 	return nil
 }
 
-func (s *GrpcServer) MakeMove(ctx context.Context, in *pb.MakeMoveReq) (*pb.Game, error) {
+// SendStartRollback tells listeners that every step was taken back, sending the starting position in place
+// of a step.
+func (s *GrpcServer) SendStartRollback(ctx context.Context, gameId int64, stream grpc.ServerStreamingServer[pb.Step]) error {
+	gameRow, err := s.Store.GetGame(ctx, gameId)
+	if err != nil {
+		log.Printf("failed to get game: %v", err)
+		return status.Errorf(codes.Internal, "failed to listen steps for game id: %d", gameId)
+	}
+	step := &pb.Step{GameId: gameId, Ord: -1, Board: gameRow.StartState, XTurn: gameRow.XTurn.Bool, Rollback: true}
+	if err = stream.Send(step); err != nil {
+		log.Printf("failed to send step: %v", err)
+	}
+	return nil
+}
+
+func (s *GrpcServer) RequestTakeback(ctx context.Context, in *pb.RequestTakebackReq) (*pb.Game, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for takeback req %v: %v", in, err)
+		return nil, err
+	}
+	err = ValidateTakeback(gameRow, sessRow.ID, false)
+	if err != nil {
+		log.Printf("failed to validate takeback req %v: %v", in, err)
+		return nil, err
+	}
+
+	stepRows, err := s.Store.GetGameSteps(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get steps: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get steps for game: %d", in.GameId)
+	}
+	if _, _, err = TakebackSteps(gameRow, stepRows, sessRow.ID); err != nil {
+		log.Printf("failed to find a move to take back for takeback req %v: %v", in, err)
+		return nil, err
+	}
+
+	takebackBy := pgtype.Int8{Int64: sessRow.ID, Valid: true}
+	result, err := s.Store.RequestTakeback(ctx, db.RequestTakebackParams{ID: in.GameId, TakebackBy: takebackBy})
+	if err != nil {
+		log.Printf("failed to request takeback: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to request takeback for game: %d", in.GameId)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, status.Errorf(codes.Aborted, "takeback on game: %d was requested concurrently", in.GameId)
+	}
+
+	gameRow.TakebackBy = takebackBy
+	game := MapGetGame(gameRow, stepRows)
+	log.Printf("player: %d requested a takeback on game: %d", sessRow.ID, in.GameId)
+
+	return game, nil
+}
+
+func (s *GrpcServer) RespondTakeback(ctx context.Context, in *pb.RespondTakebackReq) (*pb.Game, error) {
 	if in == nil {
 		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
 	}
 
-	md, err := GetSideChannelInfo(ctx)
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for takeback req %v: %v", in, err)
+		return nil, err
+	}
+	err = ValidateTakeback(gameRow, sessRow.ID, true)
 	if err != nil {
-		log.Printf("failed to get side channel info for move req %v: %v", in, err)
+		log.Printf("failed to validate takeback req %v: %v", in, err)
 		return nil, err
 	}
-	sessRow, gameRow, err := s.GetGameAndSession(ctx, md.Authorization, in.GameId)
+
+	if in.Accept {
+		updtGameParams, err := s.TakebackTrans(ctx, gameRow, gameRow.TakebackBy.Int64)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("player: %d accepted the takeback on game: %d, board: %s", sessRow.ID, in.GameId, updtGameParams.BoardState)
+		return MapGetGameWithUpdt(gameRow, updtGameParams), nil
+	}
+
+	result, err := s.Store.ClearTakeback(ctx, db.ClearTakebackParams{ID: in.GameId, TakebackBy: gameRow.TakebackBy})
+	if err != nil {
+		log.Printf("failed to clear takeback: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to decline takeback for game: %d", in.GameId)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, status.Errorf(codes.Aborted, "takeback on game: %d was answered concurrently", in.GameId)
+	}
+
+	stepRows, err := s.Store.GetGameSteps(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get steps: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get steps for game: %d", in.GameId)
+	}
+
+	gameRow.TakebackBy = pgtype.Int8{}
+	log.Printf("player: %d declined the takeback on game: %d", sessRow.ID, in.GameId)
+
+	return MapGetGame(gameRow, stepRows), nil
+}
+
+func (s *GrpcServer) MakeMove(ctx context.Context, in *pb.MakeMoveReq) (*pb.Game, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
 	if err != nil {
 		log.Printf("failed to get game and session for move req %v: %v", in, err)
 		return nil, err
 	}
+	if in.Ord != nil {
+		if err = s.CheckMoveOrd(ctx, gameRow, *in.Ord); err != nil {
+			return nil, err
+		}
+	}
 	err = ValidateMakeMove(gameRow, sessRow.ID)
 	if err != nil {
 		log.Printf("failed validate move state for move req %v: %v", in, err)
@@ -379,20 +721,24 @@ func (s *GrpcServer) MakeMove(ctx context.Context, in *pb.MakeMoveReq) (*pb.Game
 
 	updtGameParams := db.UpdateGameParams{
 		ID:         gameRow.ID,
+		Version:    gameRow.Version,
 		BoardState: tictactoe.BoardToString(result.Board),
 		XTurn:      pgtype.Bool{Bool: result.Turn, Valid: true},
 		UpdatedOn:  pgtype.Timestamptz(pgtype.Timestamp{Time: time.Now(), Valid: true}),
 		Result:     result.Result,
+		Opening:    result.Opening,
 	}
 	instStepParams := db.InsertStepParams{
 		GameID:  gameRow.ID,
 		MoveRow: in.Row,
 		MoveCol: in.Col,
-		Board:   gameRow.BoardState,
+		Board:   tictactoe.BoardToString(result.Board),
 		XTurn:   result.Turn,
 		Result:  result.Result,
 	}
-	err = s.UpdateGameTrans(ctx, in.GameId, updtGameParams, instStepParams)
+	payload := map[string]any{"row": in.Row, "col": in.Col, "result": result.Result}
+	event := AuditEvent{ActorID: sessRow.ID, EventType: AuditMove, TargetID: gameRow.ID, Payload: payload}
+	err = s.UpdateGameTrans(ctx, in.GameId, updtGameParams, instStepParams, event)
 	if err != nil {
 		return nil, err
 	}
@@ -406,19 +752,11 @@ func (s *GrpcServer) WhoAmI(ctx context.Context, in *pb.WhoAmIReq) (*pb.Player,
 		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
 	}
 
-	md, err := GetSideChannelInfo(ctx)
+	sessRow, err := RequirePlayer(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("called WhoAmI with token: %v", md.Authorization)
-
-	sessRow, err := s.Queries.GetSession(ctx, md.Authorization)
-	if err != nil {
-		log.Printf("failed to get session: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to get session for token: %s", md.Authorization)
-	}
-
 	player := pb.Player{
 		Id:       sessRow.ID,
 		Username: sessRow.Username,
@@ -428,3 +766,466 @@ func (s *GrpcServer) WhoAmI(ctx context.Context, in *pb.WhoAmIReq) (*pb.Player,
 
 	return &player, nil
 }
+
+func (s *GrpcServer) AnalyzeGame(ctx context.Context, in *pb.AnalyzeGameReq) (*pb.GameAnalysis, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	gameRow, err := s.Store.GetGame(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game: %v", err)
+		return nil, status.Errorf(codes.NotFound, "failed to get game for id: %d", in.GameId)
+	}
+	if gameRow.Result == tictactoe.Playing {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot analyze game: %d, game is still in play", in.GameId)
+	}
+
+	// a finished game never changes, so a stored analysis can be returned as is
+	analysisRows, err := s.Store.GetAnalyses(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get analyses: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get analyses for id: %d", in.GameId)
+	}
+	if len(analysisRows) > 0 {
+		analysis := MapAnalysis(in.GameId, analysisRows)
+		log.Printf("retrieved stored analysis: %v", analysis.String())
+		return analysis, nil
+	}
+
+	stepRows, err := s.Store.GetGameSteps(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get steps: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get steps for id: %d", in.GameId)
+	}
+
+	analysisRows, err = AnalyzeSteps(in.GameId, gameRow.StartState, stepRows)
+	if err != nil {
+		return nil, err
+	}
+	err = s.InsertAnalysesTrans(ctx, in.GameId, analysisRows)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := MapAnalysis(in.GameId, analysisRows)
+	log.Printf("successfully analyzed game: %v", analysis.String())
+
+	return analysis, nil
+}
+
+func (s *GrpcServer) GetHint(ctx context.Context, in *pb.GetHintReq) (*pb.Hint, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for hint req %v: %v", in, err)
+		return nil, err
+	}
+	err = ValidateMakeMove(gameRow, sessRow.ID)
+	if err != nil {
+		log.Printf("failed validate move state for hint req %v: %v", in, err)
+		return nil, err
+	}
+	if gameRow.HintBudget == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot get hint on game: %d, hints are disabled", in.GameId)
+	}
+
+	board, err := tictactoe.ParseBoard(gameRow.BoardState)
+	if err != nil {
+		log.Printf("error converting board from string: %v", err)
+		return nil, status.Error(codes.Internal, "error converting board from string")
+	}
+	err = tictactoe.ValidatePosition(board, gameRow.XTurn.Bool)
+	if err != nil {
+		log.Printf("game: %d has a corrupt board state: %s, %v", gameRow.ID, gameRow.BoardState, err)
+		return nil, status.Errorf(codes.DataLoss, "game: %d has a corrupt board state", gameRow.ID)
+	}
+	solution := tictactoe.Solve(board, gameRow.XTurn.Bool)
+
+	// the budget is checked by the update itself so concurrent requests cannot overspend it
+	params := db.UseHintParams{ID: gameRow.ID, XTurn: gameRow.XTurn.Bool}
+	result, err := s.Store.UseHint(ctx, params)
+	if err != nil {
+		log.Printf("failed to use hint: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to use hint for params: %+v", params)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "cannot get hint on game: %d, hint budget of %d is used up", in.GameId, gameRow.HintBudget)
+	}
+
+	hint := MapHint(gameRow, solution)
+	log.Printf("successfully retrieved hint: %v, board: %v", hint.String(), tictactoe.FmtBoard(board))
+
+	return hint, nil
+}
+
+func (s *GrpcServer) GetPuzzle(ctx context.Context, in *pb.GetPuzzleReq) (*pb.Puzzle, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	row, err := s.Store.GetPuzzle(ctx, in.Id)
+	if err != nil {
+		log.Printf("failed to get puzzle: %v", err)
+		return nil, status.Errorf(codes.NotFound, "failed to get puzzle for id: %d", in.Id)
+	}
+
+	puzzle := MapPuzzle(row)
+	log.Printf("successfully fetched puzzle: %v", puzzle.String())
+
+	return puzzle, nil
+}
+
+func (s *GrpcServer) SubmitPuzzleMove(ctx context.Context, in *pb.SubmitPuzzleMoveReq) (*pb.PuzzleAttempt, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzleRow, err := s.Store.GetPuzzle(ctx, in.PuzzleId)
+	if err != nil {
+		log.Printf("failed to get puzzle: %v", err)
+		return nil, status.Errorf(codes.NotFound, "failed to get puzzle for id: %d", in.PuzzleId)
+	}
+
+	// an attempt starts from the puzzle position the first time a player submits a move
+	instAttemptParams := db.InsertPuzzleAttemptParams{
+		PuzzleID:   puzzleRow.ID,
+		PlayerID:   sessRow.ID,
+		BoardState: puzzleRow.BoardState,
+		XTurn:      puzzleRow.XTurn,
+		MovesLeft:  puzzleRow.Depth,
+	}
+	_, err = s.Store.InsertPuzzleAttempt(ctx, instAttemptParams)
+	if err != nil {
+		log.Printf("failed to insert puzzle attempt: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to insert puzzle attempt for params: %+v", instAttemptParams)
+	}
+	attemptRow, err := s.Store.GetPuzzleAttempt(ctx, db.GetPuzzleAttemptParams{PuzzleID: puzzleRow.ID, PlayerID: sessRow.ID})
+	if err != nil {
+		log.Printf("failed to get puzzle attempt: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get puzzle attempt for puzzle: %d", puzzleRow.ID)
+	}
+	if attemptRow.Status != tictactoe.PuzzleSolving {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot move on puzzle: %d, attempt is already over", puzzleRow.ID)
+	}
+
+	board, err := tictactoe.ParseBoard(attemptRow.BoardState)
+	if err != nil {
+		log.Printf("error converting board from string: %v", err)
+		return nil, status.Error(codes.Internal, "error converting board from string")
+	}
+	step, err := tictactoe.PlayPuzzleMove(board, attemptRow.XTurn, attemptRow.MovesLeft, tictactoe.Tile{Row: in.Row, Col: in.Col})
+	if err != nil {
+		log.Printf("cannot make move on puzzle: %d, %v", puzzleRow.ID, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	streakRow, err := s.UpdatePuzzleAttemptTrans(ctx, attemptRow, step)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := MapPuzzleAttempt(puzzleRow, step, streakRow)
+	log.Printf("made move on puzzle: %d, attempt: %v, board: %v", puzzleRow.ID, attempt.String(), tictactoe.FmtBoard(step.Board))
+
+	return attempt, nil
+}
+
+func (s *GrpcServer) GetDailyPuzzle(ctx context.Context, in *pb.GetDailyPuzzleReq) (*pb.DailyPuzzle, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the background job normally schedules the day ahead, but schedule it here if it has not run yet
+	day := DayOf(time.Now())
+	err = s.EnsureDailyPuzzle(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	puzzleRow, err := s.Store.GetDailyPuzzle(ctx, day)
+	if err != nil {
+		log.Printf("failed to get daily puzzle: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get daily puzzle for day: %s", day.Time.Format(DayLayout))
+	}
+
+	// fetching the puzzle starts the attempt, so leaderboard timings run from the first fetch
+	instAttemptParams := db.InsertPuzzleAttemptParams{
+		PuzzleID:   puzzleRow.ID,
+		PlayerID:   sessRow.ID,
+		BoardState: puzzleRow.BoardState,
+		XTurn:      puzzleRow.XTurn,
+		MovesLeft:  puzzleRow.Depth,
+	}
+	_, err = s.Store.InsertPuzzleAttempt(ctx, instAttemptParams)
+	if err != nil {
+		log.Printf("failed to insert puzzle attempt: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to insert puzzle attempt for params: %+v", instAttemptParams)
+	}
+
+	dailyPuzzle := &pb.DailyPuzzle{Day: day.Time.Format(DayLayout), Puzzle: MapPuzzle(puzzleRow)}
+	log.Printf("successfully fetched daily puzzle: %v", dailyPuzzle.String())
+
+	return dailyPuzzle, nil
+}
+
+func (s *GrpcServer) GetDailyLeaderboard(ctx context.Context, in *pb.GetDailyLeaderboardReq) (*pb.DailyLeaderboard, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	err := ValidateGetDailyLeaderboard(in)
+	if err != nil {
+		log.Printf("failed to validate leaderboard req %v: %v", in, err)
+		return nil, err
+	}
+
+	day := DayOf(time.Now())
+	if in.Day != "" {
+		// the format was checked by ValidateGetDailyLeaderboard
+		t, _ := time.Parse(DayLayout, in.Day)
+		day = DayOf(t)
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	params := db.GetDailyLeaderboardParams{Day: day, Status: tictactoe.PuzzleSolved, Limit: limit}
+	rows, err := s.Store.GetDailyLeaderboard(ctx, params)
+	if err != nil {
+		log.Printf("failed to get daily leaderboard: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get daily leaderboard for params: %+v", params)
+	}
+
+	leaderboard := MapDailyLeaderboard(day.Time.Format(DayLayout), rows)
+	log.Printf("successfully fetched daily leaderboard: %v", leaderboard.String())
+
+	return leaderboard, nil
+}
+
+func (s *GrpcServer) GetOpeningStats(ctx context.Context, in *pb.GetOpeningStatsReq) (*pb.OpeningStats, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	var playerParam pgtype.Int8
+	if in.Player != nil {
+		playerParam.Valid = true
+		playerParam.Int64 = in.Player.Id
+	}
+
+	rows, err := s.Store.GetOpeningStats(ctx, playerParam)
+	if err != nil {
+		log.Printf("failed to get opening stats: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get opening stats for player: %v", in.Player)
+	}
+
+	stats := MapOpeningStats(rows, playerParam.Valid)
+	log.Printf("successfully fetched opening stats for player=%v: %v", in.Player, stats.String())
+
+	return stats, nil
+}
+
+func (s *GrpcServer) OfferRematch(ctx context.Context, in *pb.OfferRematchReq) (*pb.Game, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for rematch req %v: %v", in, err)
+		return nil, err
+	}
+	err = ValidateRematch(gameRow, sessRow.ID, false)
+	if err != nil {
+		log.Printf("failed to validate rematch req %v: %v", in, err)
+		return nil, err
+	}
+
+	rematchId, err := s.Store.GetRematch(ctx, pgtype.Int8{Int64: in.GameId, Valid: true})
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "game: %d was already rematched by game: %d", in.GameId, rematchId)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		log.Printf("failed to get rematch: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get rematch for game: %d", in.GameId)
+	}
+
+	rematchBy := pgtype.Int8{Int64: sessRow.ID, Valid: true}
+	result, err := s.Store.OfferRematch(ctx, db.OfferRematchParams{ID: in.GameId, RematchBy: rematchBy})
+	if err != nil {
+		log.Printf("failed to offer rematch: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to offer rematch for game: %d", in.GameId)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, status.Errorf(codes.Aborted, "rematch on game: %d was offered concurrently", in.GameId)
+	}
+
+	stepRows, err := s.Store.GetGameSteps(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get steps: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get steps for game: %d", in.GameId)
+	}
+
+	gameRow.RematchBy = rematchBy
+	game := MapGetGame(gameRow, stepRows)
+	log.Printf("player: %d offered a rematch on game: %d", sessRow.ID, in.GameId)
+
+	return game, nil
+}
+
+func (s *GrpcServer) RespondRematch(ctx context.Context, in *pb.RespondRematchReq) (*pb.Game, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for rematch req %v: %v", in, err)
+		return nil, err
+	}
+	err = ValidateRematch(gameRow, sessRow.ID, true)
+	if err != nil {
+		log.Printf("failed to validate rematch req %v: %v", in, err)
+		return nil, err
+	}
+
+	if !in.Accept {
+		result, err := s.Store.ClearRematch(ctx, db.ClearRematchParams{ID: in.GameId, RematchBy: gameRow.RematchBy})
+		if err != nil {
+			log.Printf("failed to clear rematch: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to decline rematch for game: %d", in.GameId)
+		}
+		if result.RowsAffected() == 0 {
+			return nil, status.Errorf(codes.Aborted, "rematch on game: %d was answered concurrently", in.GameId)
+		}
+
+		stepRows, err := s.Store.GetGameSteps(ctx, in.GameId)
+		if err != nil {
+			log.Printf("failed to get steps: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to get steps for game: %d", in.GameId)
+		}
+
+		gameRow.RematchBy = pgtype.Int8{}
+		log.Printf("player: %d declined the rematch on game: %d", sessRow.ID, in.GameId)
+
+		return MapGetGame(gameRow, stepRows), nil
+	}
+
+	gameId, err := s.RematchTrans(ctx, gameRow, gameRow.RematchBy.Int64)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("player: %d accepted the rematch on game: %d", sessRow.ID, in.GameId)
+
+	return s.GetGame(ctx, &pb.GetGameReq{Id: gameId})
+}
+
+func (s *GrpcServer) SendMessage(ctx context.Context, in *pb.SendMessageReq) (*pb.Message, error) {
+	if in == nil {
+		return nil, status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+
+	err := ValidateSendMessage(in, s.BlockedWords)
+	if err != nil {
+		log.Printf("failed to validate message req %v: %v", in, err)
+		return nil, err
+	}
+
+	sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+	if err != nil {
+		log.Printf("failed to get game and session for message req %v: %v", in, err)
+		return nil, err
+	}
+	if in.Channel == ChannelPlayers && !IsSeated(gameRow, sessRow.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "player: %d is not playing game: %d", sessRow.ID, in.GameId)
+	}
+
+	params := db.InsertMessageParams{
+		GameID:   in.GameId,
+		PlayerID: sessRow.ID,
+		Channel:  in.Channel,
+		Text:     strings.TrimSpace(in.Text),
+		SentOn:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	id, err := s.SendMessageTrans(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	message := MapMessage(db.GetGameMessagesRow{
+		ID:       id,
+		GameID:   params.GameID,
+		PlayerID: params.PlayerID,
+		Channel:  params.Channel,
+		Text:     params.Text,
+		SentOn:   params.SentOn,
+		Username: sessRow.Username,
+	})
+	log.Printf("successfully sent message: %v", message.String())
+
+	return message, nil
+}
+
+func (s *GrpcServer) StreamMessages(in *pb.StreamMessagesReq, stream grpc.ServerStreamingServer[pb.Message]) error {
+	if in == nil {
+		return status.Error(codes.Internal, "expected input request to be provided, was nil")
+	}
+	ctx := stream.Context()
+
+	if in.Channel == ChannelPlayers {
+		sessRow, gameRow, err := s.GetGameAndPlayer(ctx, in.GameId)
+		if err != nil {
+			log.Printf("failed to get game and session for stream req %v: %v", in, err)
+			return err
+		}
+		if !IsSeated(gameRow, sessRow.ID) {
+			return status.Errorf(codes.PermissionDenied, "player: %d is not playing game: %d", sessRow.ID, in.GameId)
+		}
+	} else if in.Channel == ChannelSpectators {
+		if _, err := s.Store.GetGame(ctx, in.GameId); err != nil {
+			log.Printf("failed to get game: %v", err)
+			return status.Errorf(codes.NotFound, "failed to get game for id: %d", in.GameId)
+		}
+	} else {
+		return status.Errorf(codes.InvalidArgument, "channel must be %d for players or %d for spectators", ChannelPlayers, ChannelSpectators)
+	}
+
+	afterId := in.AfterId
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		params := db.GetMessagesAfterParams{GameID: in.GameId, Channel: in.Channel, ID: afterId, Limit: 100}
+		rows, err := s.Store.GetMessagesAfter(ctx, params)
+		if err != nil {
+			log.Printf("failed to get messages: %v", err)
+			return status.Errorf(codes.Internal, "failed to stream messages for game id: %d", in.GameId)
+		}
+		for _, row := range rows {
+			if err = stream.Send(MapMessage(db.GetGameMessagesRow(row))); err != nil {
+				log.Printf("failed to send message: %v", err)
+				return nil
+			}
+			afterId = row.ID
+		}
+	}
+}
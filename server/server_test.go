@@ -2,24 +2,37 @@ package server
 
 import (
 	"TicTacGo/db"
+	"TicTacGo/db/sqlite"
 	"TicTacGo/tictactoe"
 	"context"
+	"database/sql"
 	_ "embed"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"log"
 	"net"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -34,12 +47,28 @@ var testDbName = "tictacgo"
 var testDbPass = "password123"
 var testDbPort = 9876
 
+var testTokenKeys = TokenKeys{Current: "test", Keys: map[string][]byte{"test": []byte(strings.Repeat("k", 32))}}
+
+// the seeded players' access tokens, for the sessions seeded with refresh tokens "User1Token" and "User3Token"
+var user1Token = signTestToken(Claims{ID: 1, Username: "user1", SessionID: 1})
+var user3Token = signTestToken(Claims{ID: 3, Username: "user3", SessionID: 2})
+
+func signTestToken(claims Claims) string {
+	claims.ExpiresOn = time.Now().Add(time.Hour).Unix()
+	token, err := testTokenKeys.Sign(claims)
+	if err != nil {
+		log.Fatalf("failed to sign test token: %v", err)
+	}
+	return token
+}
+
 var testPbGames = []pb.Game{
 	{
 		Id:         1,
 		XPlayer:    &pb.Player{Id: 1, Username: "user1"},
 		OPlayer:    &pb.Player{Id: 2, Username: "user2"},
 		BoardState: "x_o______",
+		StartState: "_________",
 		XTurn:      true,
 		Result:     tictactoe.Playing,
 		Steps: []*pb.Step{
@@ -52,6 +81,7 @@ var testPbGames = []pb.Game{
 		XPlayer:    &pb.Player{Id: 2, Username: "user2"},
 		OPlayer:    &pb.Player{Id: 1, Username: "user1"},
 		BoardState: "_________",
+		StartState: "_________",
 		XTurn:      true,
 		Result:     tictactoe.Playing,
 		Steps:      []*pb.Step{},
@@ -61,6 +91,7 @@ var testPbGames = []pb.Game{
 		XPlayer:    &pb.Player{Id: 1, Username: "user1"},
 		OPlayer:    &pb.Player{Id: 3, Username: "user3"},
 		BoardState: "_________",
+		StartState: "_________",
 		XTurn:      true,
 		Result:     tictactoe.Forfeit,
 		Steps: []*pb.Step{
@@ -71,16 +102,21 @@ var testPbGames = []pb.Game{
 		Id:         4,
 		XPlayer:    &pb.Player{Id: 1, Username: "user1"},
 		BoardState: "_________",
+		StartState: "_________",
 		XTurn:      true,
 		Result:     tictactoe.Playing,
 		Steps:      []*pb.Step{},
 	},
 }
 
+// TestArgs are what a test gets to reach the server and the backend behind it. The db is not set on the
+// memory store, and the pool is only set on postgres, for the migration tests.
 type TestArgs struct {
-	client  pb.TicTacGoServiceClient
-	pool    *pgxpool.Pool
-	queries *db.Queries
+	client pb.TicTacGoServiceClient
+	admin  pb.AdminServiceClient
+	store  Store
+	db     *sql.DB
+	pool   *pgxpool.Pool
 }
 
 func createEmbeddedDb(t *testing.T) func() {
@@ -113,6 +149,17 @@ func createEmbeddedDb(t *testing.T) func() {
 }
 
 func seedTestData(c TestArgs) {
+	switch {
+	case c.pool != nil:
+		seedPostgres(c)
+	case c.db != nil:
+		seedSqlite(c)
+	default:
+		seedMemory(c)
+	}
+}
+
+func seedPostgres(c TestArgs) {
 	ctx := context.Background()
 
 	conn, err := c.pool.Acquire(ctx)
@@ -121,14 +168,14 @@ func seedTestData(c TestArgs) {
 	}
 	defer conn.Release()
 
-	_, err = conn.Exec(ctx, "DROP TABLE IF EXISTS player_accounts, player_sessions, games, game_steps;")
+	_, err = conn.Exec(ctx, "DROP SCHEMA public CASCADE; CREATE SCHEMA public;")
 	if err != nil {
 		log.Fatalf("failed to drop schema with err: %v", err)
 	}
 
-	_, err = conn.Exec(ctx, db.CreateSchema)
+	_, err = db.MigrateUp(ctx, c.pool)
 	if err != nil {
-		log.Fatalf("failed to execute CreateSchema with err: %v", err)
+		log.Fatalf("failed to migrate the schema with err: %v", err)
 	}
 
 	_, err = conn.Exec(ctx, db.SeedTestData)
@@ -139,14 +186,124 @@ func seedTestData(c TestArgs) {
 	log.Printf("successfully created the database schema")
 }
 
-func serve(ctx context.Context, t *testing.T, pool *pgxpool.Pool) (pb.TicTacGoServiceClient, func()) {
+func seedSqlite(c TestArgs) {
+	ctx := context.Background()
+
+	// dropping tables runs an implicit delete, which would trip the foreign keys of the tables dropped later
+	_, err := c.db.ExecContext(ctx, `PRAGMA foreign_keys = OFF;
+DROP TABLE IF EXISTS player_accounts; DROP TABLE IF EXISTS player_sessions; DROP TABLE IF EXISTS games;
+DROP TABLE IF EXISTS game_steps; DROP TABLE IF EXISTS analyses; DROP TABLE IF EXISTS puzzles;
+DROP TABLE IF EXISTS puzzle_attempts; DROP TABLE IF EXISTS daily_puzzles; DROP TABLE IF EXISTS game_messages;
+DROP TABLE IF EXISTS login_failures; DROP TABLE IF EXISTS audit_events; DROP TABLE IF EXISTS idempotency_keys;
+PRAGMA foreign_keys = ON;`)
+	if err != nil {
+		log.Fatalf("failed to drop schema with err: %v", err)
+	}
+
+	_, err = c.db.ExecContext(ctx, sqlite.CreateSchema)
+	if err != nil {
+		log.Fatalf("failed to execute CreateSchema with err: %v", err)
+	}
+
+	_, err = c.db.ExecContext(ctx, db.SeedTestData)
+	if err != nil {
+		log.Fatalf("failed to execute SeedTestData with err: %v", err)
+	}
+}
+
+// seedMemory empties the memory store and fills it with the rows of db.SeedTestData.
+func seedMemory(c TestArgs) {
+	ctx := context.Background()
+
+	store := c.store.(*MemStore)
+	store.mu.Lock()
+	*store.data = *NewMemStore().data
+	store.mu.Unlock()
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	for i, role := range []int32{RolePlayer, RolePlayer, RolePlayer, RoleModerator, RoleAdmin} {
+		row, err := store.InsertPlayer(ctx, db.InsertPlayerParams{Username: fmt.Sprintf("user%d", i+1), Passwd: "password123", Salt: "test"})
+		if err != nil {
+			log.Fatalf("failed to insert player with err: %v", err)
+		}
+		if _, err = store.SetRole(ctx, db.SetRoleParams{ID: row.ID, Role: role}); err != nil {
+			log.Fatalf("failed to set role with err: %v", err)
+		}
+	}
+
+	for _, session := range []struct {
+		token    string
+		playerID int64
+	}{{"User1Token", 1}, {"User3Token", 3}} {
+		_, err := store.InsertSession(ctx, db.InsertSessionParams{
+			TokenHash:  HashToken(session.token),
+			PlayerID:   session.playerID,
+			CreatedOn:  now,
+			LastSeenOn: now,
+			ExpiresOn:  pgtype.Timestamptz{Time: now.Time.Add(DefaultSessionTTL), Valid: true},
+		})
+		if err != nil {
+			log.Fatalf("failed to insert session with err: %v", err)
+		}
+	}
+
+	games := []struct {
+		xPlayer int64
+		oPlayer pgtype.Int8
+		board   string
+		result  int32
+	}{
+		{1, pgtype.Int8{Int64: 2, Valid: true}, "x_o______", tictactoe.Playing},
+		{2, pgtype.Int8{Int64: 1, Valid: true}, "_________", tictactoe.Playing},
+		{1, pgtype.Int8{Int64: 3, Valid: true}, "_________", 4},
+		{1, pgtype.Int8{}, "_________", tictactoe.Playing},
+	}
+	for _, game := range games {
+		id, err := store.InsertGame(ctx, db.InsertGameParams{
+			XPlayer:    game.xPlayer,
+			OPlayer:    game.oPlayer,
+			BoardState: game.board,
+			StartState: "_________",
+			XTurn:      pgtype.Bool{Bool: true, Valid: true},
+			UpdatedOn:  now,
+			StartedOn:  now,
+		})
+		if err != nil {
+			log.Fatalf("failed to insert game with err: %v", err)
+		}
+		// the result is set in place, as updating the game would also move its version on
+		row := store.data.games[id]
+		row.Result = game.result
+		store.data.games[id] = row
+	}
+
+	for _, step := range []db.InsertStepParams{
+		{GameID: 1, MoveRow: 0, MoveCol: 0, Board: "x________", XTurn: false},
+		{GameID: 1, MoveRow: 0, MoveCol: 2, Board: "x_o______", XTurn: true},
+		{GameID: 3, MoveRow: 0, MoveCol: 0, Board: "_________", XTurn: true, Result: 4},
+	} {
+		if _, err := store.InsertStep(ctx, step); err != nil {
+			log.Fatalf("failed to insert step with err: %v", err)
+		}
+	}
+}
+
+func serve(ctx context.Context, t *testing.T, args TestArgs) (pb.TicTacGoServiceClient, pb.AdminServiceClient, func()) {
+	return serveWith(ctx, t, testServer(args))
+}
+
+// testServer builds a server on the test backend.
+func testServer(args TestArgs) *GrpcServer {
+	return &GrpcServer{Store: args.store, BlockedWords: DefaultBlockedWords, TokenKeys: testTokenKeys}
+}
+
+func serveWith(ctx context.Context, t *testing.T, server *GrpcServer) (pb.TicTacGoServiceClient, pb.AdminServiceClient, func()) {
 	buffer := 1024 * 1024
 	lis := bufconn.Listen(buffer)
 
-	server := &GrpcServer{Queries: db.New(pool), Pool: pool}
-
-	baseServer := grpc.NewServer()
+	baseServer := grpc.NewServer(server.ServerOptions()...)
 	pb.RegisterTicTacGoServiceServer(baseServer, server)
+	pb.RegisterAdminServiceServer(baseServer, server)
 	go func() {
 		if err := baseServer.Serve(lis); err != nil {
 			t.Logf("error serving server: %v", err)
@@ -171,10 +328,58 @@ func serve(ctx context.Context, t *testing.T, pool *pgxpool.Pool) (pb.TicTacGoSe
 	}
 
 	client := pb.NewTicTacGoServiceClient(conn)
-	return client, closer
+	admin := pb.NewAdminServiceClient(conn)
+	return client, admin, closer
+}
+
+// serverTests run against every backend.
+var serverTests = []struct {
+	name string
+	run  func(t *testing.T, args TestArgs)
+}{
+	{name: "RegisterAndLogin", run: testRegisterAndLogin},
+	{name: "Authentication", run: testAuthentication},
+	{name: "Sessions", run: testSessions},
+	{name: "Accounts", run: testAccounts},
+	{name: "LoginLockout", run: testLoginLockout},
+	{name: "GetPlayers", run: testGetPlayers},
+	{name: "CreateGame", run: testCreateGame},
+	{name: "GetGame", run: testGetGame},
+	{name: "GetGames", run: testGetGames},
+	{name: "MakeMove", run: testMakeMove},
+	{name: "ConcurrentMoves", run: testConcurrentMoves},
+	{name: "MoveOrd", run: testMoveOrd},
+	{name: "Idempotency", run: testIdempotency},
+	{name: "ListenSteps", run: testListenSteps},
+	{name: "AnalyzeGame", run: testAnalyzeGame},
+	{name: "GetHint", run: testGetHint},
+	{name: "CheckGames", run: testCheckGames},
+	{name: "CreateGameFromPosition", run: testCreateGameFromPosition},
+	{name: "Puzzles", run: testPuzzles},
+	{name: "OpeningStats", run: testOpeningStats},
+	{name: "Takebacks", run: testTakebacks},
+	{name: "Rematches", run: testRematches},
+	{name: "Chat", run: testChat},
+	{name: "DailyPuzzle", run: testDailyPuzzle},
+	{name: "Admin", run: testAdmin},
+	{name: "AuditLog", run: testAuditLog},
 }
 
 func TestServer(t *testing.T) {
+	t.Run("Postgres", testPostgresServer)
+	t.Run("Sqlite", testSqliteServer)
+	t.Run("Memory", testMemoryServer)
+}
+
+func runServerTests(t *testing.T, args TestArgs) {
+	for _, test := range serverTests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, args)
+		})
+	}
+}
+
+func testPostgresServer(t *testing.T) {
 	ctx := context.Background()
 
 	closer := createEmbeddedDb(t)
@@ -186,38 +391,61 @@ func TestServer(t *testing.T) {
 	}
 	defer pool.Close()
 
-	queries := db.New(pool)
+	conn := stdlib.OpenDBFromPool(pool)
+	defer func() {
+		_ = conn.Close()
+	}()
 
-	client, closer := serve(ctx, t, pool)
+	args := TestArgs{
+		store: NewPgStore(pool),
+		db:    conn,
+		pool:  pool,
+	}
+	client, admin, closer := serve(ctx, t, args)
 	defer closer()
+	args.client = client
+	args.admin = admin
+
+	runServerTests(t, args)
+	// the migrations are kept for postgres alone, and run last as they drop the schema
+	t.Run("Migrations", func(t *testing.T) {
+		testMigrations(t, args)
+	})
+}
+
+func testSqliteServer(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSqliteStore(ctx, ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite store with err: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
 
 	args := TestArgs{
-		pool:    pool,
-		client:  client,
-		queries: queries,
+		store: store,
+		db:    store.DB,
 	}
+	client, admin, closer := serve(ctx, t, args)
+	defer closer()
+	args.client = client
+	args.admin = admin
 
-	t.Run("RegisterAndLogin", func(t *testing.T) {
-		testRegisterAndLogin(t, args)
-	})
-	t.Run("GetPlayers", func(t *testing.T) {
-		testGetPlayers(t, args)
-	})
-	t.Run("CreateGame", func(t *testing.T) {
-		testCreateGame(t, args)
-	})
-	t.Run("GetGame", func(t *testing.T) {
-		testGetGame(t, args)
-	})
-	t.Run("GetGames", func(t *testing.T) {
-		testGetGames(t, args)
-	})
-	t.Run("MakeMove", func(t *testing.T) {
-		testMakeMove(t, args)
-	})
-	t.Run("ListenSteps", func(t *testing.T) {
-		testListenSteps(t, args)
-	})
+	runServerTests(t, args)
+}
+
+func testMemoryServer(t *testing.T) {
+	ctx := context.Background()
+
+	args := TestArgs{store: NewMemStore()}
+	client, admin, closer := serve(ctx, t, args)
+	defer closer()
+	args.client = client
+	args.admin = admin
+
+	runServerTests(t, args)
 }
 
 func testRegisterAndLogin(t *testing.T, args TestArgs) {
@@ -264,7 +492,7 @@ func testRegisterAndLogin(t *testing.T, args TestArgs) {
 				assert.Equal(t, test.expId, player.Id)
 				assert.Equal(t, test.username, player.Username)
 
-				dbPlayer, err := args.queries.GetPlayer(ctx, 6)
+				dbPlayer, err := args.store.GetPlayer(ctx, 6)
 				if err != nil {
 					t.Fatalf("failed to get game for assert: %v", err)
 				}
@@ -367,7 +595,7 @@ func testCreateGame(t *testing.T, args TestArgs) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
 	defer cancel()
 
-	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "User1Token"))
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
 	in := &pb.CreateGameReq{}
 
 	game, err := args.client.CreateGame(ctx, in)
@@ -382,6 +610,7 @@ func testCreateGame(t *testing.T, args TestArgs) {
 			Username: "user1",
 		},
 		BoardState: "_________",
+		StartState: "_________",
 		XTurn:      true,
 		Result:     tictactoe.Playing,
 		Steps:      []*pb.Step{},
@@ -389,7 +618,7 @@ func testCreateGame(t *testing.T, args TestArgs) {
 	diff := cmp.Diff(expectedGame, game, protocmp.Transform(), protocmp.IgnoreFields(expectedGame, "updatedOn", "startedOn"))
 	assert.Equal(t, "", diff)
 
-	dbGame, err := args.queries.GetGame(ctx, 5)
+	dbGame, err := args.store.GetGame(ctx, 5)
 	if err != nil {
 		t.Fatalf("failed to get game for assert: %v", err)
 	}
@@ -399,13 +628,14 @@ func testCreateGame(t *testing.T, args TestArgs) {
 		XPlayer:     1,
 		OPlayer:     pgtype.Int8{},
 		BoardState:  "_________",
+		StartState:  "_________",
 		XTurn:       pgtype.Bool{Bool: true, Valid: true},
 		Result:      tictactoe.Playing,
 		XPlayerName: pgtype.Text{String: "user1", Valid: true},
 		OPlayerName: pgtype.Text{String: "", Valid: false},
 	}
 
-	diff = cmp.Diff(expectedDbGame, dbGame, cmpopts.IgnoreFields(dbGame, "updatedOn", "startedOn"))
+	diff = cmp.Diff(expectedDbGame, dbGame, cmpopts.IgnoreFields(dbGame, "UpdatedOn", "StartedOn"))
 	assert.Equal(t, "", diff)
 }
 
@@ -465,6 +695,185 @@ func testGetGames(t *testing.T, args TestArgs) {
 	}
 }
 
+func testConcurrentMoves(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+
+	// x races a move on every square, only one can be stored and the rest are turned away, either by the
+	// version or, when they read the game after the first move, because it is no longer x's turn
+	for round := 0; round < 10; round++ {
+		gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+			XPlayer:    1,
+			OPlayer:    pgtype.Int8{Int64: 3, Valid: true},
+			BoardState: "_________",
+			StartState: "_________",
+			XTurn:      pgtype.Bool{Bool: true, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert game: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		codesCh := make(chan codes.Code, 9)
+		for i := 0; i < 9; i++ {
+			wg.Add(1)
+			go func(row, col int32) {
+				defer wg.Done()
+				_, err := args.client.MakeMove(ctx, &pb.MakeMoveReq{GameId: gameId, Row: row, Col: col})
+				codesCh <- status.Code(err)
+			}(int32(i/3), int32(i%3))
+		}
+		wg.Wait()
+		close(codesCh)
+
+		moved := 0
+		for code := range codesCh {
+			switch code {
+			case codes.OK:
+				moved++
+			case codes.Aborted, codes.PermissionDenied:
+			default:
+				t.Errorf("unexpected code for concurrent move: %v", code)
+			}
+		}
+		assert.Equal(t, 1, moved)
+
+		game, err := args.store.GetGame(ctx, gameId)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, strings.Count(game.BoardState, "x"))
+		assert.Equal(t, int32(1), game.Version)
+		steps, err := args.store.GetGameSteps(ctx, gameId)
+		assert.Nil(t, err)
+		assert.Len(t, steps, 1)
+	}
+}
+
+func testIdempotency(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	withKey := func(token string, key string) context.Context {
+		return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", token, IdempotencyKeyHeader, key))
+	}
+	countGames := func() int {
+		stats, err := args.store.GetServerStats(ctx, pgtype.Timestamptz{Time: time.Now(), Valid: true})
+		if err != nil {
+			t.Fatalf("failed to count games: %v", err)
+		}
+		return int(stats.Games)
+	}
+
+	// a retried create returns the first game instead of creating another
+	games := countGames()
+	first, err := args.client.CreateGame(withKey(user1Token, "create-1"), &pb.CreateGameReq{HintBudget: 1})
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	retry, err := args.client.CreateGame(withKey(user1Token, "create-1"), &pb.CreateGameReq{HintBudget: 1})
+	assert.Nil(t, err)
+	diff := cmp.Diff(first, retry, protocmp.Transform())
+	assert.Equal(t, "", diff)
+	assert.Equal(t, games+1, countGames())
+
+	// keys belong to a player, so another player's key does not collide
+	other, err := args.client.CreateGame(withKey(user3Token, "create-1"), &pb.CreateGameReq{HintBudget: 1})
+	assert.Nil(t, err)
+	assert.NotEqual(t, first.Id, other.Id)
+
+	type Test struct {
+		ctx     context.Context
+		in      *pb.MakeMoveReq
+		expCode codes.Code
+	}
+
+	tests := []Test{
+		{ctx: withKey(user1Token, "create-1"), in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1}, expCode: codes.FailedPrecondition},
+		{ctx: withKey(user1Token, strings.Repeat("k", MaxIdempotencyKeyLen+1)), in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1}, expCode: codes.InvalidArgument},
+		{ctx: withKey(user1Token, "move-1"), in: &pb.MakeMoveReq{GameId: 1, Row: 0, Col: 0}, expCode: codes.InvalidArgument},
+		{ctx: withKey(user1Token, "move-1"), in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1}},
+		{ctx: withKey(user1Token, "move-1"), in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1}},
+		{ctx: withKey(user1Token, "move-1"), in: &pb.MakeMoveReq{GameId: 1, Row: 2, Col: 2}, expCode: codes.FailedPrecondition},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			game, err := args.client.MakeMove(test.ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, "x_o_x____", game.BoardState)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	// the failed move released its key, and the replayed move was only stored once
+	steps, err := args.store.GetGameSteps(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, steps, 3)
+}
+
+func testMoveOrd(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	xCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	oCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: 2, Username: "user2"})))
+
+	type Test struct {
+		ctx      context.Context
+		in       *pb.MakeMoveReq
+		expBoard string
+		expCode  codes.Code
+	}
+
+	// game 1 has steps 0 and 1, so the next move gets ord 2
+	tests := []Test{
+		{ctx: xCtx, in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1, Ord: proto.Int32(1)}, expBoard: "x_o______", expCode: codes.FailedPrecondition},
+		{ctx: xCtx, in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1, Ord: proto.Int32(3)}, expBoard: "x_o______", expCode: codes.FailedPrecondition},
+		{ctx: xCtx, in: &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1, Ord: proto.Int32(2)}, expBoard: "x_o_x____"},
+		{ctx: oCtx, in: &pb.MakeMoveReq{GameId: 1, Row: 2, Col: 2, Ord: proto.Int32(2)}, expBoard: "x_o_x____", expCode: codes.FailedPrecondition},
+		{ctx: oCtx, in: &pb.MakeMoveReq{GameId: 1, Row: 2, Col: 2}, expBoard: "x_o_x___o"},
+		// a game without steps expects ord 0
+		{ctx: xCtx, in: &pb.MakeMoveReq{GameId: 2, Row: 0, Col: 0, Ord: proto.Int32(1)}, expBoard: "_________", expCode: codes.FailedPrecondition},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			game, err := args.client.MakeMove(test.ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expBoard, game.BoardState)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+
+				var current *pb.Step
+				for _, detail := range s.Details() {
+					if step, ok := detail.(*pb.Step); ok {
+						current = step
+					}
+				}
+				if assert.NotNil(t, current) {
+					assert.Equal(t, test.expBoard, current.Board)
+				}
+			}
+		})
+	}
+}
+
 func testListenSteps(t *testing.T, args TestArgs) {
 	seedTestData(args)
 
@@ -530,38 +939,44 @@ func testMakeMove(t *testing.T, args TestArgs) {
 
 	tests := []Test{
 		{
-			md:      metadata.Pairs("authorization", "User1Token"),
+			md:      metadata.Pairs("authorization", user1Token),
 			in:      &pb.MakeMoveReq{GameId: 1, Row: 0, Col: 0}, // illegal move
 			expCode: codes.InvalidArgument,
 		},
 		{
 			md:      metadata.Pairs("authorization", "InvalidToken"),
 			in:      &pb.MakeMoveReq{GameId: 1, Row: 0, Col: 0}, // invalid token
-			expCode: codes.PermissionDenied,
+			expCode: codes.Unauthenticated,
 		},
 		{
-			md:      metadata.Pairs("authorization", "User1Token"),
+			md:      metadata.MD{},
+			in:      &pb.MakeMoveReq{GameId: 1, Row: 0, Col: 0}, // missing token
+			expCode: codes.Unauthenticated,
+		},
+		{
+			md:      metadata.Pairs("authorization", user1Token),
 			in:      &pb.MakeMoveReq{GameId: 2, Row: 0, Col: 0}, // not player's turn
 			expCode: codes.PermissionDenied,
 		},
 		{
-			md:      metadata.Pairs("authorization", "User1Token"),
+			md:      metadata.Pairs("authorization", user1Token),
 			in:      &pb.MakeMoveReq{GameId: 3, Row: 0, Col: 0}, // cannot make move on game not in play
 			expCode: codes.PermissionDenied,
 		},
 		{
-			md:      metadata.Pairs("authorization", "User1Token"),
+			md:      metadata.Pairs("authorization", user1Token),
 			in:      &pb.MakeMoveReq{GameId: 4, Row: 0, Col: 0}, // cannot make move on game that is not started
 			expCode: codes.PermissionDenied,
 		},
 		{
-			md: metadata.Pairs("authorization", "User1Token"),
+			md: metadata.Pairs("authorization", user1Token),
 			in: &pb.MakeMoveReq{GameId: 1, Row: 0, Col: 1}, // success case
 			expGame: &pb.Game{
 				Id:         1,
 				XPlayer:    &pb.Player{Id: 1, Username: "user1"},
 				OPlayer:    &pb.Player{Id: 2, Username: "user2"},
 				BoardState: "xxo______",
+				StartState: "_________",
 				XTurn:      false,
 				Result:     tictactoe.Playing,
 				Steps:      []*pb.Step{},
@@ -571,8 +986,10 @@ func testMakeMove(t *testing.T, args TestArgs) {
 				XPlayer:     1,
 				OPlayer:     pgtype.Int8{Int64: 2, Valid: true},
 				BoardState:  "xxo______",
+				StartState:  "_________",
 				XTurn:       pgtype.Bool{Bool: false, Valid: true},
 				Result:      tictactoe.Playing,
+				Version:     1,
 				XPlayerName: pgtype.Text{String: "user1", Valid: true},
 				OPlayerName: pgtype.Text{String: "user2", Valid: true},
 			},
@@ -599,7 +1016,7 @@ func testMakeMove(t *testing.T, args TestArgs) {
 				assert.Equal(t, test.expCode, s.Code())
 			}
 			if test.expDbGame != nil {
-				dbGame, err := args.queries.GetGame(ctx, test.in.GameId)
+				dbGame, err := args.store.GetGame(ctx, test.in.GameId)
 				if err != nil {
 					t.Fatalf("failed to get game for assert: %v", err)
 				}
@@ -610,3 +1027,2255 @@ func testMakeMove(t *testing.T, args TestArgs) {
 		})
 	}
 }
+
+func testAnalyzeGame(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	// play out a finished game where o blunders on the first move
+	gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+		XPlayer:    1,
+		OPlayer:    pgtype.Int8{Int64: 2, Valid: true},
+		BoardState: "xxxoo____",
+		StartState: "_________",
+		XTurn:      pgtype.Bool{Bool: false, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert game: %v", err)
+	}
+	moves := []tictactoe.Tile{{Row: 0, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: 1}, {Row: 1, Col: 1}, {Row: 0, Col: 2}}
+	for i, move := range moves {
+		result := tictactoe.Playing
+		if i == len(moves)-1 {
+			result = tictactoe.XWon
+		}
+		_, err = args.store.InsertStep(ctx, db.InsertStepParams{GameID: gameId, MoveRow: move.Row, MoveCol: move.Col, XTurn: i%2 == 1, Result: result})
+		if err != nil {
+			t.Fatalf("failed to insert step: %v", err)
+		}
+	}
+	_, err = args.store.UpdateGame(ctx, db.UpdateGameParams{ID: gameId, BoardState: "xxxoo____", Result: tictactoe.XWon})
+	if err != nil {
+		t.Fatalf("failed to update game: %v", err)
+	}
+
+	expAnalysis := &pb.GameAnalysis{
+		GameId: gameId,
+		Moves: []*pb.MoveAnalysis{
+			{Ord: 0, MoveRow: 0, MoveCol: 0, XMoved: true, Best: &pb.Evaluation{Result: tictactoe.Draw, Distance: 9}, Played: &pb.Evaluation{Result: tictactoe.Draw, Distance: 9}, Annotation: tictactoe.BestMove},
+			{Ord: 1, MoveRow: 1, MoveCol: 0, XMoved: false, Best: &pb.Evaluation{Result: tictactoe.Draw, Distance: 8}, Played: &pb.Evaluation{Result: tictactoe.XWon, Distance: 6}, Annotation: tictactoe.Blunder},
+			{Ord: 2, MoveRow: 0, MoveCol: 1, XMoved: true, Best: &pb.Evaluation{Result: tictactoe.XWon, Distance: 5}, Played: &pb.Evaluation{Result: tictactoe.XWon, Distance: 5}, Annotation: tictactoe.BestMove},
+			{Ord: 3, MoveRow: 1, MoveCol: 1, XMoved: false, Best: &pb.Evaluation{Result: tictactoe.XWon, Distance: 4}, Played: &pb.Evaluation{Result: tictactoe.XWon, Distance: 2}, Annotation: tictactoe.Inaccuracy},
+			{Ord: 4, MoveRow: 0, MoveCol: 2, XMoved: true, Best: &pb.Evaluation{Result: tictactoe.XWon, Distance: 1}, Played: &pb.Evaluation{Result: tictactoe.XWon, Distance: 1}, Annotation: tictactoe.BestMove},
+		},
+		XAccuracy: 100,
+		OAccuracy: 0,
+	}
+
+	// the second call is served from the stored analysis
+	for i := 0; i < 2; i++ {
+		analysis, err := args.client.AnalyzeGame(ctx, &pb.AnalyzeGameReq{GameId: gameId})
+		if err != nil {
+			t.Fatalf("failed to analyze game: %v", err)
+		}
+
+		diff := cmp.Diff(expAnalysis, analysis, protocmp.Transform())
+		assert.Equal(t, "", diff)
+	}
+
+	rows, err := args.store.GetAnalyses(ctx, gameId)
+	if err != nil {
+		t.Fatalf("failed to get analyses for assert: %v", err)
+	}
+	assert.Equal(t, len(moves), len(rows))
+
+	type Test struct {
+		gameId  int64
+		expCode codes.Code
+	}
+
+	tests := []Test{
+		{gameId: 1, expCode: codes.FailedPrecondition},
+		{gameId: 100, expCode: codes.NotFound},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			_, err := args.client.AnalyzeGame(ctx, &pb.AnalyzeGameReq{GameId: test.gameId})
+			s, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, test.expCode, s.Code())
+		})
+	}
+}
+
+func testGetHint(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+		XPlayer:    1,
+		OPlayer:    pgtype.Int8{Int64: 2, Valid: true},
+		BoardState: "xx_oo____",
+		XTurn:      pgtype.Bool{Bool: true, Valid: true},
+		HintBudget: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert game: %v", err)
+	}
+
+	type Test struct {
+		md      metadata.MD
+		in      *pb.GetHintReq
+		expHint *pb.Hint
+		expCode codes.Code
+	}
+
+	tests := []Test{
+		{
+			md:      metadata.Pairs("authorization", user1Token),
+			in:      &pb.GetHintReq{GameId: 1}, // hints are disabled
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      metadata.Pairs("authorization", user3Token),
+			in:      &pb.GetHintReq{GameId: gameId}, // not player's turn
+			expCode: codes.PermissionDenied,
+		},
+		{
+			md: metadata.Pairs("authorization", user1Token),
+			in: &pb.GetHintReq{GameId: gameId}, // success case
+			expHint: &pb.Hint{
+				GameId:     gameId,
+				Moves:      []*pb.HintMove{{Row: 0, Col: 2, Outcome: &pb.Evaluation{Result: tictactoe.XWon, Distance: 1}}},
+				HintsUsed:  1,
+				HintBudget: 1,
+			},
+		},
+		{
+			md:      metadata.Pairs("authorization", user1Token),
+			in:      &pb.GetHintReq{GameId: gameId}, // hint budget is used up
+			expCode: codes.ResourceExhausted,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(ctx, test.md)
+
+			hint, err := args.client.GetHint(ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expHint, hint, protocmp.Transform())
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	dbGame, err := args.store.GetGame(ctx, gameId)
+	if err != nil {
+		t.Fatalf("failed to get game for assert: %v", err)
+	}
+	assert.Equal(t, int32(1), dbGame.XHintsUsed)
+	assert.Equal(t, int32(0), dbGame.OHintsUsed)
+}
+
+func testCheckGames(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	// o cannot have more marks than x
+	gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+		XPlayer:    1,
+		OPlayer:    pgtype.Int8{Int64: 2, Valid: true},
+		BoardState: "oo_______",
+		XTurn:      pgtype.Bool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert game: %v", err)
+	}
+
+	server := testServer(args)
+	corrupt, err := server.CheckGames(ctx)
+	if err != nil {
+		t.Fatalf("failed to check games: %v", err)
+	}
+	assert.Equal(t, []CorruptGame{{ID: gameId, Reason: tictactoe.ErrMarkCount.Error()}}, corrupt)
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	_, err = args.client.MakeMove(ctx, &pb.MakeMoveReq{GameId: gameId, Row: 2, Col: 2})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.DataLoss, s.Code())
+}
+
+func testCreateGameFromPosition(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	type Test struct {
+		in      *pb.CreateGameReq
+		expGame *pb.Game
+		expCode codes.Code
+	}
+
+	tests := []Test{
+		{
+			in: &pb.CreateGameReq{BoardState: "xo__x____", XTurn: false},
+			expGame: &pb.Game{
+				Id:         5,
+				XPlayer:    &pb.Player{Id: 1, Username: "user1"},
+				BoardState: "xo__x____",
+				StartState: "xo__x____",
+				XTurn:      false,
+				Result:     tictactoe.Playing,
+				Steps:      []*pb.Step{},
+			},
+		},
+		{
+			in:      &pb.CreateGameReq{BoardState: "xo__x____", XTurn: true}, // wrong side to move
+			expCode: codes.InvalidArgument,
+		},
+		{
+			in:      &pb.CreateGameReq{BoardState: "xxxoo____", XTurn: false}, // already decided
+			expCode: codes.InvalidArgument,
+		},
+		{
+			in:      &pb.CreateGameReq{BoardState: "xo__z____", XTurn: false}, // invalid symbol
+			expCode: codes.InvalidArgument,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+			defer cancel()
+
+			ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+
+			game, err := args.client.CreateGame(ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expGame, game, protocmp.Transform(), protocmp.IgnoreFields(&pb.Game{}, "updatedOn", "startedOn"))
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+}
+
+func testPuzzles(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	puzzleId, err := args.store.InsertPuzzle(ctx, db.InsertPuzzleParams{
+		BoardState:  "xo_______",
+		XTurn:       true,
+		Depth:       3,
+		SolutionRow: 1,
+		SolutionCol: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert puzzle: %v", err)
+	}
+
+	puzzle, err := args.client.GetPuzzle(ctx, &pb.GetPuzzleReq{Id: puzzleId})
+	if err != nil {
+		t.Fatalf("failed to get puzzle: %v", err)
+	}
+	expPuzzle := &pb.Puzzle{Id: puzzleId, BoardState: "xo_______", XTurn: true, Depth: 3}
+	assert.Equal(t, "", cmp.Diff(expPuzzle, puzzle, protocmp.Transform()))
+
+	type Test struct {
+		md         metadata.MD
+		in         *pb.SubmitPuzzleMoveReq
+		expAttempt *pb.PuzzleAttempt
+		expCode    codes.Code
+	}
+
+	solution := &pb.Tile{Row: 1, Col: 1}
+	tests := []Test{
+		{
+			md: metadata.Pairs("authorization", user1Token),
+			in: &pb.SubmitPuzzleMoveReq{PuzzleId: puzzleId, Row: 1, Col: 1},
+			expAttempt: &pb.PuzzleAttempt{
+				PuzzleId: puzzleId, BoardState: "xo__x___o", XTurn: true, MovesLeft: 2, Status: tictactoe.PuzzleSolving, Reply: &pb.Tile{Row: 2, Col: 2},
+			},
+		},
+		{
+			md: metadata.Pairs("authorization", user1Token),
+			in: &pb.SubmitPuzzleMoveReq{PuzzleId: puzzleId, Row: 1, Col: 0},
+			expAttempt: &pb.PuzzleAttempt{
+				PuzzleId: puzzleId, BoardState: "xooxx___o", XTurn: true, MovesLeft: 1, Status: tictactoe.PuzzleSolving, Reply: &pb.Tile{Row: 0, Col: 2},
+			},
+		},
+		{
+			md: metadata.Pairs("authorization", user1Token),
+			in: &pb.SubmitPuzzleMoveReq{PuzzleId: puzzleId, Row: 1, Col: 2},
+			expAttempt: &pb.PuzzleAttempt{
+				PuzzleId: puzzleId, BoardState: "xooxxx__o", XTurn: false, MovesLeft: 0, Status: tictactoe.PuzzleSolved, Solution: solution, Streak: 1, BestStreak: 1,
+			},
+		},
+		{
+			md:      metadata.Pairs("authorization", user1Token),
+			in:      &pb.SubmitPuzzleMoveReq{PuzzleId: puzzleId, Row: 2, Col: 0}, // attempt is already over
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md: metadata.Pairs("authorization", user3Token),
+			in: &pb.SubmitPuzzleMoveReq{PuzzleId: puzzleId, Row: 2, Col: 2},
+			expAttempt: &pb.PuzzleAttempt{
+				PuzzleId: puzzleId, BoardState: "xo______x", XTurn: false, MovesLeft: 2, Status: tictactoe.PuzzleFailed, Solution: solution,
+			},
+		},
+		{
+			md:      metadata.Pairs("authorization", user1Token),
+			in:      &pb.SubmitPuzzleMoveReq{PuzzleId: 100, Row: 0, Col: 0}, // puzzle does not exist
+			expCode: codes.NotFound,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(ctx, test.md)
+
+			attempt, err := args.client.SubmitPuzzleMove(ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expAttempt, attempt, protocmp.Transform())
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+}
+
+func testDailyPuzzle(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+
+	// every fetch on the same day returns the same scheduled puzzle
+	dailyPuzzle, err := args.client.GetDailyPuzzle(ctx, &pb.GetDailyPuzzleReq{})
+	if err != nil {
+		t.Fatalf("failed to get daily puzzle: %v", err)
+	}
+	again, err := args.client.GetDailyPuzzle(ctx, &pb.GetDailyPuzzleReq{})
+	if err != nil {
+		t.Fatalf("failed to get daily puzzle: %v", err)
+	}
+	assert.Equal(t, "", cmp.Diff(dailyPuzzle, again, protocmp.Transform()))
+	assert.Equal(t, time.Now().UTC().Format(DayLayout), dailyPuzzle.Day)
+
+	puzzle := dailyPuzzle.Puzzle
+	board, err := tictactoe.ParseBoard(puzzle.BoardState)
+	if err != nil {
+		t.Fatalf("failed to parse board: %v", err)
+	}
+	assert.Nil(t, tictactoe.ValidatePuzzle(board, puzzle.XTurn, puzzle.Depth))
+
+	// play the solver's moves until the puzzle is solved
+	xTurn := puzzle.XTurn
+	for i := int32(0); i < puzzle.Depth; i++ {
+		tile := tictactoe.Solve(board, xTurn).Moves[0]
+		attempt, err := args.client.SubmitPuzzleMove(ctx, &pb.SubmitPuzzleMoveReq{PuzzleId: puzzle.Id, Row: tile.Row, Col: tile.Col})
+		if err != nil {
+			t.Fatalf("failed to submit puzzle move: %v", err)
+		}
+		if attempt.Status == tictactoe.PuzzleSolved {
+			break
+		}
+		assert.Equal(t, tictactoe.PuzzleSolving, attempt.Status)
+
+		board, err = tictactoe.ParseBoard(attempt.BoardState)
+		if err != nil {
+			t.Fatalf("failed to parse board: %v", err)
+		}
+		xTurn = attempt.XTurn
+	}
+
+	leaderboard, err := args.client.GetDailyLeaderboard(ctx, &pb.GetDailyLeaderboardReq{})
+	if err != nil {
+		t.Fatalf("failed to get daily leaderboard: %v", err)
+	}
+	assert.Equal(t, dailyPuzzle.Day, leaderboard.Day)
+	assert.Equal(t, 1, len(leaderboard.Entries))
+	expEntry := &pb.LeaderboardEntry{Rank: 1, Player: &pb.Player{Id: 1, Username: "user1"}}
+	diff := cmp.Diff(expEntry, leaderboard.Entries[0], protocmp.Transform(), protocmp.IgnoreFields(&pb.LeaderboardEntry{}, "durationMs"))
+	assert.Equal(t, "", diff)
+
+	_, err = args.client.GetDailyLeaderboard(ctx, &pb.GetDailyLeaderboardReq{Day: "yesterday"})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+}
+
+func testOpeningStats(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	insertGame := func(xPlayer, oPlayer int64, boardState string, xTurn bool) int64 {
+		gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+			XPlayer:    xPlayer,
+			OPlayer:    pgtype.Int8{Int64: oPlayer, Valid: true},
+			BoardState: boardState,
+			StartState: "_________",
+			XTurn:      pgtype.Bool{Bool: xTurn, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert game: %v", err)
+		}
+		return gameId
+	}
+	finishGame := func(gameId int64, boardState string, result, opening int32) {
+		_, err := args.store.UpdateGame(ctx, db.UpdateGameParams{
+			ID:         gameId,
+			BoardState: boardState,
+			Result:     result,
+			Opening:    pgtype.Int4{Int32: opening, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("failed to update game: %v", err)
+		}
+	}
+
+	playingId := insertGame(3, 1, "x________", false)
+	finishGame(insertGame(1, 2, "_________", true), "xxxoo____", tictactoe.XWon, tictactoe.OpeningCornerCenter)
+	finishGame(insertGame(2, 1, "_________", true), "xxx_oo___", tictactoe.XWon, tictactoe.OpeningEdgeCenter)
+
+	// the opening is named once o replies to x's first move
+	moveCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	game, err := args.client.MakeMove(moveCtx, &pb.MakeMoveReq{GameId: playingId, Row: 1, Col: 1})
+	if err != nil {
+		t.Fatalf("failed to make move: %v", err)
+	}
+	cornerCenter := &pb.Opening{Id: tictactoe.OpeningCornerCenter, Name: "center reply to corner", Family: tictactoe.FamilyCorner}
+	edgeCenter := &pb.Opening{Id: tictactoe.OpeningEdgeCenter, Name: "center reply to edge", Family: tictactoe.FamilyEdge}
+	assert.Equal(t, "", cmp.Diff(cornerCenter, game.Opening, protocmp.Transform()))
+
+	type Test struct {
+		in       *pb.GetOpeningStatsReq
+		expStats *pb.OpeningStats
+	}
+
+	tests := []Test{
+		{
+			in: &pb.GetOpeningStatsReq{}, // wins and losses for x
+			expStats: &pb.OpeningStats{
+				Games: 3,
+				Stats: []*pb.OpeningStat{
+					{Opening: cornerCenter, Games: 2, Wins: 1, Frequency: float32(2) / 3, WinRate: 1},
+					{Opening: edgeCenter, Games: 1, Wins: 1, Frequency: float32(1) / 3, WinRate: 1},
+				},
+			},
+		},
+		{
+			in: &pb.GetOpeningStatsReq{Player: &pb.Player{Id: 1}}, // wins and losses for the player
+			expStats: &pb.OpeningStats{
+				Games: 3,
+				Stats: []*pb.OpeningStat{
+					{Opening: cornerCenter, Games: 2, Wins: 1, Frequency: float32(2) / 3, WinRate: 1},
+					{Opening: edgeCenter, Games: 1, Losses: 1, Frequency: float32(1) / 3, LossRate: 1},
+				},
+			},
+		},
+		{
+			in: &pb.GetOpeningStatsReq{Player: &pb.Player{Id: 3}}, // only unfinished games
+			expStats: &pb.OpeningStats{
+				Games: 1,
+				Stats: []*pb.OpeningStat{{Opening: cornerCenter, Games: 1, Frequency: 1}},
+			},
+		},
+		{
+			in:       &pb.GetOpeningStatsReq{Player: &pb.Player{Id: 5}}, // no games
+			expStats: &pb.OpeningStats{},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			stats, err := args.client.GetOpeningStats(ctx, test.in)
+			assert.Nil(t, err)
+
+			diff := cmp.Diff(test.expStats, stats, protocmp.Transform())
+			assert.Equal(t, "", diff)
+		})
+	}
+}
+
+func testTakebacks(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	insertGame := func(takebacksDisabled bool) int64 {
+		gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+			XPlayer:           1,
+			OPlayer:           pgtype.Int8{Int64: 3, Valid: true},
+			BoardState:        "_________",
+			StartState:        "_________",
+			XTurn:             pgtype.Bool{Bool: true, Valid: true},
+			TakebacksDisabled: takebacksDisabled,
+		})
+		if err != nil {
+			t.Fatalf("failed to insert game: %v", err)
+		}
+		return gameId
+	}
+	gameId := insertGame(false)
+	disabledId := insertGame(true)
+
+	user1 := metadata.Pairs("authorization", user1Token)
+	user3 := metadata.Pairs("authorization", user3Token)
+	for _, move := range []struct {
+		md     metadata.MD
+		gameId int64
+		row    int32
+		col    int32
+	}{{user1, gameId, 0, 0}, {user3, gameId, 1, 1}, {user1, gameId, 0, 1}, {user1, disabledId, 0, 0}} {
+		_, err := args.client.MakeMove(metadata.NewOutgoingContext(ctx, move.md), &pb.MakeMoveReq{GameId: move.gameId, Row: move.row, Col: move.col})
+		if err != nil {
+			t.Fatalf("failed to make move: %v", err)
+		}
+	}
+
+	xPlayer := &pb.Player{Id: 1, Username: "user1"}
+	oPlayer := &pb.Player{Id: 3, Username: "user3"}
+	cornerCenter := &pb.Opening{Id: tictactoe.OpeningCornerCenter, Name: "center reply to corner", Family: tictactoe.FamilyCorner}
+
+	type Test struct {
+		md       metadata.MD
+		gameId   int64
+		respond  bool
+		accept   bool
+		expGame  *pb.Game
+		expSteps int
+		expCode  codes.Code
+	}
+
+	tests := []Test{
+		{
+			md:      user3,
+			gameId:  gameId,
+			respond: true, // no takeback was requested
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:     user1,
+			gameId: gameId, // request takeback of x's last move
+			expGame: &pb.Game{
+				Id: gameId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "xx__o____", StartState: "_________",
+				XTurn: false, Opening: cornerCenter, TakebackBy: 1,
+			},
+			expSteps: 3,
+		},
+		{
+			md:      user1,
+			gameId:  gameId, // a takeback is already pending
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      user1,
+			gameId:  gameId,
+			respond: true, // cannot respond to own takeback
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      user3,
+			gameId:  gameId,
+			respond: true,
+			accept:  true, // accepting restores the position before x's move
+			expGame: &pb.Game{
+				Id: gameId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "x___o____", StartState: "_________",
+				XTurn: true, Opening: cornerCenter,
+			},
+		},
+		{
+			md:     user3,
+			gameId: gameId, // request takeback of o's last move
+			expGame: &pb.Game{
+				Id: gameId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "x___o____", StartState: "_________",
+				XTurn: true, Opening: cornerCenter, TakebackBy: 3,
+			},
+			expSteps: 2,
+		},
+		{
+			md:      user1,
+			gameId:  gameId,
+			respond: true, // declining keeps the position
+			expGame: &pb.Game{
+				Id: gameId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "x___o____", StartState: "_________",
+				XTurn: true, Opening: cornerCenter,
+			},
+			expSteps: 2,
+		},
+		{
+			md:      user1,
+			gameId:  disabledId, // takebacks are disabled
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      user3,
+			gameId:  1, // not a player of the game
+			expCode: codes.PermissionDenied,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(ctx, test.md)
+
+			var game *pb.Game
+			var err error
+			if test.respond {
+				game, err = args.client.RespondTakeback(ctx, &pb.RespondTakebackReq{GameId: test.gameId, Accept: test.accept})
+			} else {
+				game, err = args.client.RequestTakeback(ctx, &pb.RequestTakebackReq{GameId: test.gameId})
+			}
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expGame, game, protocmp.Transform(), protocmp.IgnoreFields(&pb.Game{}, "updatedOn", "startedOn", "steps"))
+				assert.Equal(t, "", diff)
+				if test.expSteps != 0 {
+					assert.Equal(t, test.expSteps, len(game.Steps))
+				}
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	stepRows, err := args.store.GetGameSteps(ctx, gameId)
+	if err != nil {
+		t.Fatalf("failed to get steps for assert: %v", err)
+	}
+	assert.Equal(t, 2, len(stepRows))
+	assert.Equal(t, "x___o____", stepRows[len(stepRows)-1].Board)
+}
+
+func testRematches(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	insertGame := func() int64 {
+		gameId, err := args.store.InsertGame(ctx, db.InsertGameParams{
+			XPlayer:    1,
+			OPlayer:    pgtype.Int8{Int64: 3, Valid: true},
+			BoardState: "_________",
+			StartState: "_________",
+			XTurn:      pgtype.Bool{Bool: true, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert game: %v", err)
+		}
+		return gameId
+	}
+	finishedId := insertGame()
+	playingId := insertGame()
+	_, err := args.store.UpdateGame(ctx, db.UpdateGameParams{ID: finishedId, BoardState: "xxxoo____", Result: tictactoe.XWon})
+	if err != nil {
+		t.Fatalf("failed to update game: %v", err)
+	}
+	for i, board := range []string{"x________", "x__o_____", "xx_o_____", "xx_oo____", "xxxoo____"} {
+		_, err = args.store.InsertStep(ctx, db.InsertStepParams{GameID: finishedId, MoveRow: int32(i % 2), MoveCol: int32(i / 2), Board: board, XTurn: i%2 == 1})
+		if err != nil {
+			t.Fatalf("failed to insert step: %v", err)
+		}
+	}
+
+	user1 := metadata.Pairs("authorization", user1Token)
+	user3 := metadata.Pairs("authorization", user3Token)
+	xPlayer := &pb.Player{Id: 1, Username: "user1"}
+	oPlayer := &pb.Player{Id: 3, Username: "user3"}
+
+	type Test struct {
+		md       metadata.MD
+		gameId   int64
+		respond  bool
+		accept   bool
+		expGame  *pb.Game
+		expSteps int
+		expCode  codes.Code
+	}
+
+	tests := []Test{
+		{
+			md:      user3,
+			gameId:  playingId, // game is not finished
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      user3,
+			gameId:  1, // not a player of the game
+			expCode: codes.PermissionDenied,
+		},
+		{
+			md:     user1,
+			gameId: finishedId, // offer a rematch
+			expGame: &pb.Game{
+				Id: finishedId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "xxxoo____", StartState: "_________",
+				Result: tictactoe.XWon, RematchBy: 1,
+			},
+			expSteps: 5,
+		},
+		{
+			md:      user3,
+			gameId:  finishedId,
+			respond: true, // declining keeps the game as it was
+			expGame: &pb.Game{
+				Id: finishedId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "xxxoo____", StartState: "_________",
+				Result: tictactoe.XWon,
+			},
+			expSteps: 5,
+		},
+		{
+			md:     user1,
+			gameId: finishedId, // offer a rematch again
+			expGame: &pb.Game{
+				Id: finishedId, XPlayer: xPlayer, OPlayer: oPlayer, BoardState: "xxxoo____", StartState: "_________",
+				Result: tictactoe.XWon, RematchBy: 1,
+			},
+			expSteps: 5,
+		},
+		{
+			md:      user1,
+			gameId:  finishedId,
+			respond: true, // cannot respond to own offer
+			expCode: codes.FailedPrecondition,
+		},
+		{
+			md:      user3,
+			gameId:  finishedId,
+			respond: true,
+			accept:  true, // accepting creates a game with colors swapped
+			expGame: &pb.Game{
+				XPlayer: oPlayer, OPlayer: xPlayer, BoardState: "_________", StartState: "_________", XTurn: true,
+				RematchOf: finishedId, Series: &pb.Series{Games: 1, OPlayerWins: 1},
+			},
+		},
+		{
+			md:      user1,
+			gameId:  finishedId, // game was already rematched
+			expCode: codes.AlreadyExists,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(ctx, test.md)
+
+			var game *pb.Game
+			var err error
+			if test.respond {
+				game, err = args.client.RespondRematch(ctx, &pb.RespondRematchReq{GameId: test.gameId, Accept: test.accept})
+			} else {
+				game, err = args.client.OfferRematch(ctx, &pb.OfferRematchReq{GameId: test.gameId})
+			}
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				ignored := []protoreflect.Name{"updatedOn", "startedOn", "steps"}
+				if test.expGame.Id == 0 {
+					ignored = append(ignored, "id")
+				}
+				diff := cmp.Diff(test.expGame, game, protocmp.Transform(), protocmp.IgnoreFields(&pb.Game{}, ignored...))
+				assert.Equal(t, "", diff)
+				if test.expSteps != 0 {
+					assert.Equal(t, test.expSteps, len(game.Steps))
+				}
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+}
+
+func testChat(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	user1 := metadata.Pairs("authorization", user1Token)
+	user3 := metadata.Pairs("authorization", user3Token)
+	player1 := &pb.Player{Id: 1, Username: "user1"}
+	player3 := &pb.Player{Id: 3, Username: "user3"}
+
+	type Test struct {
+		md         metadata.MD
+		in         *pb.SendMessageReq
+		expMessage *pb.Message
+		expCode    codes.Code
+	}
+
+	tests := []Test{
+		{
+			md:         user1,
+			in:         &pb.SendMessageReq{GameId: 1, Text: " good luck "}, // player chat
+			expMessage: &pb.Message{Id: 1, GameId: 1, Player: player1, Channel: ChannelPlayers, Text: "good luck"},
+		},
+		{
+			md:      user3,
+			in:      &pb.SendMessageReq{GameId: 1, Text: "hello"}, // not seated in the game
+			expCode: codes.PermissionDenied,
+		},
+		{
+			md:         user3,
+			in:         &pb.SendMessageReq{GameId: 1, Text: "nice opening", Channel: ChannelSpectators}, // spectator chat
+			expMessage: &pb.Message{Id: 2, GameId: 1, Player: player3, Channel: ChannelSpectators, Text: "nice opening"},
+		},
+		{
+			md:      user1,
+			in:      &pb.SendMessageReq{GameId: 1, Text: "   "}, // blank message
+			expCode: codes.InvalidArgument,
+		},
+		{
+			md:      user1,
+			in:      &pb.SendMessageReq{GameId: 1, Text: strings.Repeat("a", MaxMessageLen+1)}, // too long
+			expCode: codes.InvalidArgument,
+		},
+		{
+			md:      user1,
+			in:      &pb.SendMessageReq{GameId: 1, Text: "what the SHIT"}, // blocked word
+			expCode: codes.InvalidArgument,
+		},
+		{
+			md:      user1,
+			in:      &pb.SendMessageReq{GameId: 1, Text: "hi", Channel: 5}, // unknown channel
+			expCode: codes.InvalidArgument,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(ctx, test.md)
+
+			message, err := args.client.SendMessage(ctx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expMessage, message, protocmp.Transform(), protocmp.IgnoreFields(&pb.Message{}, "sentOn"))
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	// the history only includes the players' chat for the players
+	for _, view := range []struct {
+		md       metadata.MD
+		expTexts []string
+	}{{user1, []string{"good luck", "nice opening"}}, {user3, []string{"nice opening"}}} {
+		game, err := args.client.GetGame(metadata.NewOutgoingContext(ctx, view.md), &pb.GetGameReq{Id: 1})
+		if err != nil {
+			t.Fatalf("failed to get game: %v", err)
+		}
+		var texts []string
+		for _, message := range game.Messages {
+			texts = append(texts, message.Text)
+		}
+		assert.Equal(t, view.expTexts, texts)
+	}
+
+	// the stream sends the history after the given id, then new messages
+	streamCtx, streamCancel := context.WithCancel(metadata.NewOutgoingContext(ctx, user1))
+	defer streamCancel()
+	stream, err := args.client.StreamMessages(streamCtx, &pb.StreamMessagesReq{GameId: 1, Channel: ChannelPlayers})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to recv on stream: %v", err)
+	}
+	assert.Equal(t, "good luck", first.Text)
+
+	// the rate limit counts every message in the window, and one was already sent
+	sendCtx := metadata.NewOutgoingContext(ctx, user1)
+	for i := 1; i < DefaultMessagesPerWindow; i++ {
+		_, err = args.client.SendMessage(sendCtx, &pb.SendMessageReq{GameId: 1, Text: fmt.Sprintf("message %d", i)})
+		assert.Nil(t, err)
+	}
+	_, err = args.client.SendMessage(sendCtx, &pb.SendMessageReq{GameId: 1, Text: "one too many"})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+
+	second, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to recv on stream: %v", err)
+	}
+	assert.Equal(t, "message 1", second.Text)
+
+	// concurrent sends can not go over the limit together, and one was already sent
+	spectateCtx := metadata.NewOutgoingContext(ctx, user3)
+	var wg sync.WaitGroup
+	codesSeen := make([]codes.Code, DefaultMessagesPerWindow*2)
+	for i := range codesSeen {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := args.client.SendMessage(spectateCtx, &pb.SendMessageReq{GameId: 1, Text: "at once", Channel: ChannelSpectators})
+			codesSeen[i] = status.Code(err)
+		}(i)
+	}
+	wg.Wait()
+	sent := 0
+	for _, code := range codesSeen {
+		if code == codes.OK {
+			sent++
+		} else {
+			assert.Equal(t, codes.ResourceExhausted, code)
+		}
+	}
+	assert.Equal(t, DefaultMessagesPerWindow-1, sent)
+}
+
+func testAuthentication(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	type Test struct {
+		md        metadata.MD
+		expPlayer *pb.Player
+		expCode   codes.Code
+	}
+
+	tests := []Test{
+		{md: metadata.Pairs("authorization", user1Token), expPlayer: &pb.Player{Id: 1, Username: "user1"}},
+		{md: metadata.Pairs("authorization", "Bearer "+user1Token), expPlayer: &pb.Player{Id: 1, Username: "user1"}},
+		{md: metadata.Pairs("authorization", "bearer "+user3Token), expPlayer: &pb.Player{Id: 3, Username: "user3"}},
+		{md: metadata.Pairs("authorization", "Bearer InvalidToken"), expCode: codes.Unauthenticated},
+		{md: metadata.Pairs("authorization", user1Token, "authorization", user3Token), expCode: codes.Unauthenticated},
+		{md: metadata.MD{}, expCode: codes.Unauthenticated},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+			defer cancel()
+
+			ctx = metadata.NewOutgoingContext(ctx, test.md)
+
+			player, err := args.client.WhoAmI(ctx, &pb.WhoAmIReq{})
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+
+				diff := cmp.Diff(test.expPlayer, player, protocmp.Transform())
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	// public methods do not need a token, even an invalid one is ignored
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "InvalidToken"))
+	_, err := args.client.GetPlayers(ctx, &pb.GetPlayersReq{Page: 1, PerPage: 10})
+	assert.Nil(t, err)
+}
+
+func testSessions(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	creds := &pb.CredentialsReq{Username: "user6", Password: "password123"}
+	_, err := args.client.Register(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+
+	var logins []*pb.LoginResp
+	for range 3 {
+		resp, err := args.client.Login(ctx, creds)
+		if err != nil {
+			t.Fatalf("failed to login: %v", err)
+		}
+		assert.True(t, resp.ExpiresOn.AsTime().After(time.Now().Add(DefaultAccessTTL-time.Minute)))
+		assert.True(t, resp.RefreshExpiresOn.AsTime().After(time.Now().Add(DefaultSessionTTL-time.Minute)))
+		logins = append(logins, resp)
+	}
+
+	withToken := func(token string) context.Context {
+		return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", token))
+	}
+	assertCode := func(err error, code codes.Code) {
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, code, s.Code())
+	}
+
+	sessions, err := args.client.ListSessions(withToken(logins[0].Token), &pb.ListSessionsReq{})
+	if err != nil {
+		t.Fatalf("failed to list sessions: %v", err)
+	}
+	assert.Equal(t, 3, len(sessions.Sessions))
+	var current []int64
+	for _, session := range sessions.Sessions {
+		if session.Current {
+			current = append(current, session.Id)
+		}
+	}
+	assert.Equal(t, []int64{3}, current) // the seed data holds the first two sessions
+
+	// refreshing rotates the refresh token, so the old one can not be used again
+	refreshed, err := args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: logins[0].RefreshToken})
+	if err != nil {
+		t.Fatalf("failed to refresh token: %v", err)
+	}
+	assert.NotEqual(t, logins[0].RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, "user6", refreshed.Player.Username)
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: logins[0].RefreshToken})
+	assertCode(err, codes.Unauthenticated)
+
+	player, err := args.client.WhoAmI(withToken(refreshed.Token), &pb.WhoAmIReq{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), player.Id)
+
+	// logging out only ends the current session
+	resp, err := args.client.Logout(withToken(refreshed.Token), &pb.LogoutReq{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), resp.Sessions)
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: refreshed.RefreshToken})
+	assertCode(err, codes.Unauthenticated)
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: logins[1].RefreshToken})
+	assert.Nil(t, err)
+
+	// logging out everywhere ends the remaining sessions
+	resp, err = args.client.LogoutAll(withToken(logins[1].Token), &pb.LogoutAllReq{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), resp.Sessions)
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: logins[2].RefreshToken})
+	assertCode(err, codes.Unauthenticated)
+
+	// an expired session can not be refreshed, then is purged
+	expired := pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+	_, err = args.store.RotateSession(ctx, db.RotateSessionParams{
+		ID: 2, TokenHash: HashToken("User3Token"), NewTokenHash: HashToken("User3Token"), LastSeenOn: expired, ExpiresOn: expired,
+	})
+	if err != nil {
+		t.Fatalf("failed to expire session: %v", err)
+	}
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: "User3Token"})
+	assertCode(err, codes.Unauthenticated)
+
+	purged, err := args.store.PurgeExpiredSessions(ctx, pgtype.Timestamptz{Time: time.Now(), Valid: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), purged.RowsAffected())
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: "User1Token"})
+	assert.Nil(t, err)
+}
+
+func testAccounts(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	creds := &pb.CredentialsReq{Username: "user6", Password: "password123"}
+	_, err := args.client.Register(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	current, err := args.client.Login(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	other, err := args.client.Login(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	userCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", current.Token))
+
+	type Test struct {
+		in          *pb.ChangePasswordReq
+		expSessions int64
+		expCode     codes.Code
+	}
+
+	tests := []Test{
+		{in: &pb.ChangePasswordReq{OldPassword: "password-incorrect", NewPassword: "password456"}, expCode: codes.PermissionDenied},
+		{in: &pb.ChangePasswordReq{OldPassword: "password123", NewPassword: "p"}, expCode: codes.InvalidArgument},
+		{in: &pb.ChangePasswordReq{OldPassword: "password123", NewPassword: "password123"}, expCode: codes.InvalidArgument},
+		{in: &pb.ChangePasswordReq{OldPassword: "password123", NewPassword: "password456"}, expSessions: 1},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resp, err := args.client.ChangePassword(userCtx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expSessions, resp.Sessions)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	// the password change ends the other session and keeps the current one
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: other.RefreshToken})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, s.Code())
+
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: current.RefreshToken})
+	assert.Nil(t, err)
+
+	_, err = args.client.Login(ctx, creds)
+	s, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, s.Code())
+
+	creds = &pb.CredentialsReq{Username: "user6", Password: "password456"}
+	_, err = args.client.Login(ctx, creds)
+	assert.Nil(t, err)
+
+	game, err := args.client.CreateGame(userCtx, &pb.CreateGameReq{})
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+
+	// deleting the account needs the password, then anonymizes the player
+	_, err = args.client.DeleteAccount(userCtx, &pb.DeleteAccountReq{Password: "password123"})
+	s, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, s.Code())
+
+	resp, err := args.client.DeleteAccount(userCtx, &pb.DeleteAccountReq{Password: "password456"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), resp.Sessions)
+
+	_, err = args.client.Login(ctx, creds)
+	s, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, s.Code())
+
+	game, err = args.client.GetGame(ctx, &pb.GetGameReq{Id: game.Id})
+	assert.Nil(t, err)
+	diff := cmp.Diff(&pb.Player{Id: 6, Username: DeletedPlayerName}, game.XPlayer, protocmp.Transform())
+	assert.Equal(t, "", diff)
+
+	players, err := args.client.GetPlayers(ctx, &pb.GetPlayersReq{Page: 1, PerPage: 10})
+	assert.Nil(t, err)
+	for _, player := range players.Players {
+		assert.NotEqual(t, int64(6), player.Id)
+	}
+
+	// the whole id is kept in the name, so ids sharing their leading digits do not collide
+	deleted, err := args.store.GetPlayer(ctx, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, "deleted player 0000000000000000006", deleted.Username)
+
+	// the name is free to register again
+	_, err = args.client.Register(ctx, creds)
+	assert.Nil(t, err)
+}
+
+func testLoginLockout(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	_, err := args.client.Register(ctx, &pb.CredentialsReq{Username: "user6", Password: "password123"})
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+
+	assertCode := func(err error, code codes.Code) *status.Status {
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, code, s.Code())
+		return s
+	}
+
+	// unknown usernames and wrong passwords fail alike until the attempts run out, the last one locks out
+	for _, username := range []string{"user6", "user99"} {
+		for range LoginFreeAttempts + 1 {
+			_, err = args.client.Login(ctx, &pb.CredentialsReq{Username: username, Password: "password-incorrect"})
+			assertCode(err, codes.PermissionDenied)
+		}
+	}
+
+	// the lockout holds even for the right password, and is matched regardless of case
+	_, err = args.client.Login(ctx, &pb.CredentialsReq{Username: "USER6", Password: "password123"})
+	s := assertCode(err, codes.ResourceExhausted)
+	var retry *errdetails.RetryInfo
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	if assert.NotNil(t, retry) {
+		assert.Equal(t, LoginBaseLockout, retry.RetryDelay.AsDuration())
+	}
+
+	_, err = args.client.Login(ctx, &pb.CredentialsReq{Username: "user99", Password: "password123"})
+	assertCode(err, codes.ResourceExhausted)
+
+	// once the lockout passes a login succeeds and forgets the failures
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	// the test client's calls come from the bufconn listener
+	for _, subject := range []string{"user:USER6", "user:USER99", "peer:bufconn"} {
+		if err = args.store.LockLogin(ctx, db.LockLoginParams{Subject: subject, LockedUntil: now}); err != nil {
+			t.Fatalf("failed to end lockout: %v", err)
+		}
+	}
+	_, err = args.client.Login(ctx, &pb.CredentialsReq{Username: "user6", Password: "password123"})
+	assert.Nil(t, err)
+
+	// a failure after the login counts from one again
+	failures, err := args.store.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Subject: "user:USER6", FailedOn: now, WindowStart: pgtype.Timestamptz{Time: now.Time.Add(-time.Hour), Valid: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), failures)
+}
+
+func testAdmin(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	creds := &pb.CredentialsReq{Username: "user6", Password: "password123"}
+	registered, err := args.client.Register(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	login, err := args.client.Login(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+
+	playerCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	modCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: 4, Username: "user4"})))
+	adminCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: 5, Username: "user5"})))
+
+	expectCode := func(t *testing.T, err error, code codes.Code) {
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, code, s.Code())
+	}
+
+	// players are denied the admin service, moderators are denied admin methods
+	_, err = args.admin.BanPlayer(playerCtx, &pb.BanPlayerReq{PlayerId: 2, Reason: "spam"})
+	expectCode(t, err, codes.PermissionDenied)
+	_, err = args.admin.GetServerStats(modCtx, &pb.GetServerStatsReq{})
+	expectCode(t, err, codes.PermissionDenied)
+	_, err = args.admin.BanPlayer(ctx, &pb.BanPlayerReq{PlayerId: 2, Reason: "spam"})
+	expectCode(t, err, codes.Unauthenticated)
+
+	type BanTest struct {
+		in      *pb.BanPlayerReq
+		expCode codes.Code
+	}
+
+	banTests := []BanTest{
+		{in: &pb.BanPlayerReq{PlayerId: registered.Id, Reason: ""}, expCode: codes.InvalidArgument},
+		{in: &pb.BanPlayerReq{PlayerId: 5, Reason: "spam"}, expCode: codes.PermissionDenied},
+		{in: &pb.BanPlayerReq{PlayerId: 4, Reason: "spam"}, expCode: codes.PermissionDenied},
+		{in: &pb.BanPlayerReq{PlayerId: 100, Reason: "spam"}, expCode: codes.NotFound},
+		{in: &pb.BanPlayerReq{PlayerId: registered.Id, Reason: "spam"}},
+		{in: &pb.BanPlayerReq{PlayerId: registered.Id, Reason: "spam"}, expCode: codes.FailedPrecondition},
+	}
+
+	for i, test := range banTests {
+		t.Run(fmt.Sprintf("Ban%d", i), func(t *testing.T) {
+			account, err := args.admin.BanPlayer(modCtx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, test.in.PlayerId, account.Player.Id)
+				assert.Equal(t, test.in.Reason, account.BanReason)
+				assert.NotNil(t, account.BannedOn)
+			}
+			if test.expCode != 0 {
+				expectCode(t, err, test.expCode)
+			}
+		})
+	}
+
+	// the banned player can neither refresh nor log in
+	_, err = args.client.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: login.RefreshToken})
+	expectCode(t, err, codes.Unauthenticated)
+	_, err = args.client.Login(ctx, creds)
+	expectCode(t, err, codes.PermissionDenied)
+
+	account, err := args.admin.UnbanPlayer(modCtx, &pb.UnbanPlayerReq{PlayerId: registered.Id})
+	assert.Nil(t, err)
+	assert.Nil(t, account.BannedOn)
+	_, err = args.admin.UnbanPlayer(modCtx, &pb.UnbanPlayerReq{PlayerId: registered.Id})
+	expectCode(t, err, codes.FailedPrecondition)
+	_, err = args.client.Login(ctx, creds)
+	assert.Nil(t, err)
+
+	_, err = args.admin.SetRole(modCtx, &pb.SetRoleReq{PlayerId: registered.Id, Role: RoleModerator})
+	expectCode(t, err, codes.PermissionDenied)
+	_, err = args.admin.SetRole(adminCtx, &pb.SetRoleReq{PlayerId: 5, Role: RolePlayer})
+	expectCode(t, err, codes.FailedPrecondition)
+	_, err = args.admin.SetRole(adminCtx, &pb.SetRoleReq{PlayerId: registered.Id, Role: 3})
+	expectCode(t, err, codes.InvalidArgument)
+	account, err = args.admin.SetRole(adminCtx, &pb.SetRoleReq{PlayerId: registered.Id, Role: RoleModerator})
+	assert.Nil(t, err)
+	assert.Equal(t, RoleModerator, account.Role)
+
+	_, err = args.admin.ForceEndGame(adminCtx, &pb.ForceEndGameReq{GameId: 1, Result: tictactoe.Playing, Reason: "stuck"})
+	expectCode(t, err, codes.InvalidArgument)
+	game, err := args.admin.ForceEndGame(adminCtx, &pb.ForceEndGameReq{GameId: 1, Result: tictactoe.Draw, Reason: "stuck"})
+	assert.Nil(t, err)
+	assert.Equal(t, tictactoe.Draw, game.Result)
+	assert.Equal(t, int64(5), game.EndedBy)
+	_, err = args.admin.ForceEndGame(adminCtx, &pb.ForceEndGameReq{GameId: 1, Result: tictactoe.Draw, Reason: "stuck"})
+	expectCode(t, err, codes.FailedPrecondition)
+
+	// a forced result does not follow from the board, which is not corruption
+	server := testServer(args)
+	corrupt, err := server.CheckGames(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, corrupt)
+
+	message, err := args.client.SendMessage(playerCtx, &pb.SendMessageReq{GameId: 2, Text: "hello"})
+	if err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	deleted, err := args.admin.DeleteMessage(modCtx, &pb.DeleteMessageReq{MessageId: message.Id, Reason: "rude"})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", deleted.Text)
+	_, err = args.admin.DeleteMessage(modCtx, &pb.DeleteMessageReq{MessageId: message.Id, Reason: "rude"})
+	expectCode(t, err, codes.NotFound)
+
+	accounts, err := args.admin.GetRegistrations(modCtx, &pb.GetRegistrationsReq{Page: 1, PerPage: 10})
+	assert.Nil(t, err)
+	assert.Len(t, accounts.Accounts, 6)
+	_, err = args.admin.GetRegistrations(modCtx, &pb.GetRegistrationsReq{Page: 0, PerPage: 10})
+	expectCode(t, err, codes.InvalidArgument)
+
+	stats, err := args.admin.GetServerStats(adminCtx, &pb.GetServerStatsReq{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), stats.Players)
+	assert.Equal(t, int64(0), stats.BannedPlayers)
+	assert.Equal(t, int64(6), stats.Registrations)
+	assert.Equal(t, int64(0), stats.Messages)
+
+	// every successful admin action was audited, failed ones were rolled back with their event
+	auditEvents, err := args.store.QueryAuditLog(ctx, db.QueryAuditLogParams{
+		Until: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+		Limit: 100,
+	})
+	assert.Nil(t, err)
+	var events int
+	for _, event := range auditEvents {
+		if strings.HasPrefix(event.Method, "/service.AdminService/") {
+			events++
+		}
+	}
+	assert.Equal(t, 5, events)
+}
+
+func testAuditLog(t *testing.T, args TestArgs) {
+	seedTestData(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	start := timestamppb.Now()
+	creds := &pb.CredentialsReq{Username: "user6", Password: "password123"}
+	registered, err := args.client.Register(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	_, err = args.client.Login(ctx, &pb.CredentialsReq{Username: "user6", Password: "password-incorrect"})
+	assert.NotNil(t, err)
+	login, err := args.client.Login(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	userCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", login.Token))
+	_, err = args.client.Logout(userCtx, &pb.LogoutReq{})
+	assert.Nil(t, err)
+
+	playerCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	_, err = args.client.MakeMove(playerCtx, &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1})
+	assert.Nil(t, err)
+
+	adminCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: 5, Username: "user5"})))
+	modCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: 4, Username: "user4"})))
+
+	_, err = args.admin.QueryAuditLog(modCtx, &pb.QueryAuditLogReq{Page: 1, PerPage: 10})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, s.Code())
+
+	type Test struct {
+		in         *pb.QueryAuditLogReq
+		expTypes   []string
+		expMethods []string
+		expCode    codes.Code
+	}
+
+	tests := []Test{
+		{
+			in:       &pb.QueryAuditLogReq{ActorId: registered.Id, Page: 1, PerPage: 10},
+			expTypes: []string{AuditLogout, AuditLogin, AuditLoginFailure, AuditRegister},
+			expMethods: []string{
+				pb.TicTacGoService_Logout_FullMethodName,
+				pb.TicTacGoService_Login_FullMethodName,
+				pb.TicTacGoService_Login_FullMethodName,
+				pb.TicTacGoService_Register_FullMethodName,
+			},
+		},
+		{
+			in:         &pb.QueryAuditLogReq{ActorId: registered.Id, Page: 2, PerPage: 3},
+			expTypes:   []string{AuditRegister},
+			expMethods: []string{pb.TicTacGoService_Register_FullMethodName},
+		},
+		{
+			in:         &pb.QueryAuditLogReq{EventType: AuditMove, Since: start, Page: 1, PerPage: 10},
+			expTypes:   []string{AuditMove},
+			expMethods: []string{pb.TicTacGoService_MakeMove_FullMethodName},
+		},
+		{in: &pb.QueryAuditLogReq{Until: start, Page: 1, PerPage: 10}},
+		{in: &pb.QueryAuditLogReq{Since: start, Until: start, Page: 1, PerPage: 10}, expCode: codes.InvalidArgument},
+		{in: &pb.QueryAuditLogReq{Page: 1, PerPage: 0}, expCode: codes.InvalidArgument},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			events, err := args.admin.QueryAuditLog(adminCtx, test.in)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				var types, methods []string
+				for _, event := range events.Events {
+					types = append(types, event.EventType)
+					methods = append(methods, event.Method)
+					assert.NotEmpty(t, event.Peer)
+				}
+				assert.Equal(t, test.expTypes, types)
+				assert.Equal(t, test.expMethods, methods)
+			}
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+			}
+		})
+	}
+
+	// the audit log can not be changed after the fact, the memory store has no way to change it at all
+	if args.db == nil {
+		return
+	}
+	_, err = args.db.ExecContext(ctx, "UPDATE audit_events SET event_type = 'changed'")
+	assert.NotNil(t, err)
+	_, err = args.db.ExecContext(ctx, "DELETE FROM audit_events")
+	assert.NotNil(t, err)
+}
+
+// sqlcModels are the models sqlc generates from the migrations, by their table.
+var sqlcModels = map[string]any{
+	"analyses":         db.Analysis{},
+	"audit_events":     db.AuditEvent{},
+	"daily_puzzles":    db.DailyPuzzle{},
+	"games":            db.Game{},
+	"game_messages":    db.GameMessage{},
+	"game_steps":       db.GameStep{},
+	"idempotency_keys": db.IdempotencyKey{},
+	"login_failures":   db.LoginFailure{},
+	"player_accounts":  db.PlayerAccount{},
+	"player_sessions":  db.PlayerSession{},
+	"puzzles":          db.Puzzle{},
+	"puzzle_attempts":  db.PuzzleAttempt{},
+}
+
+// sqlcTypes are the go types sqlc gives a postgres type, for a not null column and for a nullable one.
+var sqlcTypes = map[string][2]reflect.Type{
+	"int4":        {reflect.TypeOf(int32(0)), reflect.TypeOf(pgtype.Int4{})},
+	"int8":        {reflect.TypeOf(int64(0)), reflect.TypeOf(pgtype.Int8{})},
+	"text":        {reflect.TypeOf(""), reflect.TypeOf(pgtype.Text{})},
+	"bool":        {reflect.TypeOf(false), reflect.TypeOf(pgtype.Bool{})},
+	"timestamptz": {reflect.TypeOf(pgtype.Timestamptz{}), reflect.TypeOf(pgtype.Timestamptz{})},
+	"date":        {reflect.TypeOf(pgtype.Date{}), reflect.TypeOf(pgtype.Date{})},
+	"jsonb":       {reflect.TypeOf([]byte{}), reflect.TypeOf([]byte{})},
+	"bytea":       {reflect.TypeOf([]byte{}), reflect.TypeOf([]byte{})},
+}
+
+// sqlcField is the name sqlc gives the field of a column.
+func sqlcField(column string) string {
+	parts := strings.Split(column, "_")
+	for i, part := range parts {
+		if part == "id" {
+			parts[i] = "ID"
+		} else {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func testMigrations(t *testing.T, args TestArgs) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	_, err := args.pool.Exec(ctx, "DROP SCHEMA public CASCADE; CREATE SCHEMA public;")
+	if err != nil {
+		t.Fatalf("failed to drop schema: %v", err)
+	}
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		t.Fatalf("failed to read migrations: %v", err)
+	}
+	states, err := db.GetMigrationStates(ctx, args.pool)
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), len(states))
+	for _, state := range states {
+		assert.False(t, state.AppliedOn.Valid, "migration %d is applied on an empty database", state.Version)
+	}
+
+	// servers starting together apply each migration once between them
+	var wg sync.WaitGroup
+	applied := make([][]db.Migration, 3)
+	errs := make([]error, len(applied))
+	for i := range applied {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			applied[i], errs[i] = db.MigrateUp(ctx, args.pool)
+		}(i)
+	}
+	wg.Wait()
+	total := 0
+	for i := range applied {
+		assert.Nil(t, errs[i])
+		total += len(applied[i])
+	}
+	assert.Equal(t, len(migrations), total)
+
+	// the migrated tables are the ones sqlc generated its models from
+	assertSqlcSchema(t, ctx, args.pool)
+
+	// reverting every migration leaves only schema_migrations behind
+	reverted, err := db.MigrateDown(ctx, args.pool, len(migrations))
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), len(reverted))
+	var tables int
+	err = args.pool.QueryRow(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'public' AND table_name <> 'schema_migrations'").Scan(&tables)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, tables)
+
+	// a database created before migrations existed has the baseline schema of the first migration, it is taken
+	// to be at it and migrated from there with its rows kept
+	_, err = args.pool.Exec(ctx, migrations[0].Up)
+	if err != nil {
+		t.Fatalf("failed to create the baseline schema: %v", err)
+	}
+	_, err = args.pool.Exec(ctx, `INSERT INTO player_accounts (username, passwd, salt) VALUES ('user1', 'passwd', 'salt');
+INSERT INTO player_sessions (token, player_id) VALUES ('token1', 1);
+INSERT INTO games (x_player, board_state, x_turn, result) VALUES (1, 'X________', FALSE, 0);`)
+	if err != nil {
+		t.Fatalf("failed to fill the baseline schema: %v", err)
+	}
+	upgraded, err := db.MigrateUp(ctx, args.pool)
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations)-1, len(upgraded))
+	states, err = db.GetMigrationStates(ctx, args.pool)
+	assert.Nil(t, err)
+	for _, state := range states {
+		assert.True(t, state.AppliedOn.Valid, "migration %d is pending after migrating up", state.Version)
+	}
+	assertSqlcSchema(t, ctx, args.pool)
+
+	store := NewPgStore(args.pool)
+	session, err := store.GetSession(ctx, HashToken("token1"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), session.ID)
+	game, err := store.GetGame(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "X________", game.BoardState)
+	assert.Equal(t, "_________", game.StartState)
+}
+
+// assertSqlcSchema compares the columns of the tables in the database with the fields of the sqlc models.
+func assertSqlcSchema(t *testing.T, ctx context.Context, pool *pgxpool.Pool) {
+	t.Helper()
+
+	rows, err := pool.Query(ctx, `SELECT table_name::TEXT, column_name::TEXT, udt_name::TEXT, is_nullable = 'YES'
+FROM information_schema.columns WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+ORDER BY table_name, ordinal_position`)
+	if err != nil {
+		t.Fatalf("failed to query columns: %v", err)
+	}
+	type column struct {
+		Table    string
+		Name     string
+		Type     string
+		Nullable bool
+	}
+	columns, err := pgx.CollectRows(rows, pgx.RowToStructByPos[column])
+	if err != nil {
+		t.Fatalf("failed to read columns: %v", err)
+	}
+	migrated := map[string][]string{}
+	for _, c := range columns {
+		types, ok := sqlcTypes[c.Type]
+		if !ok {
+			t.Fatalf("no sqlc type for %s.%s of type %s", c.Table, c.Name, c.Type)
+		}
+		goType := types[0]
+		if c.Nullable {
+			goType = types[1]
+		}
+		migrated[c.Table] = append(migrated[c.Table], fmt.Sprintf("%s %s", sqlcField(c.Name), goType))
+	}
+	generated := map[string][]string{}
+	for table, model := range sqlcModels {
+		modelType := reflect.TypeOf(model)
+		for i := 0; i < modelType.NumField(); i++ {
+			field := modelType.Field(i)
+			generated[table] = append(generated[table], fmt.Sprintf("%s %s", field.Name, field.Type))
+		}
+	}
+	if diff := cmp.Diff(generated, migrated); diff != "" {
+		t.Errorf("migrated schema differs from sqlc's (-sqlc +migrated):\n%s", diff)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	type Test struct {
+		failures   int32
+		expLockout time.Duration
+	}
+
+	tests := []Test{
+		{failures: 1, expLockout: 0},
+		{failures: LoginFreeAttempts, expLockout: 0},
+		{failures: LoginFreeAttempts + 1, expLockout: LoginBaseLockout},
+		{failures: LoginFreeAttempts + 2, expLockout: LoginBaseLockout * 2},
+		{failures: LoginFreeAttempts + 5, expLockout: LoginBaseLockout * 16},
+		{failures: LoginFreeAttempts + 100, expLockout: LoginMaxLockout},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			assert.Equal(t, test.expLockout, LoginLockout(test.failures, LoginFreeAttempts))
+		})
+	}
+}
+
+func TestAccessTokens(t *testing.T) {
+	oldKeys, err := ParseTokenKeys("old:" + strings.Repeat("a", 32))
+	if err != nil {
+		t.Fatalf("failed to parse keys: %v", err)
+	}
+	rotatedKeys, err := ParseTokenKeys("new:" + strings.Repeat("b", 32) + ", old:" + strings.Repeat("a", 32))
+	if err != nil {
+		t.Fatalf("failed to parse keys: %v", err)
+	}
+	assert.Equal(t, "new", rotatedKeys.Current)
+
+	now := time.Now()
+	claims := Claims{ID: 1, Username: "user1", SessionID: 4, ExpiresOn: now.Add(time.Minute).Unix()}
+	oldToken, err := oldKeys.Sign(claims)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	newToken, err := rotatedKeys.Sign(claims)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	parts := strings.Split(oldToken, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":3,"name":"user3","sid":4,"exp":9999999999}`)) + "." + parts[2]
+
+	type Test struct {
+		keys      TokenKeys
+		token     string
+		now       time.Time
+		expClaims Claims
+		expErr    error
+	}
+
+	tests := []Test{
+		{keys: oldKeys, token: oldToken, now: now, expClaims: claims},
+		{keys: rotatedKeys, token: oldToken, now: now, expClaims: claims}, // signed before the rotation
+		{keys: rotatedKeys, token: newToken, now: now, expClaims: claims},
+		{keys: oldKeys, token: newToken, now: now, expErr: ErrInvalidToken}, // unknown key id
+		{keys: oldKeys, token: oldToken, now: now.Add(time.Minute), expErr: ErrExpiredToken},
+		{keys: oldKeys, token: tampered, now: now, expErr: ErrInvalidToken},
+		{keys: oldKeys, token: "User1Token", now: now, expErr: ErrInvalidToken},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			claims, err := test.keys.Verify(test.token, test.now)
+			assert.Equal(t, test.expErr, err)
+			assert.Equal(t, test.expClaims, claims)
+		})
+	}
+
+	_, err = ParseTokenKeys("short:secret")
+	assert.NotNil(t, err)
+	_, err = ParseTokenKeys("")
+	assert.NotNil(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	type Test struct {
+		md       metadata.MD
+		expToken string
+		expErr   bool
+	}
+
+	tests := []Test{
+		{md: metadata.Pairs("authorization", "Bearer abc"), expToken: "abc"},
+		{md: metadata.Pairs("authorization", "BEARER  abc "), expToken: "abc"},
+		{md: metadata.Pairs("authorization", "abc"), expToken: "abc"},
+		{md: metadata.MD{}, expToken: ""},
+		{md: metadata.Pairs("authorization", "abc", "authorization", "def"), expErr: true},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			token, err := BearerToken(metadata.NewIncomingContext(context.Background(), test.md))
+			assert.Equal(t, test.expErr, err != nil)
+			assert.Equal(t, test.expToken, token)
+		})
+	}
+}
+
+func TestFindBlockedWord(t *testing.T) {
+	type Test struct {
+		text     string
+		expWord  string
+		expFound bool
+	}
+
+	tests := []Test{
+		{text: "good game", expFound: false},
+		{text: "well SHIT, again", expWord: "shit", expFound: true},
+		{text: "shitake mushrooms", expFound: false},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			word, found := FindBlockedWord(test.text, DefaultBlockedWords)
+			assert.Equal(t, test.expWord, word)
+			assert.Equal(t, test.expFound, found)
+		})
+	}
+	assert.Equal(t, []string{"darn", "heck"}, ParseBlockedWords(" Darn, heck ,,"))
+	assert.Equal(t, DefaultBlockedWords, ParseBlockedWords(""))
+}
+
+func TestMapSeries(t *testing.T) {
+	gameRow := db.GetGameRow{ID: 4, XPlayer: 2, OPlayer: pgtype.Int8{Int64: 1, Valid: true}}
+	seriesRows := []db.GetSeriesRow{
+		{ID: 1, XPlayer: 1, OPlayer: pgtype.Int8{Int64: 2, Valid: true}, Result: tictactoe.XWon},
+		{ID: 2, XPlayer: 2, OPlayer: pgtype.Int8{Int64: 1, Valid: true}, Result: tictactoe.XWon},
+		{ID: 3, XPlayer: 1, OPlayer: pgtype.Int8{Int64: 2, Valid: true}, Result: tictactoe.Draw},
+		{ID: 4, XPlayer: 2, OPlayer: pgtype.Int8{Int64: 1, Valid: true}, Result: tictactoe.Playing},
+	}
+
+	series := MapSeries(gameRow, seriesRows)
+	diff := cmp.Diff(&pb.Series{Games: 3, XPlayerWins: 1, OPlayerWins: 1, Draws: 1}, series, protocmp.Transform())
+	assert.Equal(t, "", diff)
+}
+
+func TestTakebackSteps(t *testing.T) {
+	gameRow := db.GetGameRow{ID: 1, XPlayer: 1, OPlayer: pgtype.Int8{Int64: 2, Valid: true}, StartState: "_________"}
+	stepRows := []db.GameStep{
+		{GameID: 1, Ord: 0, MoveRow: 0, MoveCol: 0, Board: "x________", XTurn: false},
+		{GameID: 1, Ord: 1, MoveRow: 1, MoveCol: 1, Board: "x___o____", XTurn: true},
+		{GameID: 1, Ord: 2, MoveRow: 0, MoveCol: 1, Board: "xx__o____", XTurn: false},
+	}
+
+	type Test struct {
+		stepRows   []db.GameStep
+		requester  int64
+		expOrd     int32
+		expBoard   string
+		expTurn    bool
+		expOpening pgtype.Int4
+		expCode    codes.Code
+	}
+
+	tests := []Test{
+		{stepRows: stepRows, requester: 1, expOrd: 2, expBoard: "x___o____", expTurn: true},
+		{stepRows: stepRows, requester: 2, expOrd: 1, expBoard: "x________", expTurn: false, expOpening: pgtype.Int4{Valid: true}},
+		{stepRows: stepRows[:1], requester: 1, expOrd: 0, expBoard: "_________", expTurn: true, expOpening: pgtype.Int4{Valid: true}},
+		{stepRows: stepRows[:1], requester: 2, expCode: codes.FailedPrecondition},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ord, params, err := TakebackSteps(gameRow, test.stepRows, test.requester)
+			if test.expCode != 0 {
+				s, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expCode, s.Code())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expOrd, ord)
+			assert.Equal(t, test.expBoard, params.BoardState)
+			assert.Equal(t, test.expTurn, params.XTurn.Bool)
+			assert.Equal(t, test.expOpening, params.Opening)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limits := map[string]RateLimit{"/limited": {Rate: 2, Burst: 3}}
+	limiter := NewRateLimiter(limits, RateLimit{Rate: 100, Burst: 100})
+	timeNow := time.Now()
+	limiter.now = func() time.Time { return timeNow }
+
+	type Test struct {
+		caller     string
+		method     string
+		elapsed    time.Duration
+		expAllowed bool
+		expWait    time.Duration
+	}
+
+	tests := []Test{
+		{caller: "player:1", method: "/limited", expAllowed: true},
+		{caller: "player:1", method: "/limited", expAllowed: true},
+		{caller: "player:1", method: "/limited", expAllowed: true},
+		{caller: "player:1", method: "/limited", expWait: time.Millisecond * 500}, // burst is used up
+		{caller: "player:3", method: "/limited", expAllowed: true},                // callers have their own buckets
+		{caller: "player:1", method: "/other", expAllowed: true},                  // methods have their own buckets
+		{caller: "player:1", method: "/limited", elapsed: time.Millisecond * 250, expWait: time.Millisecond * 250},
+		{caller: "player:1", method: "/limited", elapsed: time.Millisecond * 250, expAllowed: true}, // refilled
+		{caller: "player:1", method: "/limited", expWait: time.Millisecond * 500},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			timeNow = timeNow.Add(test.elapsed)
+			allowed, wait := limiter.Allow(test.caller, test.method)
+			assert.Equal(t, test.expAllowed, allowed)
+			assert.Equal(t, test.expWait, wait)
+		})
+	}
+
+	// a bucket that has refilled is dropped once swept
+	timeNow = timeNow.Add(RateLimitSweepInterval + time.Second)
+	limiter.Allow("player:1", "/limited")
+	assert.Equal(t, 1, len(limiter.buckets))
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, fallback, err := ParseRateLimits("MakeMove=0.5/4, *=5/6,StreamMessages=1/1,BanPlayer=0.1/2")
+	assert.Nil(t, err)
+	assert.Equal(t, RateLimit{Rate: 0.5, Burst: 4}, limits[pb.TicTacGoService_MakeMove_FullMethodName])
+	assert.Equal(t, RateLimit{Rate: 1, Burst: 1}, limits[pb.TicTacGoService_StreamMessages_FullMethodName])
+	assert.Equal(t, RateLimit{Rate: 0.1, Burst: 2}, limits[pb.AdminService_BanPlayer_FullMethodName])
+	assert.Equal(t, DefaultRateLimits[pb.TicTacGoService_Register_FullMethodName], limits[pb.TicTacGoService_Register_FullMethodName])
+	assert.Equal(t, RateLimit{Rate: 5, Burst: 6}, fallback)
+
+	for _, invalid := range []string{"MakeMove", "MakeMove=1", "MakeMove=0/1", "MakeMove=1/0", "Unknown=1/1"} {
+		_, _, err = ParseRateLimits(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestChatLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	store := NewMemStore()
+	args := TestArgs{store: store}
+	seedTestData(args)
+	server := testServer(args)
+	server.ChatLimit = 2
+	server.ChatWindow = time.Hour
+	client, _, closer := serveWith(ctx, t, server)
+	defer closer()
+
+	// the configured limit replaces the default one
+	sendCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	for i := range 2 {
+		_, err := client.SendMessage(sendCtx, &pb.SendMessageReq{GameId: 1, Text: fmt.Sprintf("message %d", i)})
+		assert.Nil(t, err)
+	}
+	_, err := client.SendMessage(sendCtx, &pb.SendMessageReq{GameId: 1, Text: "one too many"})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.Contains(t, s.Message(), "at most 2 messages every 1h0m0s")
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	limits := map[string]RateLimit{
+		pb.TicTacGoService_WhoAmI_FullMethodName:   {Rate: 0.001, Burst: 2},
+		pb.TicTacGoService_Register_FullMethodName: {Rate: 0.001, Burst: 1},
+	}
+	server := &GrpcServer{TokenKeys: testTokenKeys, RateLimiter: NewRateLimiter(limits, DefaultRateLimit)}
+	client, _, closer := serveWith(ctx, t, server)
+	defer closer()
+
+	assertLimited := func(err error) {
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.ResourceExhausted, s.Code())
+		var retry *errdetails.RetryInfo
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.RetryInfo); ok {
+				retry = info
+			}
+		}
+		if assert.NotNil(t, retry) {
+			assert.True(t, retry.RetryDelay.AsDuration() > time.Second*500)
+		}
+	}
+
+	// signed in calls are limited per player
+	user1 := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user1Token))
+	user3 := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", user3Token))
+	for range 2 {
+		_, err := client.WhoAmI(user1, &pb.WhoAmIReq{})
+		assert.Nil(t, err)
+	}
+	_, err := client.WhoAmI(user1, &pb.WhoAmIReq{})
+	assertLimited(err)
+
+	_, err = client.WhoAmI(user3, &pb.WhoAmIReq{})
+	assert.Nil(t, err)
+
+	// anonymous calls are limited per address, the registration is invalid so it does not reach the database
+	_, err = client.Register(ctx, &pb.CredentialsReq{Username: "u", Password: "p"})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+
+	_, err = client.Register(ctx, &pb.CredentialsReq{Username: "u", Password: "p"})
+	assertLimited(err)
+}
+
+func TestRequiredRole(t *testing.T) {
+	type Test struct {
+		method  string
+		expRole int32
+		expOk   bool
+	}
+
+	tests := []Test{
+		{method: pb.TicTacGoService_MakeMove_FullMethodName, expRole: RolePlayer, expOk: false},
+		{method: pb.AdminService_BanPlayer_FullMethodName, expRole: RoleModerator, expOk: true},
+		{method: pb.AdminService_SetRole_FullMethodName, expRole: RoleAdmin, expOk: true},
+		{method: "/" + pb.AdminService_ServiceDesc.ServiceName + "/Unlisted", expRole: RoleAdmin, expOk: true},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			role, ok := RequiredRole(test.method)
+			assert.Equal(t, test.expRole, role)
+			assert.Equal(t, test.expOk, ok)
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	method := pb.TicTacGoService_MakeMove_FullMethodName
+	hash, err := RequestHash(method, &pb.MakeMoveReq{GameId: 1, Row: 1, Col: 1})
+	if err != nil {
+		t.Fatalf("failed to hash request: %v", err)
+	}
+	otherHash, err := RequestHash(method, &pb.MakeMoveReq{GameId: 1, Row: 2, Col: 1})
+	if err != nil {
+		t.Fatalf("failed to hash request: %v", err)
+	}
+	assert.NotEqual(t, hash, otherHash)
+
+	stored := &pb.Game{Id: 1, BoardState: "x_o_x____"}
+	response, err := proto.Marshal(stored)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	row := db.IdempotencyKey{Method: method, RequestHash: hash, ResponseType: "service.Game", Response: response}
+
+	type Test struct {
+		row     db.IdempotencyKey
+		method  string
+		hash    string
+		expCode codes.Code
+	}
+
+	pending := row
+	pending.Response = nil
+	tests := []Test{
+		{row: row, method: method, hash: hash},
+		{row: row, method: method, hash: otherHash, expCode: codes.FailedPrecondition},
+		{row: row, method: pb.TicTacGoService_CreateGame_FullMethodName, hash: hash, expCode: codes.FailedPrecondition},
+		{row: pending, method: method, hash: hash, expCode: codes.Aborted},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resp, err := Replay(test.row, test.method, test.hash)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				diff := cmp.Diff(stored, resp, protocmp.Transform())
+				assert.Equal(t, "", diff)
+			}
+			if test.expCode != 0 {
+				assert.Equal(t, test.expCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestValidateMoveOrd(t *testing.T) {
+	gameRow := db.GetGameRow{ID: 1, BoardState: "x_o______", XTurn: pgtype.Bool{Bool: true, Valid: true}}
+	lastStep := &db.GameStep{GameID: 1, Ord: 1, MoveRow: 0, MoveCol: 2, Board: "x_o______", XTurn: true}
+
+	type Test struct {
+		lastStep *db.GameStep
+		expected int32
+		expOrd   int32
+		expCode  codes.Code
+	}
+
+	tests := []Test{
+		{lastStep: lastStep, expected: 2},
+		{lastStep: lastStep, expected: 1, expOrd: 1, expCode: codes.FailedPrecondition},
+		{lastStep: nil, expected: 0},
+		{lastStep: nil, expected: 1, expOrd: -1, expCode: codes.FailedPrecondition},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := ValidateMoveOrd(gameRow, test.lastStep, test.expected)
+			if test.expCode == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			s, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, test.expCode, s.Code())
+			details := s.Details()
+			if assert.Len(t, details, 2) {
+				current, ok := details[1].(*pb.Step)
+				assert.True(t, ok)
+				assert.Equal(t, test.expOrd, current.Ord)
+				assert.Equal(t, gameRow.BoardState, current.Board)
+			}
+		})
+	}
+}
+
+func TestMemStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	server := &GrpcServer{Store: NewMemStore(), BlockedWords: DefaultBlockedWords, TokenKeys: testTokenKeys}
+	client, _, closer := serveWith(ctx, t, server)
+	defer closer()
+
+	creds := &pb.CredentialsReq{Username: "memory1", Password: "password1"}
+	player, err := client.Register(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	_, err = client.Register(ctx, &pb.CredentialsReq{Username: "MEMORY1", Password: "password1"})
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.AlreadyExists, s.Code())
+
+	login, err := client.Login(ctx, creds)
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	assert.Equal(t, player.Id, login.Player.Id)
+	authCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", login.Token))
+
+	_, err = client.CreateGame(authCtx, &pb.CreateGameReq{})
+	assert.Nil(t, err)
+
+	// there is no way to join a game as 'O', so the game is put in the store the way the seed data does
+	opponent, err := client.Register(ctx, &pb.CredentialsReq{Username: "memory2", Password: "password2"})
+	if err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	gameID, err := server.Store.InsertGame(ctx, db.InsertGameParams{
+		XPlayer:    player.Id,
+		OPlayer:    pgtype.Int8{Int64: opponent.Id, Valid: true},
+		BoardState: "_________",
+		StartState: "_________",
+		XTurn:      pgtype.Bool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert game: %v", err)
+	}
+	oCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", signTestToken(Claims{ID: opponent.Id, Username: opponent.Username})))
+
+	var game *pb.Game
+	for i, move := range [][2]int32{{0, 0}, {1, 1}, {0, 1}} {
+		moverCtx := authCtx
+		if i%2 == 1 {
+			moverCtx = oCtx
+		}
+		ord := int32(i)
+		game, err = client.MakeMove(moverCtx, &pb.MakeMoveReq{GameId: gameID, Row: move[0], Col: move[1], Ord: &ord})
+		if err != nil {
+			t.Fatalf("failed to make move %d: %v", i, err)
+		}
+	}
+
+	// a stale ord is rejected and leaves the game as it was
+	stale := int32(1)
+	_, err = client.MakeMove(oCtx, &pb.MakeMoveReq{GameId: gameID, Row: 2, Col: 2, Ord: &stale})
+	s, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, s.Code())
+
+	got, err := client.GetGame(authCtx, &pb.GetGameReq{Id: gameID})
+	if err != nil {
+		t.Fatalf("failed to get game: %v", err)
+	}
+	assert.Equal(t, game.BoardState, got.BoardState)
+	assert.Len(t, got.Steps, 3)
+
+	// chat is kept in memory along with the game
+	_, err = client.SendMessage(authCtx, &pb.SendMessageReq{GameId: gameID, Text: "good game"})
+	if err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	got, err = client.GetGame(authCtx, &pb.GetGameReq{Id: gameID})
+	if err != nil {
+		t.Fatalf("failed to get game: %v", err)
+	}
+	if assert.Len(t, got.Messages, 1) {
+		assert.Equal(t, "good game", got.Messages[0].Text)
+	}
+}
+
+func TestMemStoreInTx(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemStore()
+
+	player, err := store.InsertPlayer(ctx, db.InsertPlayerParams{Username: "memory1", Passwd: "hash"})
+	if err != nil {
+		t.Fatalf("failed to insert player: %v", err)
+	}
+
+	expiresOn := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+	_, err = store.InsertSession(ctx, db.InsertSessionParams{TokenHash: "hash", PlayerID: player.ID, ExpiresOn: expiresOn})
+	if err != nil {
+		t.Fatalf("failed to insert session: %v", err)
+	}
+
+	// a failed transaction leaves nothing behind
+	failed := errors.New("failed")
+	var failedId int64
+	err = store.InTx(ctx, "test", func(ctx context.Context, tx Store) error {
+		failedId, err = tx.InsertGame(ctx, db.InsertGameParams{XPlayer: player.ID, BoardState: "_________"})
+		if err != nil {
+			return err
+		}
+		_, err = tx.UpdatePassword(ctx, db.UpdatePasswordParams{ID: player.ID, Passwd: "changed"})
+		if err != nil {
+			return err
+		}
+		_, err = tx.DeletePlayerSessions(ctx, player.ID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.InsertAuditEvent(ctx, db.InsertAuditEventParams{EventType: "test"})
+		if err != nil {
+			return err
+		}
+		return failed
+	})
+	assert.Equal(t, failed, err)
+
+	_, err = store.GetGame(ctx, failedId)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	account, err := store.GetAccount(ctx, player.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", account.Passwd)
+	sessions, err := store.GetPlayerSessions(ctx, player.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(sessions))
+	assert.Equal(t, 0, len(store.data.auditEvents))
+
+	// a committed one keeps its changes, under an id the failed one did not take back
+	var gameId int64
+	err = store.InTx(ctx, "test", func(ctx context.Context, tx Store) error {
+		gameId, err = tx.InsertGame(ctx, db.InsertGameParams{XPlayer: player.ID, BoardState: "_________"})
+		return err
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, failedId, gameId)
+	game, err := store.GetGame(ctx, gameId)
+	assert.Nil(t, err)
+	assert.Equal(t, "memory1", game.XPlayerName.String)
+
+	// updates are guarded by the version like the postgres ones
+	params := db.UpdateGameParams{ID: game.ID, BoardState: "x________", Version: game.Version}
+	result, err := store.UpdateGame(ctx, params)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected())
+	result, err = store.UpdateGame(ctx, params)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), result.RowsAffected())
+}
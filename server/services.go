@@ -2,20 +2,25 @@ package server
 
 import (
 	"TicTacGo/db"
+	"TicTacGo/pb"
 	"TicTacGo/tictactoe"
 	"context"
-	"github.com/jackc/pgx/v5"
-	"golang.org/x/sync/errgroup"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
 	"time"
 )
 
 type MoveResult struct {
-	Board  tictactoe.Board
-	Turn   bool
-	Result int32
+	Board   tictactoe.Board
+	Turn    bool
+	Result  int32
+	Opening pgtype.Int4
 }
 
 func MakeMove(gameRow db.GetGameRow, row, col int32) (MoveResult, error) {
@@ -30,6 +35,11 @@ func MakeMove(gameRow db.GetGameRow, row, col int32) (MoveResult, error) {
 		log.Printf("error converting board from string: %v", err)
 		return MoveResult{}, status.Error(codes.Internal, "error converting board from string")
 	}
+	err = tictactoe.ValidatePosition(board, gameRow.XTurn.Bool)
+	if err != nil {
+		log.Printf("game: %d has a corrupt board state: %s, %v", gameRow.ID, gameRow.BoardState, err)
+		return MoveResult{}, status.Errorf(codes.DataLoss, "game: %d has a corrupt board state", gameRow.ID)
+	}
 	board, turn, err := tictactoe.MoveBoard(board, gameRow.XTurn.Bool, row, col, tileValue)
 	if err != nil {
 		log.Printf("cannot make move on gameRow: %d, %s", gameRow.ID, err.Error())
@@ -37,75 +47,673 @@ func MakeMove(gameRow db.GetGameRow, row, col int32) (MoveResult, error) {
 	}
 	result := tictactoe.GetResult(board)
 
-	return MoveResult{Board: board, Turn: turn, Result: result}, nil
+	// openings only apply to games played from the empty board, and are named once o has replied
+	var opening pgtype.Int4
+	start, _ := tictactoe.NewGame()
+	if gameRow.StartState == tictactoe.BoardToString(start) {
+		if o, ok := tictactoe.ClassifyOpening(board); ok {
+			opening = pgtype.Int4{Int32: o.ID, Valid: true}
+		}
+	}
+
+	return MoveResult{Board: board, Turn: turn, Result: result, Opening: opening}, nil
+}
+
+// IsSeated checks whether the player plays either side of the game.
+func IsSeated(gameRow db.GetGameRow, playerID int64) bool {
+	return playerID != 0 && (gameRow.XPlayer == playerID || gameRow.OPlayer.Valid && gameRow.OPlayer.Int64 == playerID)
+}
+
+// IssueTokens signs an access token for the claims' session and builds the response carrying both tokens.
+func (s *GrpcServer) IssueTokens(claims Claims, refreshToken string, timeNow time.Time) (*pb.LoginResp, error) {
+	expiresOn := timeNow.Add(s.AccessTTL())
+	claims.ExpiresOn = expiresOn.Unix()
+
+	token, err := s.TokenKeys.Sign(claims)
+	if err != nil {
+		log.Printf("failed to sign access token: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to sign access token for player: %d", claims.ID)
+	}
+
+	resp := pb.LoginResp{
+		Token:            token,
+		Player:           &pb.Player{Id: claims.ID, Username: claims.Username},
+		ExpiresOn:        &timestamppb.Timestamp{Seconds: expiresOn.Unix()},
+		RefreshToken:     refreshToken,
+		RefreshExpiresOn: &timestamppb.Timestamp{Seconds: timeNow.Add(s.SessionTTL()).Unix()},
+	}
+	return &resp, nil
 }
 
-func (s *GrpcServer) GetGameAndSession(ctx context.Context, token string, gameId int64) (db.GetSessionRow, db.GetGameRow, error) {
-	eg, egCtx := errgroup.WithContext(ctx)
+// GetGameAndPlayer returns the signed in player along with the game they are acting on.
+func (s *GrpcServer) GetGameAndPlayer(ctx context.Context, gameId int64) (Claims, db.GetGameRow, error) {
+	sessRow, err := RequirePlayer(ctx)
+	if err != nil {
+		return Claims{}, db.GetGameRow{}, err
+	}
+	gameRow, err := s.Store.GetGame(ctx, gameId)
+	if err != nil {
+		log.Printf("failed to get game: %v", err)
+		return Claims{}, db.GetGameRow{}, status.Errorf(codes.Internal, "failed to get game for id: %d", gameId)
+	}
+	return sessRow, gameRow, nil
+}
 
-	var sessRow db.GetSessionRow
-	var gameRow db.GetGameRow
+// CheckMoveOrd compares the ord the client expects its move to get with the step that comes next.
+func (s *GrpcServer) CheckMoveOrd(ctx context.Context, gameRow db.GetGameRow, expected int32) error {
+	row, err := s.Store.GetLastStep(ctx, gameRow.ID)
+	if errors.Is(err, ErrNotFound) {
+		return ValidateMoveOrd(gameRow, nil, expected)
+	}
+	if err != nil {
+		log.Printf("failed to get last step: %v", err)
+		return status.Errorf(codes.Internal, "failed to get last step for game: %d", gameRow.ID)
+	}
+	return ValidateMoveOrd(gameRow, &row, expected)
+}
 
-	eg.Go(func() error {
-		row, err := s.Queries.GetSession(egCtx, token)
+// UpdateGameTrans stores a move made on a game read outside the transaction. The update only applies to
+// the version that was read, so a move racing another change to the game fails with Aborted.
+func (s *GrpcServer) UpdateGameTrans(ctx context.Context, gameId int64, updtGameParams db.UpdateGameParams, instStepParams db.InsertStepParams, event AuditEvent) error {
+	err := s.Store.InTx(ctx, "UpdateGame and InsertStep", func(ctx context.Context, qtx Store) error {
+		result, err := qtx.UpdateGame(ctx, updtGameParams)
 		if err != nil {
-			log.Printf("failed to get session: %v", err)
-			return status.Errorf(codes.PermissionDenied, "failed to get session for params: %s", token)
+			log.Printf("failed to update game: %v", err)
+			return status.Errorf(codes.Internal, "failed to update game for id: %d and params: %+v", gameId, updtGameParams)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.Aborted, "game: %d was changed concurrently, reload it and retry", gameId)
 		}
-		sessRow = row
+		_, err = qtx.InsertStep(ctx, instStepParams)
+		if err != nil {
+			log.Printf("failed to insert step: %v", err)
+			return status.Errorf(codes.Internal, "failed to insert step for id: %d and params: %+v", gameId, instStepParams)
+		}
+		return RecordAudit(ctx, qtx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("executed UpdateGame and InsertStep transaction for game: %d", gameId)
+	return nil
+}
+
+// CheckPassword confirms the signed in player's password before an account change.
+func (s *GrpcServer) CheckPassword(ctx context.Context, playerID int64, password string) (db.GetAccountRow, error) {
+	row, err := s.Store.GetAccount(ctx, playerID)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("no account found for player: %d", playerID)
+		return db.GetAccountRow{}, status.Error(codes.Unauthenticated, "account no longer exists")
+	}
+	if err != nil {
+		log.Printf("failed to get account: %v", err)
+		return db.GetAccountRow{}, status.Errorf(codes.Internal, "failed to get account for player: %d", playerID)
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(row.Passwd), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return db.GetAccountRow{}, status.Error(codes.PermissionDenied, "password is incorrect")
+	}
+	if err != nil {
+		log.Printf("failed to compare hashed password: %v", err)
+		return db.GetAccountRow{}, status.Errorf(codes.Internal, "failed to compare password for player: %d", playerID)
+	}
+	return row, nil
+}
+
+// ChangePasswordTrans stores the new password hash and ends every session but the current one, returning
+// the number of sessions ended.
+func (s *GrpcServer) ChangePasswordTrans(ctx context.Context, player Claims, hashedPassword string) (int64, error) {
+	var sessions int64
+	err := s.Store.InTx(ctx, "UpdatePassword and DeleteOtherSessions", func(ctx context.Context, qtx Store) error {
+		result, err := qtx.UpdatePassword(ctx, db.UpdatePasswordParams{ID: player.ID, Passwd: hashedPassword})
+		if err != nil {
+			log.Printf("failed to update password: %v", err)
+			return status.Errorf(codes.Internal, "failed to update password for player: %d", player.ID)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Error(codes.Unauthenticated, "account no longer exists")
+		}
+		result, err = qtx.DeleteOtherSessions(ctx, db.DeleteOtherSessionsParams{PlayerID: player.ID, ID: player.SessionID})
+		if err != nil {
+			log.Printf("failed to delete sessions: %v", err)
+			return status.Errorf(codes.Internal, "failed to delete sessions for player: %d", player.ID)
+		}
+		sessions = result.RowsAffected()
 		return nil
 	})
-	eg.Go(func() error {
-		row, err := s.Queries.GetGame(egCtx, gameId)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("executed UpdatePassword and DeleteOtherSessions transaction for player: %d", player.ID)
+	return sessions, nil
+}
+
+// DeleteAccountTrans anonymizes the player and ends all of their sessions, returning the number of sessions
+// ended. The account row is kept so that the player's games stay intact, under a name longer than a
+// registered username can be so that it is never taken.
+func (s *GrpcServer) DeleteAccountTrans(ctx context.Context, playerID int64) (int64, error) {
+	var sessions int64
+	err := s.Store.InTx(ctx, "AnonymizePlayer and DeletePlayerSessions", func(ctx context.Context, qtx Store) error {
+		params := db.AnonymizePlayerParams{ID: playerID, DeletedOn: pgtype.Timestamptz{Time: time.Now(), Valid: true}}
+		result, err := qtx.AnonymizePlayer(ctx, params)
+		if err != nil {
+			log.Printf("failed to anonymize player: %v", err)
+			return status.Errorf(codes.Internal, "failed to anonymize player: %d", playerID)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Error(codes.Unauthenticated, "account no longer exists")
+		}
+		result, err = qtx.DeletePlayerSessions(ctx, playerID)
 		if err != nil {
-			log.Printf("failed to get game: %v", err)
-			return status.Errorf(codes.Internal, "failed to get game for id: %d", gameId)
+			log.Printf("failed to delete sessions: %v", err)
+			return status.Errorf(codes.Internal, "failed to delete sessions for player: %d", playerID)
 		}
-		gameRow = row
+		sessions = result.RowsAffected()
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("executed AnonymizePlayer and DeletePlayerSessions transaction for player: %d", playerID)
+	return sessions, nil
+}
+
+// TakebackSteps finds the requester's last move and the state to restore once it is taken back. When the
+// opponent has already replied, the reply is taken back as well so the requester is to move again.
+func TakebackSteps(gameRow db.GetGameRow, stepRows []db.GameStep, requester int64) (int32, db.UpdateGameParams, error) {
+	requesterIsX := gameRow.XPlayer == requester
 
-	if err := eg.Wait(); err != nil {
-		log.Printf("failed to retrieve session and game for session token=%s and gameId=%d, with err %v", token, gameId, err)
-		return db.GetSessionRow{}, db.GetGameRow{}, err
+	last := -1
+	for i, stepRow := range stepRows {
+		// a step records the side to move after it, so x made the move when it is o's turn
+		if stepRow.Result != tictactoe.Forfeit && !stepRow.XTurn == requesterIsX {
+			last = i
+		}
+	}
+	if last < 0 {
+		return 0, db.UpdateGameParams{}, status.Errorf(codes.FailedPrecondition, "player: %d has no move to take back on game: %d", requester, gameRow.ID)
 	}
 
-	return sessRow, gameRow, nil
+	params := db.UpdateGameParams{
+		ID:        gameRow.ID,
+		Version:   gameRow.Version,
+		UpdatedOn: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Result:    tictactoe.Playing,
+	}
+	if last > 0 {
+		params.BoardState = stepRows[last-1].Board
+		params.XTurn = pgtype.Bool{Bool: stepRows[last-1].XTurn, Valid: true}
+	} else {
+		board, err := tictactoe.ParseBoard(gameRow.StartState)
+		if err != nil {
+			log.Printf("error converting board from string: %v", err)
+			return 0, db.UpdateGameParams{}, status.Error(codes.Internal, "error converting board from string")
+		}
+		params.BoardState = gameRow.StartState
+		params.XTurn = pgtype.Bool{Bool: tictactoe.ImpliedTurn(board), Valid: true}
+	}
+	// the opening is named by the second move, so it no longer applies once that move is taken back
+	if last < 2 {
+		params.Opening = pgtype.Int4{Int32: tictactoe.OpeningNone, Valid: true}
+	}
+
+	return stepRows[last].Ord, params, nil
 }
 
-func (s *GrpcServer) UpdateGameTrans(ctx context.Context, gameId int64, updtGameParams db.UpdateGameParams, instStepParams db.InsertStepParams) error {
-	dbCtx, cancel := context.WithTimeout(ctx, time.Second*5)
-	defer cancel()
+// TakebackTrans accepts a pending takeback, deleting the taken back steps and restoring the game. The
+// request is claimed first, so a takeback is only applied once when responses race.
+func (s *GrpcServer) TakebackTrans(ctx context.Context, gameRow db.GetGameRow, requester int64) (db.UpdateGameParams, error) {
+	var ord int32
+	var updtGameParams db.UpdateGameParams
+	err := s.Store.InTx(ctx, "takeback", func(ctx context.Context, qtx Store) error {
+		clearParams := db.ClearTakebackParams{ID: gameRow.ID, TakebackBy: pgtype.Int8{Int64: requester, Valid: true}}
+		result, err := qtx.ClearTakeback(ctx, clearParams)
+		if err != nil {
+			log.Printf("failed to clear takeback: %v", err)
+			return status.Errorf(codes.Internal, "failed to clear takeback for params: %+v", clearParams)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.Aborted, "takeback on game: %d was answered concurrently", gameRow.ID)
+		}
+
+		stepRows, err := qtx.GetGameSteps(ctx, gameRow.ID)
+		if err != nil {
+			log.Printf("failed to get steps: %v", err)
+			return status.Errorf(codes.Internal, "failed to get steps for game: %d", gameRow.ID)
+		}
+		ord, updtGameParams, err = TakebackSteps(gameRow, stepRows, requester)
+		if err != nil {
+			return err
+		}
 
-	tx, err := s.Pool.Begin(dbCtx)
+		deleteParams := db.DeleteStepsFromParams{GameID: gameRow.ID, Ord: ord}
+		_, err = qtx.DeleteStepsFrom(ctx, deleteParams)
+		if err != nil {
+			log.Printf("failed to delete steps: %v", err)
+			return status.Errorf(codes.Internal, "failed to delete steps for params: %+v", deleteParams)
+		}
+		result, err = qtx.UpdateGame(ctx, updtGameParams)
+		if err != nil {
+			log.Printf("failed to update game: %v", err)
+			return status.Errorf(codes.Internal, "failed to update game for id: %d and params: %+v", gameRow.ID, updtGameParams)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.Aborted, "game: %d was changed concurrently, reload it and retry", gameRow.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return db.UpdateGameParams{}, err
+	}
+
+	log.Printf("executed takeback transaction for game: %d from ord: %d", gameRow.ID, ord)
+	return updtGameParams, nil
+}
+
+// RematchTrans accepts a pending rematch offer, creating a game from the same starting position with the
+// colors swapped. A game can only be rematched once, so racing responses fail with AlreadyExists.
+func (s *GrpcServer) RematchTrans(ctx context.Context, gameRow db.GetGameRow, offerer int64) (int64, error) {
+	board, err := tictactoe.ParseBoard(gameRow.StartState)
 	if err != nil {
-		log.Printf("failed to acquire a connection: %v", err)
-		return status.Errorf(codes.Internal, "an unexpected error occured")
+		log.Printf("error converting board from string: %v", err)
+		return 0, status.Error(codes.Internal, "error converting board from string")
 	}
 
-	defer func(tx pgx.Tx, ctx context.Context) {
-		err := tx.Rollback(ctx)
+	var gameId int64
+	err = s.Store.InTx(ctx, "rematch", func(ctx context.Context, qtx Store) error {
+		clearParams := db.ClearRematchParams{ID: gameRow.ID, RematchBy: pgtype.Int8{Int64: offerer, Valid: true}}
+		result, err := qtx.ClearRematch(ctx, clearParams)
 		if err != nil {
-			log.Printf("failed to rollback UpdateGame and InsertStep transaction: %v", err)
+			log.Printf("failed to clear rematch: %v", err)
+			return status.Errorf(codes.Internal, "failed to clear rematch for params: %+v", clearParams)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.Aborted, "rematch on game: %d was answered concurrently", gameRow.ID)
 		}
-	}(tx, dbCtx)
-	qtx := s.Queries.WithTx(tx)
 
-	_, err = qtx.UpdateGame(dbCtx, updtGameParams)
+		timeNow := time.Now()
+		params := db.InsertGameParams{
+			XPlayer:           gameRow.OPlayer.Int64,
+			OPlayer:           pgtype.Int8{Int64: gameRow.XPlayer, Valid: true},
+			BoardState:        gameRow.StartState,
+			StartState:        gameRow.StartState,
+			XTurn:             pgtype.Bool{Bool: tictactoe.ImpliedTurn(board), Valid: true},
+			UpdatedOn:         pgtype.Timestamptz{Time: timeNow, Valid: true},
+			StartedOn:         pgtype.Timestamptz{Time: timeNow, Valid: true},
+			HintBudget:        gameRow.HintBudget,
+			TakebacksDisabled: gameRow.TakebacksDisabled,
+			RematchOf:         pgtype.Int8{Int64: gameRow.ID, Valid: true},
+		}
+		gameId, err = qtx.InsertGame(ctx, params)
+		if err != nil {
+			if errors.Is(err, ErrConflict) {
+				log.Printf("game already rematched: %v", err)
+				return status.Errorf(codes.AlreadyExists, "game: %d was already rematched", gameRow.ID)
+			}
+			log.Printf("failed to insert game: %v", err)
+			return status.Errorf(codes.Internal, "failed to insert game for params: %+v", params)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("failed to update game: %v", err)
-		return status.Errorf(codes.Internal, "failed to update game for id: %d and params: %+v", gameId, updtGameParams)
+		return 0, err
+	}
+
+	log.Printf("executed rematch transaction for game: %d, created game: %d", gameRow.ID, gameId)
+	return gameId, nil
+}
+
+// AnalyzeSteps replays the moves of a game from its starting position and annotates each one. Steps that
+// record a forfeit rather than a move are skipped.
+func AnalyzeSteps(gameId int64, startState string, stepRows []db.GameStep) ([]db.Analysis, error) {
+	var tiles []tictactoe.Tile
+	var ords []int32
+	for _, stepRow := range stepRows {
+		if stepRow.Result == tictactoe.Forfeit {
+			continue
+		}
+		tiles = append(tiles, tictactoe.Tile{Row: stepRow.MoveRow, Col: stepRow.MoveCol})
+		ords = append(ords, stepRow.Ord)
 	}
-	_, err = qtx.InsertStep(dbCtx, instStepParams)
+
+	board, err := tictactoe.ParseBoard(startState)
+	if err != nil {
+		log.Printf("error converting board from string: %v", err)
+		return nil, status.Error(codes.Internal, "error converting board from string")
+	}
+	moves, err := tictactoe.AnalyzeMoves(board, tictactoe.ImpliedTurn(board), tiles)
 	if err != nil {
-		log.Printf("failed to insert step: %v", err)
-		return status.Errorf(codes.Internal, "failed to insert step for id: %d and params: %+v", gameId, instStepParams)
+		log.Printf("failed to replay steps for game: %d, %v", gameId, err)
+		return nil, status.Errorf(codes.Internal, "failed to replay steps for game: %d", gameId)
 	}
 
-	if err = tx.Commit(dbCtx); err != nil {
-		return status.Errorf(codes.Internal, "failed to commit UpdateGame and InsertStep transaction")
+	var rows []db.Analysis
+	for i, move := range moves {
+		rows = append(rows, db.Analysis{
+			GameID:         gameId,
+			Ord:            ords[i],
+			MoveRow:        move.Row,
+			MoveCol:        move.Col,
+			XMoved:         move.XMoved,
+			BestResult:     move.Best.Result,
+			BestDistance:   move.Best.Distance,
+			PlayedResult:   move.Played.Result,
+			PlayedDistance: move.Played.Distance,
+			Annotation:     move.Annotation,
+		})
 	}
+	return rows, nil
+}
 
-	log.Printf("executed UpdateGame and InsertStep transaction for game: %d", gameId)
+func (s *GrpcServer) InsertAnalysesTrans(ctx context.Context, gameId int64, rows []db.Analysis) error {
+	err := s.Store.InTx(ctx, "InsertAnalysis", func(ctx context.Context, qtx Store) error {
+		for _, row := range rows {
+			params := db.InsertAnalysisParams{
+				GameID:         row.GameID,
+				Ord:            row.Ord,
+				MoveRow:        row.MoveRow,
+				MoveCol:        row.MoveCol,
+				XMoved:         row.XMoved,
+				BestResult:     row.BestResult,
+				BestDistance:   row.BestDistance,
+				PlayedResult:   row.PlayedResult,
+				PlayedDistance: row.PlayedDistance,
+				Annotation:     row.Annotation,
+			}
+			_, err := qtx.InsertAnalysis(ctx, params)
+			if err != nil {
+				log.Printf("failed to insert analysis: %v", err)
+				return status.Errorf(codes.Internal, "failed to insert analysis for id: %d and params: %+v", gameId, params)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("executed InsertAnalysis transaction for game: %d", gameId)
 	return nil
 }
+
+// MessagesPerWindow returns how many chat messages a player may send in a window, or the default when none
+// is configured.
+func (s *GrpcServer) MessagesPerWindow() int64 {
+	if s.ChatLimit <= 0 {
+		return DefaultMessagesPerWindow
+	}
+	return s.ChatLimit
+}
+
+// MessageWindow returns the configured chat window, or the default when none is configured.
+func (s *GrpcServer) MessageWindow() time.Duration {
+	if s.ChatWindow <= 0 {
+		return DefaultMessageWindow
+	}
+	return s.ChatWindow
+}
+
+// SendMessageTrans inserts the message unless the player has used up their messages for the window. The
+// player's row is locked while counting, so concurrent sends can not all pass the count.
+func (s *GrpcServer) SendMessageTrans(ctx context.Context, params db.InsertMessageParams) (int64, error) {
+	var id int64
+	limit, window := s.MessagesPerWindow(), s.MessageWindow()
+	err := s.Store.InTx(ctx, "SendMessage", func(ctx context.Context, qtx Store) error {
+		_, err := qtx.LockPlayer(ctx, params.PlayerID)
+		if err != nil {
+			log.Printf("failed to lock player: %v", err)
+			return status.Errorf(codes.Internal, "failed to lock player: %d", params.PlayerID)
+		}
+
+		countParams := db.CountRecentMessagesParams{
+			PlayerID: params.PlayerID,
+			SentOn:   pgtype.Timestamptz{Time: params.SentOn.Time.Add(-window), Valid: true},
+		}
+		count, err := qtx.CountRecentMessages(ctx, countParams)
+		if err != nil {
+			log.Printf("failed to count recent messages: %v", err)
+			return status.Errorf(codes.Internal, "failed to count recent messages for params: %+v", countParams)
+		}
+		if count >= limit {
+			return status.Errorf(codes.ResourceExhausted, "player: %d can send at most %d messages every %s", params.PlayerID, limit, window)
+		}
+
+		id, err = qtx.InsertMessage(ctx, params)
+		if err != nil {
+			log.Printf("failed to insert message: %v", err)
+			return status.Errorf(codes.Internal, "failed to insert message for params: %+v", params)
+		}
+		return nil
+	})
+	return id, err
+}
+
+type CorruptGame struct {
+	ID     int64
+	Reason string
+}
+
+// CheckGamePosition reports why a stored game could not have been reached in play, if it couldn't.
+func CheckGamePosition(row db.GetGamePositionsRow) error {
+	if !row.XTurn.Valid {
+		return errors.New("side to move is missing")
+	}
+	board, err := tictactoe.ParseBoard(row.BoardState)
+	if err != nil {
+		return err
+	}
+	err = tictactoe.ValidatePosition(board, row.XTurn.Bool)
+	if err != nil {
+		return err
+	}
+	// forfeits and games ended by an admin keep the board they ended on, whatever its result
+	forced := row.Result == tictactoe.Forfeit || row.EndedBy.Valid
+	if result := tictactoe.GetResult(board); !forced && row.Result != result {
+		return fmt.Errorf("stored result %d does not match board result %d", row.Result, result)
+	}
+	return nil
+}
+
+// CheckGames scans every stored game for board states that could not have been reached in play.
+func (s *GrpcServer) CheckGames(ctx context.Context) ([]CorruptGame, error) {
+	var corrupt []CorruptGame
+
+	params := db.GetGamePositionsParams{ID: 0, Limit: 500}
+	for {
+		rows, err := s.Store.GetGamePositions(ctx, params)
+		if err != nil {
+			log.Printf("failed to get game positions: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to get game positions for params: %+v", params)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			if err := CheckGamePosition(row); err != nil {
+				log.Printf("found corrupt game: %d, board: %s, xTurn: %v, result: %d, %v", row.ID, row.BoardState, row.XTurn, row.Result, err)
+				corrupt = append(corrupt, CorruptGame{ID: row.ID, Reason: err.Error()})
+			}
+		}
+		params.ID = rows[len(rows)-1].ID
+	}
+
+	return corrupt, nil
+}
+
+func (s *GrpcServer) UpdatePuzzleAttemptTrans(ctx context.Context, attemptRow db.PuzzleAttempt, step tictactoe.PuzzleStep) (db.GetPuzzleStreakRow, error) {
+	var streakRow db.GetPuzzleStreakRow
+	err := s.Store.InTx(ctx, "UpdatePuzzleAttempt and UpdatePuzzleStreak", func(ctx context.Context, qtx Store) error {
+		// the update only applies to the board the move was made on, so a concurrent move cannot be overwritten
+		timeNow := time.Now()
+		updtAttemptParams := db.UpdatePuzzleAttemptParams{
+			PuzzleID:       attemptRow.PuzzleID,
+			PlayerID:       attemptRow.PlayerID,
+			PrevBoardState: attemptRow.BoardState,
+			BoardState:     tictactoe.BoardToString(step.Board),
+			XTurn:          step.XTurn,
+			MovesLeft:      step.MovesLeft,
+			Status:         step.Status,
+			UpdatedOn:      pgtype.Timestamptz{Time: timeNow, Valid: true},
+			CompletedOn:    pgtype.Timestamptz{Time: timeNow, Valid: step.Status != tictactoe.PuzzleSolving},
+		}
+		result, err := qtx.UpdatePuzzleAttempt(ctx, updtAttemptParams)
+		if err != nil {
+			log.Printf("failed to update puzzle attempt: %v", err)
+			return status.Errorf(codes.Internal, "failed to update puzzle attempt for params: %+v", updtAttemptParams)
+		}
+		if result.RowsAffected() == 0 {
+			return status.Errorf(codes.Aborted, "puzzle attempt for puzzle: %d was changed by another move", attemptRow.PuzzleID)
+		}
+
+		if step.Status == tictactoe.PuzzleSolving {
+			streakRow, err = qtx.GetPuzzleStreak(ctx, attemptRow.PlayerID)
+		} else {
+			var row db.UpdatePuzzleStreakRow
+			row, err = qtx.UpdatePuzzleStreak(ctx, db.UpdatePuzzleStreakParams{ID: attemptRow.PlayerID, Solved: step.Status == tictactoe.PuzzleSolved})
+			streakRow = db.GetPuzzleStreakRow(row)
+		}
+		if err != nil {
+			log.Printf("failed to update puzzle streak: %v", err)
+			return status.Errorf(codes.Internal, "failed to update puzzle streak for player: %d", attemptRow.PlayerID)
+		}
+		return nil
+	})
+	if err != nil {
+		return db.GetPuzzleStreakRow{}, err
+	}
+
+	log.Printf("executed UpdatePuzzleAttempt and UpdatePuzzleStreak transaction for puzzle: %d", attemptRow.PuzzleID)
+	return streakRow, nil
+}
+
+const DayLayout = "2006-01-02"
+
+// dailyCandidates are the puzzles the daily schedule rotates through
+var dailyCandidates = append(tictactoe.GeneratePuzzles(2), tictactoe.GeneratePuzzles(3)...)
+
+// DayOf returns the UTC calendar day containing the time.
+func DayOf(t time.Time) pgtype.Date {
+	year, month, day := t.UTC().Date()
+	return pgtype.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// errDayScheduled rolls back EnsureDailyPuzzle when the day has a puzzle already.
+var errDayScheduled = errors.New("daily puzzle already scheduled")
+
+// EnsureDailyPuzzle schedules a generated puzzle for the day unless one is scheduled already. The candidate is
+// picked from the day itself, so servers racing to schedule the same day agree on the puzzle.
+func (s *GrpcServer) EnsureDailyPuzzle(ctx context.Context, day pgtype.Date) error {
+	var puzzleId int64
+	err := s.Store.InTx(ctx, "InsertPuzzle and InsertDailyPuzzle", func(ctx context.Context, qtx Store) error {
+		_, err := qtx.GetDailyPuzzle(ctx, day)
+		if err == nil {
+			return errDayScheduled
+		}
+		if !errors.Is(err, ErrNotFound) {
+			log.Printf("failed to get daily puzzle: %v", err)
+			return status.Errorf(codes.Internal, "failed to get daily puzzle for day: %s", day.Time.Format(DayLayout))
+		}
+
+		candidate := dailyCandidates[int(day.Time.Unix()/(24*60*60))%len(dailyCandidates)]
+		instPuzzleParams := db.InsertPuzzleParams{
+			BoardState:  tictactoe.BoardToString(candidate.Board),
+			XTurn:       candidate.XTurn,
+			Depth:       candidate.Depth,
+			SolutionRow: candidate.Solution.Row,
+			SolutionCol: candidate.Solution.Col,
+			Difficulty:  candidate.Difficulty,
+		}
+		puzzleId, err = qtx.InsertPuzzle(ctx, instPuzzleParams)
+		if err != nil {
+			log.Printf("failed to insert puzzle: %v", err)
+			return status.Errorf(codes.Internal, "failed to insert puzzle for params: %+v", instPuzzleParams)
+		}
+
+		result, err := qtx.InsertDailyPuzzle(ctx, db.InsertDailyPuzzleParams{Day: day, PuzzleID: puzzleId})
+		if err != nil {
+			log.Printf("failed to insert daily puzzle: %v", err)
+			return status.Errorf(codes.Internal, "failed to insert daily puzzle for day: %s", day.Time.Format(DayLayout))
+		}
+		if result.RowsAffected() == 0 {
+			// another server scheduled the day first, failing the transaction discards the puzzle inserted here
+			return errDayScheduled
+		}
+		return nil
+	})
+	if errors.Is(err, errDayScheduled) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("scheduled puzzle: %d for day: %s", puzzleId, day.Time.Format(DayLayout))
+	return nil
+}
+
+// RunDailyPuzzles keeps today's and tomorrow's puzzles scheduled, checking on every tick until the context is done.
+func (s *GrpcServer) RunDailyPuzzles(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		timeNow := time.Now()
+		for _, day := range []pgtype.Date{DayOf(timeNow), DayOf(timeNow.Add(24 * time.Hour))} {
+			if err := s.EnsureDailyPuzzle(ctx, day); err != nil {
+				log.Printf("failed to schedule daily puzzle: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunSessionPurge deletes expired sessions, forgotten login failures and expired idempotency keys every
+// interval until the context is done.
+func (s *GrpcServer) RunSessionPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.Store.PurgeExpiredSessions(ctx, pgtype.Timestamptz{Time: time.Now(), Valid: true})
+		if err != nil {
+			log.Printf("failed to purge expired sessions: %v", err)
+		} else if result.RowsAffected() > 0 {
+			log.Printf("purged %d expired sessions", result.RowsAffected())
+		}
+
+		timeNow := time.Now()
+		params := db.PurgeLoginFailuresParams{
+			WindowStart: pgtype.Timestamptz{Time: timeNow.Add(-LoginFailureWindow), Valid: true},
+			Now:         pgtype.Timestamptz{Time: timeNow, Valid: true},
+		}
+		result, err = s.Store.PurgeLoginFailures(ctx, params)
+		if err != nil {
+			log.Printf("failed to purge login failures: %v", err)
+		} else if result.RowsAffected() > 0 {
+			log.Printf("purged %d stale login failures", result.RowsAffected())
+		}
+
+		result, err = s.Store.PurgeIdempotencyKeys(ctx, pgtype.Timestamptz{Time: timeNow, Valid: true})
+		if err != nil {
+			log.Printf("failed to purge idempotency keys: %v", err)
+		} else if result.RowsAffected() > 0 {
+			log.Printf("purged %d expired idempotency keys", result.RowsAffected())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,1003 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"TicTacGo/db/sqlite"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	driver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"net/url"
+	"time"
+)
+
+// SqliteStore is the Store backed by a sqlite file, running the sqlc queries generated for sqlite. Sqlite
+// takes one writer at a time, so the store keeps a single connection and calls wait their turn for it.
+type SqliteStore struct {
+	Queries *sqlite.Queries
+	DB      *sql.DB
+	inTx    bool
+}
+
+// NewSqliteStore opens the sqlite database at the path, creating it and its tables if they do not exist.
+// Use ":memory:" for a database that is gone once the store is closed.
+func NewSqliteStore(ctx context.Context, path string) (*SqliteStore, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_time_format", "sqlite")
+	conn, err := sql.Open("sqlite", fmt.Sprintf("file:%s?%s", path, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(1)
+
+	if _, err = conn.ExecContext(ctx, sqlite.CreateSchema); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return &SqliteStore{Queries: sqlite.New(conn), DB: conn}, nil
+}
+
+func (s *SqliteStore) Close() error {
+	return s.DB.Close()
+}
+
+func (s *SqliteStore) InTx(ctx context.Context, name string, fn func(ctx context.Context, tx Store) error) error {
+	if s.inTx {
+		return fn(ctx, s)
+	}
+	dbCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(dbCtx, nil)
+	if err != nil {
+		log.Printf("failed to acquire a connection: %v", err)
+		return status.Errorf(codes.Internal, "an unexpected error occured")
+	}
+
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("failed to rollback %s transaction: %v", name, err)
+		}
+	}(tx)
+
+	if err = fn(dbCtx, &SqliteStore{Queries: s.Queries.WithTx(tx), DB: s.DB, inTx: true}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return status.Errorf(codes.Internal, "failed to commit %s transaction", name)
+	}
+	return nil
+}
+
+// sqliteError turns misses and conflicts into the store's ErrNotFound and ErrConflict.
+func sqliteError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	var sqliteErr *driver.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return fmt.Errorf("%w: %v", ErrConflict, sqliteErr)
+		}
+	}
+	return err
+}
+
+// sqliteTag turns the result of a statement into the command tag postgres would have returned for it.
+func sqliteTag(op string, result sql.Result, err error) (pgconn.CommandTag, error) {
+	if err != nil {
+		return pgconn.CommandTag{}, sqliteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return memTag(op, int(rows)), nil
+}
+
+func toSqliteTime(t pgtype.Timestamptz) time.Time {
+	return t.Time.UTC()
+}
+
+func toSqliteNullTime(t pgtype.Timestamptz) sql.NullTime {
+	return sql.NullTime{Time: t.Time.UTC(), Valid: t.Valid}
+}
+
+func toSqliteInt(i pgtype.Int8) sql.NullInt64 {
+	return sql.NullInt64{Int64: i.Int64, Valid: i.Valid}
+}
+
+func toSqliteBool(b pgtype.Bool) sql.NullBool {
+	return sql.NullBool{Bool: b.Bool, Valid: b.Valid}
+}
+
+func fromSqliteTime(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+func fromSqliteNullTime(t sql.NullTime) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t.Time, Valid: t.Valid}
+}
+
+func fromSqliteInt(i sql.NullInt64) pgtype.Int8 {
+	return pgtype.Int8{Int64: i.Int64, Valid: i.Valid}
+}
+
+func fromSqliteBool(b sql.NullBool) pgtype.Bool {
+	return pgtype.Bool{Bool: b.Bool, Valid: b.Valid}
+}
+
+func fromSqliteText(t sql.NullString) pgtype.Text {
+	return pgtype.Text{String: t.String, Valid: t.Valid}
+}
+
+func fromSqliteStep(step sqlite.GameStep) db.GameStep {
+	return db.GameStep{
+		GameID:  step.GameID,
+		Ord:     int32(step.Ord),
+		MoveRow: int32(step.MoveRow),
+		MoveCol: int32(step.MoveCol),
+		Board:   step.Board,
+		XTurn:   step.XTurn,
+		Result:  int32(step.Result),
+		MadeOn:  fromSqliteTime(step.MadeOn),
+	}
+}
+
+func fromSqliteSteps(rows []sqlite.GameStep, err error) ([]db.GameStep, error) {
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	steps := make([]db.GameStep, 0, len(rows))
+	for _, row := range rows {
+		steps = append(steps, fromSqliteStep(row))
+	}
+	return steps, nil
+}
+
+func toSqliteDate(d pgtype.Date) time.Time {
+	return time.Date(d.Time.Year(), d.Time.Month(), d.Time.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func fromSqlitePlayer(row sqlite.PlayerAccount) db.PlayerAccount {
+	return db.PlayerAccount{
+		ID:               row.ID,
+		Username:         row.Username,
+		Passwd:           row.Passwd,
+		Salt:             row.Salt,
+		PuzzleStreak:     int32(row.PuzzleStreak),
+		BestPuzzleStreak: int32(row.BestPuzzleStreak),
+		DeletedOn:        fromSqliteNullTime(row.DeletedOn),
+		Role:             int32(row.Role),
+		RegisteredOn:     fromSqliteTime(row.RegisteredOn),
+		BannedOn:         fromSqliteNullTime(row.BannedOn),
+		BanReason:        row.BanReason,
+	}
+}
+
+func fromSqlitePuzzle(row sqlite.Puzzle) db.Puzzle {
+	return db.Puzzle{
+		ID:          row.ID,
+		BoardState:  row.BoardState,
+		XTurn:       row.XTurn,
+		Depth:       int32(row.Depth),
+		SolutionRow: int32(row.SolutionRow),
+		SolutionCol: int32(row.SolutionCol),
+		Difficulty:  int32(row.Difficulty),
+		CreatedOn:   fromSqliteTime(row.CreatedOn),
+	}
+}
+
+func (s *SqliteStore) InsertPlayer(ctx context.Context, arg db.InsertPlayerParams) (db.InsertPlayerRow, error) {
+	row, err := s.Queries.InsertPlayer(ctx, sqlite.InsertPlayerParams{
+		Username:     arg.Username,
+		Passwd:       arg.Passwd,
+		Salt:         arg.Salt,
+		RegisteredOn: time.Now().UTC(),
+	})
+	if err != nil {
+		return db.InsertPlayerRow{}, sqliteError(err)
+	}
+	return db.InsertPlayerRow{ID: row.ID, Username: row.Username}, nil
+}
+
+func (s *SqliteStore) GetPlayer(ctx context.Context, id int64) (db.GetPlayerRow, error) {
+	row, err := s.Queries.GetPlayer(ctx, id)
+	if err != nil {
+		return db.GetPlayerRow{}, sqliteError(err)
+	}
+	return db.GetPlayerRow{ID: row.ID, Username: row.Username}, nil
+}
+
+func (s *SqliteStore) GetAccount(ctx context.Context, id int64) (db.GetAccountRow, error) {
+	row, err := s.Queries.GetAccount(ctx, id)
+	if err != nil {
+		return db.GetAccountRow{}, sqliteError(err)
+	}
+	return db.GetAccountRow{ID: row.ID, Username: row.Username, Passwd: row.Passwd}, nil
+}
+
+func (s *SqliteStore) GetAccountByName(ctx context.Context, upper interface{}) (db.GetAccountByNameRow, error) {
+	row, err := s.Queries.GetAccountByName(ctx, fmt.Sprint(upper))
+	if err != nil {
+		return db.GetAccountByNameRow{}, sqliteError(err)
+	}
+	return db.GetAccountByNameRow{ID: row.ID, Username: row.Username, Passwd: row.Passwd, BannedOn: fromSqliteNullTime(row.BannedOn)}, nil
+}
+
+func (s *SqliteStore) GetPlayers(ctx context.Context, arg db.GetPlayersParams) ([]db.GetPlayersRow, error) {
+	rows, err := s.Queries.GetPlayers(ctx, sqlite.GetPlayersParams{Now: time.Now().UTC(), ID: arg.ID, Limit: int64(arg.Limit)})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	players := make([]db.GetPlayersRow, 0, len(rows))
+	for _, row := range rows {
+		players = append(players, db.GetPlayersRow{ID: row.ID, Username: row.Username, Cnt: row.Cnt})
+	}
+	return players, nil
+}
+
+func (s *SqliteStore) UpdatePassword(ctx context.Context, arg db.UpdatePasswordParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.UpdatePassword(ctx, sqlite.UpdatePasswordParams{Passwd: arg.Passwd, ID: arg.ID})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) AnonymizePlayer(ctx context.Context, arg db.AnonymizePlayerParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.AnonymizePlayer(ctx, sqlite.AnonymizePlayerParams{DeletedOn: toSqliteNullTime(arg.DeletedOn), ID: arg.ID})
+	return sqliteTag("UPDATE", result, err)
+}
+
+// LockPlayer only checks the player exists, the store runs one transaction at a time so it holds the lock already.
+func (s *SqliteStore) LockPlayer(ctx context.Context, id int64) (int64, error) {
+	id, err := s.Queries.LockPlayer(ctx, id)
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetPlayerRole(ctx context.Context, id int64) (db.GetPlayerRoleRow, error) {
+	row, err := s.Queries.GetPlayerRole(ctx, id)
+	if err != nil {
+		return db.GetPlayerRoleRow{}, sqliteError(err)
+	}
+	return db.GetPlayerRoleRow{Role: int32(row.Role), BannedOn: fromSqliteNullTime(row.BannedOn)}, nil
+}
+
+func (s *SqliteStore) SetRole(ctx context.Context, arg db.SetRoleParams) (db.PlayerAccount, error) {
+	row, err := s.Queries.SetRole(ctx, sqlite.SetRoleParams{Role: int64(arg.Role), ID: arg.ID})
+	if err != nil {
+		return db.PlayerAccount{}, sqliteError(err)
+	}
+	return fromSqlitePlayer(row), nil
+}
+
+func (s *SqliteStore) BanPlayer(ctx context.Context, arg db.BanPlayerParams) (db.PlayerAccount, error) {
+	row, err := s.Queries.BanPlayer(ctx, sqlite.BanPlayerParams{
+		BannedOn:  toSqliteNullTime(arg.BannedOn),
+		BanReason: arg.BanReason,
+		ID:        arg.ID,
+	})
+	if err != nil {
+		return db.PlayerAccount{}, sqliteError(err)
+	}
+	return fromSqlitePlayer(row), nil
+}
+
+func (s *SqliteStore) UnbanPlayer(ctx context.Context, id int64) (db.PlayerAccount, error) {
+	row, err := s.Queries.UnbanPlayer(ctx, id)
+	if err != nil {
+		return db.PlayerAccount{}, sqliteError(err)
+	}
+	return fromSqlitePlayer(row), nil
+}
+
+func (s *SqliteStore) GetRegistrations(ctx context.Context, arg db.GetRegistrationsParams) ([]db.PlayerAccount, error) {
+	rows, err := s.Queries.GetRegistrations(ctx, sqlite.GetRegistrationsParams{
+		Since:  toSqliteTime(arg.Since),
+		Offset: int64(arg.Offset),
+		Limit:  int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	players := make([]db.PlayerAccount, 0, len(rows))
+	for _, row := range rows {
+		players = append(players, fromSqlitePlayer(row))
+	}
+	return players, nil
+}
+
+func (s *SqliteStore) InsertSession(ctx context.Context, arg db.InsertSessionParams) (int64, error) {
+	id, err := s.Queries.InsertSession(ctx, sqlite.InsertSessionParams{
+		TokenHash:  arg.TokenHash,
+		PlayerID:   arg.PlayerID,
+		Device:     arg.Device,
+		CreatedOn:  toSqliteTime(arg.CreatedOn),
+		LastSeenOn: toSqliteTime(arg.LastSeenOn),
+		ExpiresOn:  toSqliteTime(arg.ExpiresOn),
+	})
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetSession(ctx context.Context, tokenHash string) (db.GetSessionRow, error) {
+	row, err := s.Queries.GetSession(ctx, sqlite.GetSessionParams{TokenHash: tokenHash, Now: time.Now().UTC()})
+	if err != nil {
+		return db.GetSessionRow{}, sqliteError(err)
+	}
+	return db.GetSessionRow{ID: row.ID, Username: row.Username, SessionID: row.SessionID}, nil
+}
+
+func (s *SqliteStore) RotateSession(ctx context.Context, arg db.RotateSessionParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.RotateSession(ctx, sqlite.RotateSessionParams{
+		NewTokenHash: arg.NewTokenHash,
+		LastSeenOn:   toSqliteTime(arg.LastSeenOn),
+		ExpiresOn:    toSqliteTime(arg.ExpiresOn),
+		ID:           arg.ID,
+		TokenHash:    arg.TokenHash,
+	})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) GetPlayerSessions(ctx context.Context, playerID int64) ([]db.GetPlayerSessionsRow, error) {
+	rows, err := s.Queries.GetPlayerSessions(ctx, sqlite.GetPlayerSessionsParams{PlayerID: playerID, Now: time.Now().UTC()})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	sessions := make([]db.GetPlayerSessionsRow, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, db.GetPlayerSessionsRow{
+			ID:         row.ID,
+			Device:     row.Device,
+			CreatedOn:  fromSqliteTime(row.CreatedOn),
+			LastSeenOn: fromSqliteTime(row.LastSeenOn),
+			ExpiresOn:  fromSqliteTime(row.ExpiresOn),
+		})
+	}
+	return sessions, nil
+}
+
+func (s *SqliteStore) DeleteSession(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	result, err := s.Queries.DeleteSession(ctx, id)
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) DeletePlayerSessions(ctx context.Context, playerID int64) (pgconn.CommandTag, error) {
+	result, err := s.Queries.DeletePlayerSessions(ctx, playerID)
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) DeleteOtherSessions(ctx context.Context, arg db.DeleteOtherSessionsParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.DeleteOtherSessions(ctx, sqlite.DeleteOtherSessionsParams{PlayerID: arg.PlayerID, ID: arg.ID})
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) PurgeExpiredSessions(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	result, err := s.Queries.PurgeExpiredSessions(ctx, toSqliteTime(expiresOn))
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) InsertGame(ctx context.Context, arg db.InsertGameParams) (int64, error) {
+	id, err := s.Queries.InsertGame(ctx, sqlite.InsertGameParams{
+		XPlayer:           arg.XPlayer,
+		OPlayer:           toSqliteInt(arg.OPlayer),
+		BoardState:        arg.BoardState,
+		StartState:        arg.StartState,
+		XTurn:             toSqliteBool(arg.XTurn),
+		UpdatedOn:         toSqliteTime(arg.UpdatedOn),
+		StartedOn:         toSqliteTime(arg.StartedOn),
+		HintBudget:        int64(arg.HintBudget),
+		TakebacksDisabled: arg.TakebacksDisabled,
+		RematchOf:         toSqliteInt(arg.RematchOf),
+	})
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetGame(ctx context.Context, id int64) (db.GetGameRow, error) {
+	row, err := s.Queries.GetGame(ctx, id)
+	if err != nil {
+		return db.GetGameRow{}, sqliteError(err)
+	}
+	return db.GetGameRow{
+		ID:                row.ID,
+		XPlayer:           row.XPlayer,
+		OPlayer:           fromSqliteInt(row.OPlayer),
+		BoardState:        row.BoardState,
+		StartState:        row.StartState,
+		XTurn:             fromSqliteBool(row.XTurn),
+		UpdatedOn:         fromSqliteTime(row.UpdatedOn),
+		StartedOn:         fromSqliteTime(row.StartedOn),
+		Result:            int32(row.Result),
+		HintBudget:        int32(row.HintBudget),
+		XHintsUsed:        int32(row.XHintsUsed),
+		OHintsUsed:        int32(row.OHintsUsed),
+		Opening:           int32(row.Opening),
+		TakebacksDisabled: row.TakebacksDisabled,
+		TakebackBy:        fromSqliteInt(row.TakebackBy),
+		RematchOf:         fromSqliteInt(row.RematchOf),
+		RematchBy:         fromSqliteInt(row.RematchBy),
+		EndedBy:           fromSqliteInt(row.EndedBy),
+		Version:           int32(row.Version),
+		XPlayerName:       fromSqliteText(row.XPlayerName),
+		OPlayerName:       fromSqliteText(row.OPlayerName),
+		XPlayerDeleted:    row.XPlayerDeleted,
+		OPlayerDeleted:    row.OPlayerDeleted,
+	}, nil
+}
+
+func (s *SqliteStore) GetGames(ctx context.Context, arg db.GetGamesParams) ([]db.GetGamesRow, error) {
+	rows, err := s.Queries.GetGames(ctx, sqlite.GetGamesParams{
+		ID:      arg.ID,
+		XPlayer: toSqliteInt(arg.XPlayer),
+		OPlayer: toSqliteInt(arg.OPlayer),
+		Limit:   int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	games := make([]db.GetGamesRow, 0, len(rows))
+	for _, row := range rows {
+		games = append(games, db.GetGamesRow{
+			ID:                row.ID,
+			XPlayer:           row.XPlayer,
+			OPlayer:           fromSqliteInt(row.OPlayer),
+			BoardState:        row.BoardState,
+			StartState:        row.StartState,
+			XTurn:             fromSqliteBool(row.XTurn),
+			UpdatedOn:         fromSqliteTime(row.UpdatedOn),
+			StartedOn:         fromSqliteTime(row.StartedOn),
+			Result:            int32(row.Result),
+			HintBudget:        int32(row.HintBudget),
+			XHintsUsed:        int32(row.XHintsUsed),
+			OHintsUsed:        int32(row.OHintsUsed),
+			Opening:           int32(row.Opening),
+			TakebacksDisabled: row.TakebacksDisabled,
+			TakebackBy:        fromSqliteInt(row.TakebackBy),
+			RematchOf:         fromSqliteInt(row.RematchOf),
+			RematchBy:         fromSqliteInt(row.RematchBy),
+			EndedBy:           fromSqliteInt(row.EndedBy),
+			XPlayerName:       fromSqliteText(row.XPlayerName),
+			OPlayerName:       fromSqliteText(row.OPlayerName),
+			XPlayerDeleted:    row.XPlayerDeleted,
+			OPlayerDeleted:    row.OPlayerDeleted,
+		})
+	}
+	return games, nil
+}
+
+func (s *SqliteStore) GetGamePositions(ctx context.Context, arg db.GetGamePositionsParams) ([]db.GetGamePositionsRow, error) {
+	rows, err := s.Queries.GetGamePositions(ctx, sqlite.GetGamePositionsParams{ID: arg.ID, Limit: int64(arg.Limit)})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	positions := make([]db.GetGamePositionsRow, 0, len(rows))
+	for _, row := range rows {
+		positions = append(positions, db.GetGamePositionsRow{
+			ID:         row.ID,
+			BoardState: row.BoardState,
+			XTurn:      fromSqliteBool(row.XTurn),
+			Result:     int32(row.Result),
+			EndedBy:    fromSqliteInt(row.EndedBy),
+		})
+	}
+	return positions, nil
+}
+
+func (s *SqliteStore) UpdateGame(ctx context.Context, arg db.UpdateGameParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.UpdateGame(ctx, sqlite.UpdateGameParams{
+		BoardState: arg.BoardState,
+		XTurn:      toSqliteBool(arg.XTurn),
+		UpdatedOn:  toSqliteTime(arg.UpdatedOn),
+		Result:     int64(arg.Result),
+		Opening:    sql.NullInt64{Int64: int64(arg.Opening.Int32), Valid: arg.Opening.Valid},
+		ID:         arg.ID,
+		Version:    int64(arg.Version),
+	})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) UseHint(ctx context.Context, arg db.UseHintParams) (pgconn.CommandTag, error) {
+	var xTurn int64
+	if arg.XTurn {
+		xTurn = 1
+	}
+	result, err := s.Queries.UseHint(ctx, sqlite.UseHintParams{XTurn: xTurn, ID: arg.ID})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) RequestTakeback(ctx context.Context, arg db.RequestTakebackParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.RequestTakeback(ctx, sqlite.RequestTakebackParams{TakebackBy: toSqliteInt(arg.TakebackBy), ID: arg.ID})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) ClearTakeback(ctx context.Context, arg db.ClearTakebackParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.ClearTakeback(ctx, sqlite.ClearTakebackParams{ID: arg.ID, TakebackBy: toSqliteInt(arg.TakebackBy)})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) OfferRematch(ctx context.Context, arg db.OfferRematchParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.OfferRematch(ctx, sqlite.OfferRematchParams{RematchBy: toSqliteInt(arg.RematchBy), ID: arg.ID})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) ClearRematch(ctx context.Context, arg db.ClearRematchParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.ClearRematch(ctx, sqlite.ClearRematchParams{ID: arg.ID, RematchBy: toSqliteInt(arg.RematchBy)})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) GetRematch(ctx context.Context, rematchOf pgtype.Int8) (int64, error) {
+	id, err := s.Queries.GetRematch(ctx, toSqliteInt(rematchOf))
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetSeries(ctx context.Context, id int64) ([]db.GetSeriesRow, error) {
+	rows, err := s.Queries.GetSeries(ctx, id)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	series := make([]db.GetSeriesRow, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, db.GetSeriesRow{ID: row.ID, XPlayer: row.XPlayer, OPlayer: fromSqliteInt(row.OPlayer), Result: int32(row.Result)})
+	}
+	return series, nil
+}
+
+func (s *SqliteStore) ForceEndGame(ctx context.Context, arg db.ForceEndGameParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.ForceEndGame(ctx, sqlite.ForceEndGameParams{
+		Result:    int64(arg.Result),
+		UpdatedOn: toSqliteTime(arg.UpdatedOn),
+		EndedBy:   toSqliteInt(arg.EndedBy),
+		ID:        arg.ID,
+	})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) GetOpeningStats(ctx context.Context, player pgtype.Int8) ([]db.GetOpeningStatsRow, error) {
+	rows, err := s.Queries.GetOpeningStats(ctx, toSqliteInt(player))
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	stats := make([]db.GetOpeningStatsRow, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, db.GetOpeningStatsRow{
+			Opening:   int32(row.Opening),
+			Result:    int32(row.Result),
+			PlayerIsX: row.PlayerIsX,
+			Games:     row.Games,
+		})
+	}
+	return stats, nil
+}
+
+func (s *SqliteStore) GetServerStats(ctx context.Context, since pgtype.Timestamptz) (db.GetServerStatsRow, error) {
+	row, err := s.Queries.GetServerStats(ctx, sqlite.GetServerStatsParams{Since: toSqliteTime(since), Now: time.Now().UTC()})
+	if err != nil {
+		return db.GetServerStatsRow{}, sqliteError(err)
+	}
+	return db.GetServerStatsRow{
+		Players:       row.Players,
+		BannedPlayers: row.BannedPlayers,
+		Registrations: row.Registrations,
+		Sessions:      row.Sessions,
+		Games:         row.Games,
+		ActiveGames:   row.ActiveGames,
+		Messages:      row.Messages,
+	}, nil
+}
+
+func (s *SqliteStore) InsertStep(ctx context.Context, arg db.InsertStepParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.InsertStep(ctx, sqlite.InsertStepParams{
+		GameID:  arg.GameID,
+		MoveRow: int64(arg.MoveRow),
+		MoveCol: int64(arg.MoveCol),
+		Board:   arg.Board,
+		XTurn:   arg.XTurn,
+		Result:  int64(arg.Result),
+		MadeOn:  time.Now().UTC(),
+	})
+	return sqliteTag("INSERT 0", result, err)
+}
+
+func (s *SqliteStore) GetGameSteps(ctx context.Context, gameID int64) ([]db.GameStep, error) {
+	return fromSqliteSteps(s.Queries.GetGameSteps(ctx, gameID))
+}
+
+func (s *SqliteStore) GetGamesSteps(ctx context.Context, gameids []int64) ([]db.GameStep, error) {
+	if len(gameids) == 0 {
+		return nil, nil
+	}
+	return fromSqliteSteps(s.Queries.GetGamesSteps(ctx, gameids))
+}
+
+func (s *SqliteStore) GetLastStep(ctx context.Context, gameID int64) (db.GameStep, error) {
+	row, err := s.Queries.GetLastStep(ctx, gameID)
+	if err != nil {
+		return db.GameStep{}, sqliteError(err)
+	}
+	return fromSqliteStep(row), nil
+}
+
+func (s *SqliteStore) DeleteStepsFrom(ctx context.Context, arg db.DeleteStepsFromParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.DeleteStepsFrom(ctx, sqlite.DeleteStepsFromParams{GameID: arg.GameID, Ord: int64(arg.Ord)})
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) GetAnalyses(ctx context.Context, gameID int64) ([]db.Analysis, error) {
+	rows, err := s.Queries.GetAnalyses(ctx, gameID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	analyses := make([]db.Analysis, 0, len(rows))
+	for _, row := range rows {
+		analyses = append(analyses, db.Analysis{
+			GameID:         row.GameID,
+			Ord:            int32(row.Ord),
+			MoveRow:        int32(row.MoveRow),
+			MoveCol:        int32(row.MoveCol),
+			XMoved:         row.XMoved,
+			BestResult:     int32(row.BestResult),
+			BestDistance:   int32(row.BestDistance),
+			PlayedResult:   int32(row.PlayedResult),
+			PlayedDistance: int32(row.PlayedDistance),
+			Annotation:     int32(row.Annotation),
+			AnalyzedOn:     fromSqliteTime(row.AnalyzedOn),
+		})
+	}
+	return analyses, nil
+}
+
+func (s *SqliteStore) InsertAnalysis(ctx context.Context, arg db.InsertAnalysisParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.InsertAnalysis(ctx, sqlite.InsertAnalysisParams{
+		GameID:         arg.GameID,
+		Ord:            int64(arg.Ord),
+		MoveRow:        int64(arg.MoveRow),
+		MoveCol:        int64(arg.MoveCol),
+		XMoved:         arg.XMoved,
+		BestResult:     int64(arg.BestResult),
+		BestDistance:   int64(arg.BestDistance),
+		PlayedResult:   int64(arg.PlayedResult),
+		PlayedDistance: int64(arg.PlayedDistance),
+		Annotation:     int64(arg.Annotation),
+		AnalyzedOn:     time.Now().UTC(),
+	})
+	return sqliteTag("INSERT 0", result, err)
+}
+
+func (s *SqliteStore) GetPuzzle(ctx context.Context, id int64) (db.Puzzle, error) {
+	row, err := s.Queries.GetPuzzle(ctx, id)
+	if err != nil {
+		return db.Puzzle{}, sqliteError(err)
+	}
+	return fromSqlitePuzzle(row), nil
+}
+
+func (s *SqliteStore) InsertPuzzle(ctx context.Context, arg db.InsertPuzzleParams) (int64, error) {
+	id, err := s.Queries.InsertPuzzle(ctx, sqlite.InsertPuzzleParams{
+		BoardState:  arg.BoardState,
+		XTurn:       arg.XTurn,
+		Depth:       int64(arg.Depth),
+		SolutionRow: int64(arg.SolutionRow),
+		SolutionCol: int64(arg.SolutionCol),
+		Difficulty:  int64(arg.Difficulty),
+		CreatedOn:   time.Now().UTC(),
+	})
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetPuzzleAttempt(ctx context.Context, arg db.GetPuzzleAttemptParams) (db.PuzzleAttempt, error) {
+	row, err := s.Queries.GetPuzzleAttempt(ctx, sqlite.GetPuzzleAttemptParams{PuzzleID: arg.PuzzleID, PlayerID: arg.PlayerID})
+	if err != nil {
+		return db.PuzzleAttempt{}, sqliteError(err)
+	}
+	return db.PuzzleAttempt{
+		PuzzleID:    row.PuzzleID,
+		PlayerID:    row.PlayerID,
+		BoardState:  row.BoardState,
+		XTurn:       row.XTurn,
+		MovesLeft:   int32(row.MovesLeft),
+		Status:      int32(row.Status),
+		StartedOn:   fromSqliteTime(row.StartedOn),
+		UpdatedOn:   fromSqliteTime(row.UpdatedOn),
+		CompletedOn: fromSqliteNullTime(row.CompletedOn),
+	}, nil
+}
+
+func (s *SqliteStore) InsertPuzzleAttempt(ctx context.Context, arg db.InsertPuzzleAttemptParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.InsertPuzzleAttempt(ctx, sqlite.InsertPuzzleAttemptParams{
+		PuzzleID:   arg.PuzzleID,
+		PlayerID:   arg.PlayerID,
+		BoardState: arg.BoardState,
+		XTurn:      arg.XTurn,
+		MovesLeft:  int64(arg.MovesLeft),
+		Now:        time.Now().UTC(),
+	})
+	return sqliteTag("INSERT 0", result, err)
+}
+
+func (s *SqliteStore) UpdatePuzzleAttempt(ctx context.Context, arg db.UpdatePuzzleAttemptParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.UpdatePuzzleAttempt(ctx, sqlite.UpdatePuzzleAttemptParams{
+		BoardState:     arg.BoardState,
+		XTurn:          arg.XTurn,
+		MovesLeft:      int64(arg.MovesLeft),
+		Status:         int64(arg.Status),
+		UpdatedOn:      toSqliteTime(arg.UpdatedOn),
+		CompletedOn:    toSqliteNullTime(arg.CompletedOn),
+		PuzzleID:       arg.PuzzleID,
+		PlayerID:       arg.PlayerID,
+		PrevBoardState: arg.PrevBoardState,
+	})
+	return sqliteTag("UPDATE", result, err)
+}
+
+func (s *SqliteStore) GetPuzzleStreak(ctx context.Context, id int64) (db.GetPuzzleStreakRow, error) {
+	row, err := s.Queries.GetPuzzleStreak(ctx, id)
+	if err != nil {
+		return db.GetPuzzleStreakRow{}, sqliteError(err)
+	}
+	return db.GetPuzzleStreakRow{PuzzleStreak: int32(row.PuzzleStreak), BestPuzzleStreak: int32(row.BestPuzzleStreak)}, nil
+}
+
+func (s *SqliteStore) UpdatePuzzleStreak(ctx context.Context, arg db.UpdatePuzzleStreakParams) (db.UpdatePuzzleStreakRow, error) {
+	row, err := s.Queries.UpdatePuzzleStreak(ctx, sqlite.UpdatePuzzleStreakParams{Solved: arg.Solved, ID: arg.ID})
+	if err != nil {
+		return db.UpdatePuzzleStreakRow{}, sqliteError(err)
+	}
+	return db.UpdatePuzzleStreakRow{PuzzleStreak: int32(row.PuzzleStreak), BestPuzzleStreak: int32(row.BestPuzzleStreak)}, nil
+}
+
+func (s *SqliteStore) GetDailyPuzzle(ctx context.Context, day pgtype.Date) (db.Puzzle, error) {
+	row, err := s.Queries.GetDailyPuzzle(ctx, toSqliteDate(day))
+	if err != nil {
+		return db.Puzzle{}, sqliteError(err)
+	}
+	return fromSqlitePuzzle(row), nil
+}
+
+func (s *SqliteStore) InsertDailyPuzzle(ctx context.Context, arg db.InsertDailyPuzzleParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.InsertDailyPuzzle(ctx, sqlite.InsertDailyPuzzleParams{Day: toSqliteDate(arg.Day), PuzzleID: arg.PuzzleID})
+	return sqliteTag("INSERT 0", result, err)
+}
+
+func (s *SqliteStore) GetDailyLeaderboard(ctx context.Context, arg db.GetDailyLeaderboardParams) ([]db.GetDailyLeaderboardRow, error) {
+	rows, err := s.Queries.GetDailyLeaderboard(ctx, sqlite.GetDailyLeaderboardParams{
+		Day:    toSqliteDate(arg.Day),
+		Status: int64(arg.Status),
+		Limit:  int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	leaders := make([]db.GetDailyLeaderboardRow, 0, len(rows))
+	for _, row := range rows {
+		leaders = append(leaders, db.GetDailyLeaderboardRow{
+			PlayerID:    row.PlayerID,
+			Username:    row.Username,
+			StartedOn:   fromSqliteTime(row.StartedOn),
+			CompletedOn: fromSqliteNullTime(row.CompletedOn),
+		})
+	}
+	return leaders, nil
+}
+
+func (s *SqliteStore) InsertMessage(ctx context.Context, arg db.InsertMessageParams) (int64, error) {
+	id, err := s.Queries.InsertMessage(ctx, sqlite.InsertMessageParams{
+		GameID:   arg.GameID,
+		PlayerID: arg.PlayerID,
+		Channel:  int64(arg.Channel),
+		Text:     arg.Text,
+		SentOn:   toSqliteTime(arg.SentOn),
+	})
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) GetGameMessages(ctx context.Context, gameID int64) ([]db.GetGameMessagesRow, error) {
+	rows, err := s.Queries.GetGameMessages(ctx, gameID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	messages := make([]db.GetGameMessagesRow, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, db.GetGameMessagesRow{
+			ID:       row.ID,
+			GameID:   row.GameID,
+			PlayerID: row.PlayerID,
+			Channel:  int32(row.Channel),
+			Text:     row.Text,
+			SentOn:   fromSqliteTime(row.SentOn),
+			Username: row.Username,
+		})
+	}
+	return messages, nil
+}
+
+func (s *SqliteStore) GetMessage(ctx context.Context, id int64) (db.GetMessageRow, error) {
+	row, err := s.Queries.GetMessage(ctx, id)
+	if err != nil {
+		return db.GetMessageRow{}, sqliteError(err)
+	}
+	return db.GetMessageRow{
+		ID:       row.ID,
+		GameID:   row.GameID,
+		PlayerID: row.PlayerID,
+		Channel:  int32(row.Channel),
+		Text:     row.Text,
+		SentOn:   fromSqliteTime(row.SentOn),
+		Username: row.Username,
+	}, nil
+}
+
+func (s *SqliteStore) GetMessagesAfter(ctx context.Context, arg db.GetMessagesAfterParams) ([]db.GetMessagesAfterRow, error) {
+	rows, err := s.Queries.GetMessagesAfter(ctx, sqlite.GetMessagesAfterParams{
+		GameID:  arg.GameID,
+		Channel: int64(arg.Channel),
+		ID:      arg.ID,
+		Limit:   int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	messages := make([]db.GetMessagesAfterRow, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, db.GetMessagesAfterRow{
+			ID:       row.ID,
+			GameID:   row.GameID,
+			PlayerID: row.PlayerID,
+			Channel:  int32(row.Channel),
+			Text:     row.Text,
+			SentOn:   fromSqliteTime(row.SentOn),
+			Username: row.Username,
+		})
+	}
+	return messages, nil
+}
+
+func (s *SqliteStore) DeleteMessage(ctx context.Context, id int64) (pgconn.CommandTag, error) {
+	result, err := s.Queries.DeleteMessage(ctx, id)
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) CountRecentMessages(ctx context.Context, arg db.CountRecentMessagesParams) (int64, error) {
+	count, err := s.Queries.CountRecentMessages(ctx, sqlite.CountRecentMessagesParams{PlayerID: arg.PlayerID, SentOn: toSqliteTime(arg.SentOn)})
+	return count, sqliteError(err)
+}
+
+func (s *SqliteStore) GetLoginLockout(ctx context.Context, subjects []string) (pgtype.Timestamptz, error) {
+	if len(subjects) == 0 {
+		return pgtype.Timestamptz{}, nil
+	}
+	lockedUntil, err := s.Queries.GetLoginLockout(ctx, subjects)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgtype.Timestamptz{}, nil
+	}
+	if err != nil {
+		return pgtype.Timestamptz{}, sqliteError(err)
+	}
+	return fromSqliteTime(lockedUntil), nil
+}
+
+// RecordLoginFailure resets failures older than the window before counting this one, sqlite cannot take
+// the window as a parameter of the upsert itself.
+func (s *SqliteStore) RecordLoginFailure(ctx context.Context, arg db.RecordLoginFailureParams) (int32, error) {
+	var failures int64
+	err := s.InTx(ctx, "RecordLoginFailure", func(ctx context.Context, tx Store) error {
+		qtx := tx.(*SqliteStore).Queries
+		resetParams := sqlite.ResetLoginFailuresParams{Subject: arg.Subject, WindowStart: toSqliteTime(arg.WindowStart)}
+		if err := qtx.ResetLoginFailures(ctx, resetParams); err != nil {
+			return sqliteError(err)
+		}
+		var err error
+		failures, err = qtx.RecordLoginFailure(ctx, sqlite.RecordLoginFailureParams{Subject: arg.Subject, FailedOn: toSqliteTime(arg.FailedOn)})
+		return sqliteError(err)
+	})
+	return int32(failures), err
+}
+
+func (s *SqliteStore) LockLogin(ctx context.Context, arg db.LockLoginParams) error {
+	return sqliteError(s.Queries.LockLogin(ctx, sqlite.LockLoginParams{LockedUntil: toSqliteTime(arg.LockedUntil), Subject: arg.Subject}))
+}
+
+func (s *SqliteStore) ClearLoginFailures(ctx context.Context, subject string) error {
+	return sqliteError(s.Queries.ClearLoginFailures(ctx, subject))
+}
+
+func (s *SqliteStore) PurgeLoginFailures(ctx context.Context, arg db.PurgeLoginFailuresParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.PurgeLoginFailures(ctx, sqlite.PurgeLoginFailuresParams{WindowStart: toSqliteTime(arg.WindowStart), Now: toSqliteTime(arg.Now)})
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	row, err := s.Queries.GetIdempotencyKey(ctx, sqlite.GetIdempotencyKeyParams{PlayerID: arg.PlayerID, Key: arg.Key, ExpiresOn: toSqliteTime(arg.ExpiresOn)})
+	if err != nil {
+		return db.IdempotencyKey{}, sqliteError(err)
+	}
+	return db.IdempotencyKey{
+		PlayerID:     row.PlayerID,
+		Key:          row.Key,
+		Method:       row.Method,
+		RequestHash:  row.RequestHash,
+		ResponseType: row.ResponseType,
+		Response:     row.Response,
+		CreatedOn:    fromSqliteTime(row.CreatedOn),
+		ExpiresOn:    fromSqliteTime(row.ExpiresOn),
+	}, nil
+}
+
+func (s *SqliteStore) ReserveIdempotencyKey(ctx context.Context, arg db.ReserveIdempotencyKeyParams) (pgconn.CommandTag, error) {
+	result, err := s.Queries.ReserveIdempotencyKey(ctx, sqlite.ReserveIdempotencyKeyParams{
+		PlayerID:    arg.PlayerID,
+		Key:         arg.Key,
+		Method:      arg.Method,
+		RequestHash: arg.RequestHash,
+		CreatedOn:   toSqliteTime(arg.CreatedOn),
+		ExpiresOn:   toSqliteTime(arg.ExpiresOn),
+	})
+	return sqliteTag("INSERT 0", result, err)
+}
+
+func (s *SqliteStore) StoreIdempotentResponse(ctx context.Context, arg db.StoreIdempotentResponseParams) error {
+	return sqliteError(s.Queries.StoreIdempotentResponse(ctx, sqlite.StoreIdempotentResponseParams{
+		ResponseType: arg.ResponseType,
+		Response:     arg.Response,
+		PlayerID:     arg.PlayerID,
+		Key:          arg.Key,
+	}))
+}
+
+func (s *SqliteStore) ReleaseIdempotencyKey(ctx context.Context, arg db.ReleaseIdempotencyKeyParams) error {
+	return sqliteError(s.Queries.ReleaseIdempotencyKey(ctx, sqlite.ReleaseIdempotencyKeyParams{PlayerID: arg.PlayerID, Key: arg.Key}))
+}
+
+func (s *SqliteStore) PurgeIdempotencyKeys(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error) {
+	result, err := s.Queries.PurgeIdempotencyKeys(ctx, toSqliteTime(expiresOn))
+	return sqliteTag("DELETE", result, err)
+}
+
+func (s *SqliteStore) InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) (int64, error) {
+	id, err := s.Queries.InsertAuditEvent(ctx, sqlite.InsertAuditEventParams{
+		ActorID:   toSqliteInt(arg.ActorID),
+		EventType: arg.EventType,
+		TargetID:  toSqliteInt(arg.TargetID),
+		Peer:      arg.Peer,
+		Method:    arg.Method,
+		Payload:   string(arg.Payload),
+		CreatedOn: toSqliteTime(arg.CreatedOn),
+	})
+	return id, sqliteError(err)
+}
+
+func (s *SqliteStore) QueryAuditLog(ctx context.Context, arg db.QueryAuditLogParams) ([]db.AuditEvent, error) {
+	rows, err := s.Queries.QueryAuditLog(ctx, sqlite.QueryAuditLogParams{
+		Since:     toSqliteTime(arg.Since),
+		Until:     toSqliteTime(arg.Until),
+		ActorId:   toSqliteInt(arg.ActorId),
+		EventType: sql.NullString{String: arg.EventType.String, Valid: arg.EventType.Valid},
+		Offset:    int64(arg.Offset),
+		Limit:     int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	events := make([]db.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, db.AuditEvent{
+			ID:        row.ID,
+			ActorID:   fromSqliteInt(row.ActorID),
+			EventType: row.EventType,
+			TargetID:  fromSqliteInt(row.TargetID),
+			Peer:      row.Peer,
+			Method:    row.Method,
+			Payload:   []byte(row.Payload),
+			CreatedOn: fromSqliteTime(row.CreatedOn),
+		})
+	}
+	return events, nil
+}
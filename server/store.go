@@ -0,0 +1,200 @@
+package server
+
+import (
+	"TicTacGo/db"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by a Store when the row asked for does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned by a Store when a row would break a unique key.
+	ErrConflict = errors.New("conflict")
+)
+
+// Store holds the players, sessions, games and steps, the analyses, puzzles and chat messages built on them,
+// along with the login failures, idempotency keys and audit events recorded while they are used. Its methods
+// match the sqlc queries of the same name, and every store reports misses as ErrNotFound and conflicts as
+// ErrConflict, so that callers need not know which one is behind them.
+type Store interface {
+	InsertPlayer(ctx context.Context, arg db.InsertPlayerParams) (db.InsertPlayerRow, error)
+	GetPlayer(ctx context.Context, id int64) (db.GetPlayerRow, error)
+	GetAccount(ctx context.Context, id int64) (db.GetAccountRow, error)
+	GetAccountByName(ctx context.Context, upper interface{}) (db.GetAccountByNameRow, error)
+	GetPlayers(ctx context.Context, arg db.GetPlayersParams) ([]db.GetPlayersRow, error)
+	UpdatePassword(ctx context.Context, arg db.UpdatePasswordParams) (pgconn.CommandTag, error)
+	AnonymizePlayer(ctx context.Context, arg db.AnonymizePlayerParams) (pgconn.CommandTag, error)
+	LockPlayer(ctx context.Context, id int64) (int64, error)
+	GetPlayerRole(ctx context.Context, id int64) (db.GetPlayerRoleRow, error)
+	SetRole(ctx context.Context, arg db.SetRoleParams) (db.PlayerAccount, error)
+	BanPlayer(ctx context.Context, arg db.BanPlayerParams) (db.PlayerAccount, error)
+	UnbanPlayer(ctx context.Context, id int64) (db.PlayerAccount, error)
+	GetRegistrations(ctx context.Context, arg db.GetRegistrationsParams) ([]db.PlayerAccount, error)
+
+	InsertSession(ctx context.Context, arg db.InsertSessionParams) (int64, error)
+	GetSession(ctx context.Context, tokenHash string) (db.GetSessionRow, error)
+	RotateSession(ctx context.Context, arg db.RotateSessionParams) (pgconn.CommandTag, error)
+	GetPlayerSessions(ctx context.Context, playerID int64) ([]db.GetPlayerSessionsRow, error)
+	DeleteSession(ctx context.Context, id int64) (pgconn.CommandTag, error)
+	DeletePlayerSessions(ctx context.Context, playerID int64) (pgconn.CommandTag, error)
+	DeleteOtherSessions(ctx context.Context, arg db.DeleteOtherSessionsParams) (pgconn.CommandTag, error)
+	PurgeExpiredSessions(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error)
+
+	InsertGame(ctx context.Context, arg db.InsertGameParams) (int64, error)
+	GetGame(ctx context.Context, id int64) (db.GetGameRow, error)
+	GetGames(ctx context.Context, arg db.GetGamesParams) ([]db.GetGamesRow, error)
+	GetGamePositions(ctx context.Context, arg db.GetGamePositionsParams) ([]db.GetGamePositionsRow, error)
+	UpdateGame(ctx context.Context, arg db.UpdateGameParams) (pgconn.CommandTag, error)
+	UseHint(ctx context.Context, arg db.UseHintParams) (pgconn.CommandTag, error)
+	RequestTakeback(ctx context.Context, arg db.RequestTakebackParams) (pgconn.CommandTag, error)
+	ClearTakeback(ctx context.Context, arg db.ClearTakebackParams) (pgconn.CommandTag, error)
+	OfferRematch(ctx context.Context, arg db.OfferRematchParams) (pgconn.CommandTag, error)
+	ClearRematch(ctx context.Context, arg db.ClearRematchParams) (pgconn.CommandTag, error)
+	GetRematch(ctx context.Context, rematchOf pgtype.Int8) (int64, error)
+	GetSeries(ctx context.Context, id int64) ([]db.GetSeriesRow, error)
+	ForceEndGame(ctx context.Context, arg db.ForceEndGameParams) (pgconn.CommandTag, error)
+	GetOpeningStats(ctx context.Context, player pgtype.Int8) ([]db.GetOpeningStatsRow, error)
+	GetServerStats(ctx context.Context, since pgtype.Timestamptz) (db.GetServerStatsRow, error)
+
+	InsertStep(ctx context.Context, arg db.InsertStepParams) (pgconn.CommandTag, error)
+	GetGameSteps(ctx context.Context, gameID int64) ([]db.GameStep, error)
+	GetGamesSteps(ctx context.Context, gameids []int64) ([]db.GameStep, error)
+	GetLastStep(ctx context.Context, gameID int64) (db.GameStep, error)
+	DeleteStepsFrom(ctx context.Context, arg db.DeleteStepsFromParams) (pgconn.CommandTag, error)
+
+	GetAnalyses(ctx context.Context, gameID int64) ([]db.Analysis, error)
+	InsertAnalysis(ctx context.Context, arg db.InsertAnalysisParams) (pgconn.CommandTag, error)
+
+	GetPuzzle(ctx context.Context, id int64) (db.Puzzle, error)
+	InsertPuzzle(ctx context.Context, arg db.InsertPuzzleParams) (int64, error)
+	GetPuzzleAttempt(ctx context.Context, arg db.GetPuzzleAttemptParams) (db.PuzzleAttempt, error)
+	InsertPuzzleAttempt(ctx context.Context, arg db.InsertPuzzleAttemptParams) (pgconn.CommandTag, error)
+	UpdatePuzzleAttempt(ctx context.Context, arg db.UpdatePuzzleAttemptParams) (pgconn.CommandTag, error)
+	GetPuzzleStreak(ctx context.Context, id int64) (db.GetPuzzleStreakRow, error)
+	UpdatePuzzleStreak(ctx context.Context, arg db.UpdatePuzzleStreakParams) (db.UpdatePuzzleStreakRow, error)
+	GetDailyPuzzle(ctx context.Context, day pgtype.Date) (db.Puzzle, error)
+	InsertDailyPuzzle(ctx context.Context, arg db.InsertDailyPuzzleParams) (pgconn.CommandTag, error)
+	GetDailyLeaderboard(ctx context.Context, arg db.GetDailyLeaderboardParams) ([]db.GetDailyLeaderboardRow, error)
+
+	InsertMessage(ctx context.Context, arg db.InsertMessageParams) (int64, error)
+	GetGameMessages(ctx context.Context, gameID int64) ([]db.GetGameMessagesRow, error)
+	GetMessage(ctx context.Context, id int64) (db.GetMessageRow, error)
+	GetMessagesAfter(ctx context.Context, arg db.GetMessagesAfterParams) ([]db.GetMessagesAfterRow, error)
+	DeleteMessage(ctx context.Context, id int64) (pgconn.CommandTag, error)
+	CountRecentMessages(ctx context.Context, arg db.CountRecentMessagesParams) (int64, error)
+
+	GetLoginLockout(ctx context.Context, subjects []string) (pgtype.Timestamptz, error)
+	RecordLoginFailure(ctx context.Context, arg db.RecordLoginFailureParams) (int32, error)
+	LockLogin(ctx context.Context, arg db.LockLoginParams) error
+	ClearLoginFailures(ctx context.Context, subject string) error
+	PurgeLoginFailures(ctx context.Context, arg db.PurgeLoginFailuresParams) (pgconn.CommandTag, error)
+
+	GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)
+	ReserveIdempotencyKey(ctx context.Context, arg db.ReserveIdempotencyKeyParams) (pgconn.CommandTag, error)
+	StoreIdempotentResponse(ctx context.Context, arg db.StoreIdempotentResponseParams) error
+	ReleaseIdempotencyKey(ctx context.Context, arg db.ReleaseIdempotencyKeyParams) error
+	PurgeIdempotencyKeys(ctx context.Context, expiresOn pgtype.Timestamptz) (pgconn.CommandTag, error)
+
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) (int64, error)
+	QueryAuditLog(ctx context.Context, arg db.QueryAuditLogParams) ([]db.AuditEvent, error)
+
+	// InTx runs fn against a store whose changes are kept only if fn returns nil, the name is used in logs.
+	InTx(ctx context.Context, name string, fn func(ctx context.Context, tx Store) error) error
+}
+
+// PgStore is the Store backed by postgres, running the sqlc queries on the pool.
+type PgStore struct {
+	*db.Queries
+	Pool *pgxpool.Pool
+}
+
+func NewPgStore(pool *pgxpool.Pool) *PgStore {
+	return &PgStore{Queries: db.New(pgConn{pool}), Pool: pool}
+}
+
+// pgConn runs the sqlc queries on a pool or transaction, turning misses and conflicts into the store's
+// ErrNotFound and ErrConflict.
+type pgConn struct {
+	db.DBTX
+}
+
+func (c pgConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	tag, err := c.DBTX.Exec(ctx, sql, args...)
+	return tag, pgError(err)
+}
+
+func (c pgConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := c.DBTX.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return pgRows{rows}, nil
+}
+
+func (c pgConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return pgRow{c.DBTX.QueryRow(ctx, sql, args...)}
+}
+
+type pgRows struct {
+	pgx.Rows
+}
+
+func (r pgRows) Err() error {
+	return pgError(r.Rows.Err())
+}
+
+type pgRow struct {
+	pgx.Row
+}
+
+func (r pgRow) Scan(dest ...any) error {
+	return pgError(r.Row.Scan(dest...))
+}
+
+func pgError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("%w: %v", ErrConflict, pgErr)
+	}
+	return err
+}
+
+func (p *PgStore) InTx(ctx context.Context, name string, fn func(ctx context.Context, tx Store) error) error {
+	dbCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(dbCtx)
+	if err != nil {
+		log.Printf("failed to acquire a connection: %v", err)
+		return status.Errorf(codes.Internal, "an unexpected error occured")
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("failed to rollback %s transaction: %v", name, err)
+		}
+	}(tx, dbCtx)
+
+	if err = fn(dbCtx, &PgStore{Queries: db.New(pgConn{tx}), Pool: p.Pool}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(dbCtx); err != nil {
+		return status.Errorf(codes.Internal, "failed to commit %s transaction", name)
+	}
+	return nil
+}
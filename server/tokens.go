@@ -0,0 +1,146 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultAccessTTL is how long an access token is accepted, when no ttl is configured. Access tokens are
+// verified without the database, so a logout only takes effect for them once they expire.
+const DefaultAccessTTL = time.Minute * 15
+
+var ErrInvalidToken = errors.New("token is invalid")
+var ErrExpiredToken = errors.New("token is expired")
+
+// Claims are the signed contents of an access token, naming the player and the session it was issued to.
+type Claims struct {
+	ID        int64  `json:"sub"`
+	Username  string `json:"name"`
+	SessionID int64  `json:"sid"`
+	ExpiresOn int64  `json:"exp"`
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// TokenKeys holds the hmac keys by key id. Tokens are signed with the current key and verified with the
+// key named in their header, so a new key can be rolled out while tokens signed with the old one are still
+// accepted.
+type TokenKeys struct {
+	Current string
+	Keys    map[string][]byte
+}
+
+// ParseTokenKeys reads keys from a comma separated list of "kid:secret" pairs, the first key signs tokens.
+func ParseTokenKeys(s string) (TokenKeys, error) {
+	keys := TokenKeys{Keys: make(map[string][]byte)}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kid, secret, found := strings.Cut(pair, ":")
+		if !found || kid == "" || len(secret) < 32 {
+			return TokenKeys{}, fmt.Errorf("expected token key as 'kid:secret' with a secret of at least 32 chars, got key: %q", kid)
+		}
+		if _, ok := keys.Keys[kid]; ok {
+			return TokenKeys{}, fmt.Errorf("duplicate token key id: %q", kid)
+		}
+		if keys.Current == "" {
+			keys.Current = kid
+		}
+		keys.Keys[kid] = []byte(secret)
+	}
+	if keys.Current == "" {
+		return TokenKeys{}, errors.New("expected at least one token key")
+	}
+	return keys, nil
+}
+
+func sign(secret []byte, data string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign issues an access token for the claims with the current key.
+func (k TokenKeys) Sign(claims Claims) (string, error) {
+	secret, ok := k.Keys[k.Current]
+	if !ok {
+		return "", fmt.Errorf("no token key for current key id: %q", k.Current)
+	}
+	header, err := json.Marshal(tokenHeader{Alg: "HS256", Typ: "JWT", Kid: k.Current})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	data := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return data + "." + sign(secret, data), nil
+}
+
+// Verify checks the token's signature and expiry, returning its claims.
+func (k TokenKeys) Verify(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Claims{}, ErrInvalidToken
+	}
+	secret, ok := k.Keys[header.Kid]
+	if !ok {
+		return Claims{}, ErrInvalidToken
+	}
+	expected := sign(secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresOn {
+		return Claims{}, ErrExpiredToken
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// NewRefreshToken returns a random refresh token, which is handed to the client once and only stored hashed.
+func NewRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken hashes a refresh token for storage and lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
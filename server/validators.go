@@ -9,12 +9,71 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 const MinUsernameLen = 5
 const MaxUsernameLen = 20
 const MinPasswordLen = 5
 const MaxPasswordLen = 100
+const MaxHintBudget = 5
+const MaxLeaderboardLimit = 100
+const MaxMessageLen = 280
+const DefaultMessagesPerWindow = 5
+const DefaultMessageWindow = time.Second * 10
+const MaxReasonLen = 280
+const MaxRegistrationsPerPage = 100
+const MaxAuditEventsPerPage = 100
+
+const (
+	ChannelPlayers    int32 = 0
+	ChannelSpectators int32 = 1
+)
+
+// DefaultBlockedWords is the profanity filter used when none is configured.
+var DefaultBlockedWords = []string{"fuck", "shit", "bitch", "cunt", "asshole", "bastard", "dick"}
+
+// ParseBlockedWords reads a comma separated list of blocked words, falling back to the default list when
+// the list is empty.
+func ParseBlockedWords(s string) []string {
+	var words []string
+	for _, word := range strings.Split(s, ",") {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return DefaultBlockedWords
+	}
+	return words
+}
+
+// FindBlockedWord returns the first word of the text that is blocked, ignoring case.
+func FindBlockedWord(text string, blockedWords []string) (string, bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		if slices.Contains(blockedWords, word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+func passwordViolation(field string, password string) *errdetails.BadRequest_FieldViolation {
+	if len(password) < MinPasswordLen || len(password) > MaxPasswordLen {
+		return &errdetails.BadRequest_FieldViolation{
+			Field:  field,
+			Reason: fmt.Sprintf("password must be between %d and %d chars", MinPasswordLen, MaxPasswordLen),
+		}
+	}
+	return nil
+}
 
 func ValidateRegistration(in *pb.CredentialsReq) error {
 	var violations []*errdetails.BadRequest_FieldViolation
@@ -25,10 +84,31 @@ func ValidateRegistration(in *pb.CredentialsReq) error {
 		}
 		violations = append(violations, violation)
 	}
-	if len(in.Password) < MinPasswordLen || len(in.Password) > MaxPasswordLen {
+	if violation := passwordViolation("password", in.Password); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "registration credentials are invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateChangePassword(in *pb.ChangePasswordReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if violation := passwordViolation("newPassword", in.NewPassword); violation != nil {
+		violations = append(violations, violation)
+	}
+	if in.NewPassword == in.OldPassword {
 		violation := &errdetails.BadRequest_FieldViolation{
-			Field:  "password",
-			Reason: fmt.Sprintf("password must be between %d and %d chars", MinUsernameLen, MinUsernameLen),
+			Field:  "newPassword",
+			Reason: "new password must differ from the old password",
 		}
 		violations = append(violations, violation)
 	}
@@ -38,7 +118,113 @@ func ValidateRegistration(in *pb.CredentialsReq) error {
 	}
 
 	violation := &errdetails.BadRequest{FieldViolations: violations}
-	st, err := status.New(codes.InvalidArgument, "registration credentials are invalid").WithDetails(violation)
+	st, err := status.New(codes.InvalidArgument, "new password is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateCreateGame(in *pb.CreateGameReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.HintBudget < 0 || in.HintBudget > MaxHintBudget {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "hintBudget",
+			Reason: fmt.Sprintf("hint budget must be between %d and %d", 0, MaxHintBudget),
+		}
+		violations = append(violations, violation)
+	}
+	if in.BoardState != "" {
+		var reason string
+		board, err := tictactoe.ParseBoard(in.BoardState)
+		if err != nil {
+			reason = err.Error()
+		} else if err = tictactoe.ValidatePosition(board, in.XTurn); err != nil {
+			reason = fmt.Sprintf("starting position cannot be reached in play: %v", err)
+		} else if tictactoe.GetResult(board) != tictactoe.Playing {
+			reason = "starting position is already decided"
+		}
+		if reason != "" {
+			violation := &errdetails.BadRequest_FieldViolation{
+				Field:  "boardState",
+				Reason: reason,
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "game options are invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateGetDailyLeaderboard(in *pb.GetDailyLeaderboardReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.Day != "" {
+		if _, err := time.Parse(DayLayout, in.Day); err != nil {
+			violation := &errdetails.BadRequest_FieldViolation{
+				Field:  "day",
+				Reason: fmt.Sprintf("day must be formatted as %s", DayLayout),
+			}
+			violations = append(violations, violation)
+		}
+	}
+	if in.Limit < 0 || in.Limit > MaxLeaderboardLimit {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "limit",
+			Reason: fmt.Sprintf("limit must be between %d and %d", 0, MaxLeaderboardLimit),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "leaderboard request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// ValidateMoveOrd rejects a move made against a stale view of the game. The current position is attached
+// to the error, so that the client can resync without reading the game again.
+func ValidateMoveOrd(gameRow db.GetGameRow, lastStep *db.GameStep, expected int32) error {
+	current := &pb.Step{
+		GameId: gameRow.ID,
+		Ord:    -1,
+		Board:  gameRow.BoardState,
+		XTurn:  gameRow.XTurn.Bool,
+		Result: gameRow.Result,
+	}
+	if lastStep != nil {
+		current.Ord = lastStep.Ord
+		current.MoveRow = lastStep.MoveRow
+		current.MoveCol = lastStep.MoveCol
+	}
+	next := current.Ord + 1
+	if expected == next {
+		return nil
+	}
+
+	log.Printf("cannot make move on game: %d, expected ord: %d, next ord: %d", gameRow.ID, expected, next)
+	violation := &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+		{
+			Type:        "stale",
+			Subject:     "ord",
+			Description: fmt.Sprintf("cannot make move on game: %d, expected ord: %d, next ord: %d", gameRow.ID, expected, next),
+		},
+	}}
+	st, err := status.New(codes.FailedPrecondition, "move was made against a stale game").WithDetails(violation, current)
 	if err != nil {
 		return err
 	}
@@ -86,3 +272,300 @@ func ValidateMakeMove(gameRow db.GetGameRow, moverID int64) error {
 	}
 	return st.Err()
 }
+
+// ValidateTakeback checks that a player of the game can request a takeback, or respond to the opponent's
+// request when responding.
+func ValidateTakeback(gameRow db.GetGameRow, playerID int64, responding bool) error {
+	if !IsSeated(gameRow, playerID) {
+		log.Printf("cannot take back on game: %d, player: %d is not playing", gameRow.ID, playerID)
+		return status.Errorf(codes.PermissionDenied, "player: %d is not playing game: %d", playerID, gameRow.ID)
+	}
+
+	var violations []*errdetails.PreconditionFailure_Violation
+	if gameRow.TakebacksDisabled {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "takebacks",
+			Description: fmt.Sprintf("cannot take back on game: %d, takebacks are disabled", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+	if gameRow.Result != tictactoe.Playing {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "state",
+			Description: fmt.Sprintf("cannot take back on game: %d, game is not in play", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+	if !responding && gameRow.TakebackBy.Valid {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "takeback",
+			Description: fmt.Sprintf("cannot request takeback on game: %d, a takeback is already pending", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+	if responding && (!gameRow.TakebackBy.Valid || gameRow.TakebackBy.Int64 == playerID) {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "takeback",
+			Description: fmt.Sprintf("cannot respond to takeback on game: %d, no takeback was requested by the opponent", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	log.Printf("cannot take back on game: %d, violations: %v", gameRow.ID, violations)
+
+	violation := &errdetails.PreconditionFailure{Violations: violations}
+	st, err := status.New(codes.FailedPrecondition, "takeback is not allowed").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// ValidateRematch checks that a player of a finished game can offer a rematch, or respond to the opponent's
+// offer when responding.
+func ValidateRematch(gameRow db.GetGameRow, playerID int64, responding bool) error {
+	if !IsSeated(gameRow, playerID) {
+		log.Printf("cannot rematch game: %d, player: %d is not playing", gameRow.ID, playerID)
+		return status.Errorf(codes.PermissionDenied, "player: %d is not playing game: %d", playerID, gameRow.ID)
+	}
+
+	var violations []*errdetails.PreconditionFailure_Violation
+	if !gameRow.OPlayer.Valid || gameRow.Result == tictactoe.Playing {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "state",
+			Description: fmt.Sprintf("cannot rematch game: %d, game is not finished", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+	if !responding && gameRow.RematchBy.Valid {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "rematch",
+			Description: fmt.Sprintf("cannot offer rematch on game: %d, a rematch is already offered", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+	if responding && (!gameRow.RematchBy.Valid || gameRow.RematchBy.Int64 == playerID) {
+		violation := &errdetails.PreconditionFailure_Violation{
+			Type:        "validation",
+			Subject:     "rematch",
+			Description: fmt.Sprintf("cannot respond to rematch on game: %d, no rematch was offered by the opponent", gameRow.ID),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	log.Printf("cannot rematch game: %d, violations: %v", gameRow.ID, violations)
+
+	violation := &errdetails.PreconditionFailure{Violations: violations}
+	st, err := status.New(codes.FailedPrecondition, "rematch is not allowed").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateSendMessage(in *pb.SendMessageReq, blockedWords []string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	text := strings.TrimSpace(in.Text)
+	if text == "" || utf8.RuneCountInString(text) > MaxMessageLen {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "text",
+			Reason: fmt.Sprintf("text must be between %d and %d chars", 1, MaxMessageLen),
+		}
+		violations = append(violations, violation)
+	}
+	if word, ok := FindBlockedWord(text, blockedWords); ok {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "text",
+			Reason: fmt.Sprintf("text contains a blocked word: %s", word),
+		}
+		violations = append(violations, violation)
+	}
+	if in.Channel != ChannelPlayers && in.Channel != ChannelSpectators {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "channel",
+			Reason: fmt.Sprintf("channel must be %d for players or %d for spectators", ChannelPlayers, ChannelSpectators),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "message is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func reasonViolation(reason string) *errdetails.BadRequest_FieldViolation {
+	if strings.TrimSpace(reason) == "" || utf8.RuneCountInString(reason) > MaxReasonLen {
+		return &errdetails.BadRequest_FieldViolation{
+			Field:  "reason",
+			Reason: fmt.Sprintf("reason must be between %d and %d chars", 1, MaxReasonLen),
+		}
+	}
+	return nil
+}
+
+func ValidateBanPlayer(in *pb.BanPlayerReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if violation := reasonViolation(in.Reason); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "ban request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateSetRole(in *pb.SetRoleReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.Role < RolePlayer || in.Role > RoleAdmin {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "role",
+			Reason: fmt.Sprintf("role must be between %d and %d", RolePlayer, RoleAdmin),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "role request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateForceEndGame(in *pb.ForceEndGameReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.Result != tictactoe.XWon && in.Result != tictactoe.OWon && in.Result != tictactoe.Draw {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "result",
+			Reason: fmt.Sprintf("result must be one of %d, %d or %d", tictactoe.XWon, tictactoe.OWon, tictactoe.Draw),
+		}
+		violations = append(violations, violation)
+	}
+	if violation := reasonViolation(in.Reason); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "force end request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateDeleteMessage(in *pb.DeleteMessageReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if violation := reasonViolation(in.Reason); violation != nil {
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "delete message request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateGetRegistrations(in *pb.GetRegistrationsReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.Page < 1 {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "page",
+			Reason: "page must be at least 1",
+		}
+		violations = append(violations, violation)
+	}
+	if in.PerPage < 1 || in.PerPage > MaxRegistrationsPerPage {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "perPage",
+			Reason: fmt.Sprintf("perPage must be between %d and %d", 1, MaxRegistrationsPerPage),
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "registrations request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+func ValidateQueryAuditLog(in *pb.QueryAuditLogReq) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.Page < 1 {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "page",
+			Reason: "page must be at least 1",
+		}
+		violations = append(violations, violation)
+	}
+	if in.PerPage < 1 || in.PerPage > MaxAuditEventsPerPage {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "perPage",
+			Reason: fmt.Sprintf("perPage must be between %d and %d", 1, MaxAuditEventsPerPage),
+		}
+		violations = append(violations, violation)
+	}
+	if in.Since != nil && in.Until != nil && !in.Until.AsTime().After(in.Since.AsTime()) {
+		violation := &errdetails.BadRequest_FieldViolation{
+			Field:  "until",
+			Reason: "until must be after since",
+		}
+		violations = append(violations, violation)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest{FieldViolations: violations}
+	st, err := status.New(codes.InvalidArgument, "audit log request is invalid").WithDetails(violation)
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
@@ -0,0 +1,80 @@
+package tictactoe
+
+import "errors"
+
+const (
+	BestMove   int32 = 0
+	Inaccuracy int32 = 1
+	Blunder    int32 = 2
+)
+
+var ErrGameOver = errors.New("game has already ended")
+
+type MoveAnalysis struct {
+	Tile
+	XMoved     bool
+	Best       Evaluation
+	Played     Evaluation
+	Annotation int32
+}
+
+// AnalyzeMove compares the evaluation after a move with the best evaluation available
+// before it. A move that changes the theoretical result is a blunder, and a move that
+// keeps the result but wins slower (or loses faster) is an inaccuracy.
+func AnalyzeMove(board Board, xTurn bool, tile Tile) (MoveAnalysis, error) {
+	if GetResult(board) != Playing {
+		return MoveAnalysis{}, ErrGameOver
+	}
+
+	next, turn, err := MoveBoard(board, xTurn, tile.Row, tile.Col, TileValue(xTurn))
+	if err != nil {
+		return MoveAnalysis{}, err
+	}
+
+	best := Solve(board, xTurn).Evaluation
+	played := Solve(next, turn).Evaluation
+	played.Distance++
+
+	annotation := BestMove
+	if played.Result != best.Result {
+		annotation = Blunder
+	} else if played.Distance != best.Distance {
+		annotation = Inaccuracy
+	}
+
+	return MoveAnalysis{Tile: tile, XMoved: xTurn, Best: best, Played: played, Annotation: annotation}, nil
+}
+
+// Accuracy is the percentage of a player's moves that were annotated as the best move.
+func Accuracy(moves []MoveAnalysis, xMoved bool) float32 {
+	var total, best int
+	for _, move := range moves {
+		if move.XMoved != xMoved {
+			continue
+		}
+		total++
+		if move.Annotation == BestMove {
+			best++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float32(best) / float32(total) * 100
+}
+
+// AnalyzeMoves replays a sequence of moves from a starting position and annotates each one.
+func AnalyzeMoves(board Board, xTurn bool, tiles []Tile) ([]MoveAnalysis, error) {
+	var moves []MoveAnalysis
+	for _, tile := range tiles {
+		move, err := AnalyzeMove(board, xTurn, tile)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+
+		board[tile.Row][tile.Col] = TileValue(xTurn)
+		xTurn = !xTurn
+	}
+	return moves, nil
+}
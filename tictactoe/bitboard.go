@@ -0,0 +1,154 @@
+package tictactoe
+
+import (
+	"math/bits"
+)
+
+// Bitboard stores a board as one bitmask per side, bit i is set when the tile at row-major index i
+// holds that side's mark.
+type Bitboard struct {
+	X uint16
+	O uint16
+}
+
+const fullMask uint16 = 0b111111111
+
+var (
+	// winMasks holds one mask per line in winLines
+	winMasks = buildWinMasks()
+	// wonMasks reports for every 9 bit mask whether it contains a complete line
+	wonMasks = buildWonMasks()
+	// ternary holds the base 3 value of every 9 bit mask with each set bit counted as 1
+	ternary = buildTernary()
+)
+
+func buildWinMasks() [8]uint16 {
+	var masks [8]uint16
+	for i, line := range winLines {
+		for _, tile := range line {
+			masks[i] |= 1 << GetIndex(tile)
+		}
+	}
+	return masks
+}
+
+func buildWonMasks() [512]bool {
+	var won [512]bool
+	for mask := range won {
+		for _, winMask := range winMasks {
+			if uint16(mask)&winMask == winMask {
+				won[mask] = true
+			}
+		}
+	}
+	return won
+}
+
+func buildTernary() [512]uint16 {
+	var values [512]uint16
+	for mask := range values {
+		for i := range 9 {
+			values[mask] *= 3
+			if mask&(1<<i) != 0 {
+				values[mask]++
+			}
+		}
+	}
+	return values
+}
+
+func FromBoard(board Board) Bitboard {
+	var b Bitboard
+	for _, tile := range allLines {
+		switch board[tile.Row][tile.Col] {
+		case X:
+			b.X |= 1 << GetIndex(tile)
+		case O:
+			b.O |= 1 << GetIndex(tile)
+		}
+	}
+	return b
+}
+
+func (b Bitboard) Board() Board {
+	var board Board
+	for _, tile := range allLines {
+		bit := uint16(1) << GetIndex(tile)
+		if b.X&bit != 0 {
+			board[tile.Row][tile.Col] = X
+		} else if b.O&bit != 0 {
+			board[tile.Row][tile.Col] = O
+		}
+	}
+	return board
+}
+
+func ParseBitboard(s string) (Bitboard, error) {
+	board, err := ParseBoard(s)
+	if err != nil {
+		return Bitboard{}, err
+	}
+	return FromBoard(board), nil
+}
+
+func (b Bitboard) String() string {
+	return BoardToString(b.Board())
+}
+
+// Result checks both sides against the win lookup and counts marks to detect a full board.
+func (b Bitboard) Result() int32 {
+	switch {
+	case wonMasks[b.X]:
+		return XWon
+	case wonMasks[b.O]:
+		return OWon
+	case bits.OnesCount16(b.X|b.O) == 9:
+		return Draw
+	default:
+		return Playing
+	}
+}
+
+// Empty returns the mask of tiles that hold no mark.
+func (b Bitboard) Empty() uint16 {
+	return ^(b.X | b.O) & fullMask
+}
+
+// Move marks the tile at the index for the side to move, without checking that it is empty.
+func (b Bitboard) Move(index int, xTurn bool) Bitboard {
+	if xTurn {
+		b.X |= 1 << index
+	} else {
+		b.O |= 1 << index
+	}
+	return b
+}
+
+// Turn returns the side to move for a position reached by alternating moves with x first.
+func (b Bitboard) Turn() bool {
+	return bits.OnesCount16(b.X) == bits.OnesCount16(b.O)
+}
+
+// Encode packs the position into a base 3 number, reading tiles in row-major order.
+func (b Bitboard) Encode() uint16 {
+	return ternary[b.X] + 2*ternary[b.O]
+}
+
+func IndexTile(index int) Tile {
+	return Tile{Row: int32(index / 3), Col: int32(index % 3)}
+}
+
+// Decode unpacks a position packed by Encode.
+func Decode(code uint16) Bitboard {
+	var b Bitboard
+	for i := 8; i >= 0; i-- {
+		switch code % 3 {
+		case 1:
+			b.X |= 1 << i
+		case 2:
+			b.O |= 1 << i
+		}
+		code /= 3
+	}
+	return b
+}
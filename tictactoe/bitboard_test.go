@@ -0,0 +1,156 @@
+package tictactoe
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// loopGetResult is the per-tile implementation of GetResult that Bitboard.Result replaced, kept to
+// check and benchmark the bitboard against.
+func loopGetResult(board Board) int32 {
+	for _, line := range winLines {
+		value0 := board[line[0].Row][line[0].Col]
+		value1 := board[line[1].Row][line[1].Col]
+		value2 := board[line[2].Row][line[2].Col]
+		if value0 != E && value0 == value1 && value1 == value2 {
+			switch value0 {
+			case X:
+				return XWon
+			case O:
+				return OWon
+			}
+		}
+	}
+
+	isDraw := true
+	for _, tile := range allLines {
+		value := board[tile.Row][tile.Col]
+		isDraw = isDraw && value != E
+	}
+	if isDraw {
+		return Draw
+	}
+
+	return Playing
+}
+
+// loopCanonical is the board based implementation of Canonical that the bitboard replaced.
+func loopCanonical(board Board) (Board, Symmetry) {
+	encode := func(board Board) uint16 {
+		var code uint16
+		for _, tile := range allLines {
+			code = code*3 + uint16(board[tile.Row][tile.Col])
+		}
+		return code
+	}
+
+	canonical, symmetry := board, Identity
+	code := encode(board)
+	for _, s := range symmetries[1:] {
+		mapped := s.Board(board)
+		if mappedCode := encode(mapped); mappedCode < code {
+			canonical, symmetry, code = mapped, s, mappedCode
+		}
+	}
+	return canonical, symmetry
+}
+
+func TestBitboardConversion(t *testing.T) {
+	type Test struct {
+		boardStr    string
+		expBitboard Bitboard
+	}
+
+	tests := []Test{
+		{boardStr: "_________", expBitboard: Bitboard{}},
+		{boardStr: "x________", expBitboard: Bitboard{X: 0b000000001}},
+		{boardStr: "xoxxoooxo", expBitboard: Bitboard{X: 0b010001101, O: 0b101110010}},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			b, err := ParseBitboard(test.boardStr)
+			if err != nil {
+				t.Fatalf("failed to parse bitboard: %v", err)
+			}
+
+			assert.Equal(t, test.expBitboard, b)
+			assert.Equal(t, test.boardStr, b.String())
+			assert.Equal(t, b, FromBoard(b.Board()))
+		})
+	}
+}
+
+func TestBitboardMatchesBoard(t *testing.T) {
+	for _, b := range reachable() {
+		board := b.Board()
+
+		assert.Equal(t, loopGetResult(board), b.Result(), "board: %s", b)
+		assert.Equal(t, ImpliedTurn(board), b.Turn(), "board: %s", b)
+
+		expCanonical, expSymmetry := loopCanonical(board)
+		canonical, symmetry := b.Canonical()
+		assert.Equal(t, expCanonical, canonical.Board(), "board: %s", b)
+		assert.Equal(t, expSymmetry, symmetry, "board: %s", b)
+	}
+}
+
+func BenchmarkGetResult(b *testing.B) {
+	positions := reachable()
+	boards := make([]Board, len(positions))
+	for i, position := range positions {
+		boards[i] = position.Board()
+	}
+
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loopGetResult(boards[i%len(boards)])
+		}
+	})
+	b.Run("Bitboard", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			positions[i%len(positions)].Result()
+		}
+	})
+}
+
+func BenchmarkCanonical(b *testing.B) {
+	positions := reachable()
+	boards := make([]Board, len(positions))
+	for i, position := range positions {
+		boards[i] = position.Board()
+	}
+
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loopCanonical(boards[i%len(boards)])
+		}
+	})
+	b.Run("Bitboard", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			positions[i%len(positions)].Canonical()
+		}
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	positions := reachable()
+	boards := make([]Board, len(positions))
+	for i, position := range positions {
+		boards[i] = position.Board()
+	}
+
+	b.Run("Table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			board := boards[i%len(boards)]
+			Solve(board, ImpliedTurn(board))
+		}
+	})
+	b.Run("Search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			position := positions[i%len(positions)]
+			search(position, position.Turn())
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package tictactoe
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	DifficultyEasy   int32 = 1
+	DifficultyMedium int32 = 2
+	DifficultyHard   int32 = 3
+)
+
+type GeneratedPuzzle struct {
+	Board      Board
+	XTurn      bool
+	Depth      int32
+	Solution   Tile
+	Difficulty int32
+}
+
+// GeneratePuzzles searches one position from every symmetry class for a forced win in exactly depth moves
+// with a single winning first move. Puzzles are returned in a stable order.
+func GeneratePuzzles(depth int32) []GeneratedPuzzle {
+	codes := make([]uint16, 0, len(table))
+	for code := range table {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	var puzzles []GeneratedPuzzle
+	for _, code := range codes {
+		b := Decode(code)
+		xTurn := b.Turn()
+		if b.Result() != Playing {
+			continue
+		}
+
+		solution := Solve(b.Board(), xTurn)
+		if solution.Result != winFor(xTurn) || solution.Distance != 2*depth-1 || len(solution.Moves) != 1 {
+			continue
+		}
+
+		puzzle := GeneratedPuzzle{
+			Board:      b.Board(),
+			XTurn:      xTurn,
+			Depth:      depth,
+			Solution:   solution.Moves[0],
+			Difficulty: Grade(b, xTurn, depth),
+		}
+		puzzles = append(puzzles, puzzle)
+	}
+	return puzzles
+}
+
+// Grade rates a puzzle mostly by its depth, and then by its decoys, the other moves that still avoid a loss
+// and so do not look wrong at a glance.
+func Grade(b Bitboard, xTurn bool, depth int32) int32 {
+	var decoys int32
+	for empty := b.Empty(); empty != 0; empty &= empty - 1 {
+		next := b.Move(bits.TrailingZeros16(empty), xTurn)
+		eval := lookup(next, !xTurn)
+		if eval.Result != winFor(!xTurn) && !(eval.Result == winFor(xTurn) && eval.Distance+1 == 2*depth-1) {
+			decoys++
+		}
+	}
+
+	switch score := 3*depth + decoys; {
+	case score < 6:
+		return DifficultyEasy
+	case score < 9:
+		return DifficultyMedium
+	default:
+		return DifficultyHard
+	}
+}
@@ -0,0 +1,88 @@
+package tictactoe
+
+import "math/bits"
+
+// Opening names the first move of a game and the reply to it. Openings are matched up to symmetry,
+// so every corner opening is the same opening.
+type Opening struct {
+	ID     int32
+	Name   string
+	Family string
+}
+
+const (
+	OpeningNone                 int32 = 0
+	OpeningCornerCenter         int32 = 1
+	OpeningCornerOppositeCorner int32 = 2
+	OpeningCornerAdjacentCorner int32 = 3
+	OpeningCornerAdjacentEdge   int32 = 4
+	OpeningCornerFarEdge        int32 = 5
+	OpeningEdgeCenter           int32 = 6
+	OpeningEdgeAdjacentCorner   int32 = 7
+	OpeningEdgeFarCorner        int32 = 8
+	OpeningEdgeOppositeEdge     int32 = 9
+	OpeningEdgeAdjacentEdge     int32 = 10
+	OpeningCenterCorner         int32 = 11
+	OpeningCenterEdge           int32 = 12
+)
+
+const (
+	FamilyCorner = "corner"
+	FamilyEdge   = "edge"
+	FamilyCenter = "center"
+)
+
+// openingPositions lists every opening with the first move and reply of one position it covers.
+var openingPositions = []struct {
+	Opening
+	First Tile
+	Reply Tile
+}{
+	{Opening{OpeningCornerCenter, "center reply to corner", FamilyCorner}, topLeft, middle},
+	{Opening{OpeningCornerOppositeCorner, "opposite corner reply to corner", FamilyCorner}, topLeft, bottomRight},
+	{Opening{OpeningCornerAdjacentCorner, "adjacent corner reply to corner", FamilyCorner}, topLeft, topRight},
+	{Opening{OpeningCornerAdjacentEdge, "adjacent edge reply to corner", FamilyCorner}, topLeft, top},
+	{Opening{OpeningCornerFarEdge, "far edge reply to corner", FamilyCorner}, topLeft, bottom},
+	{Opening{OpeningEdgeCenter, "center reply to edge", FamilyEdge}, top, middle},
+	{Opening{OpeningEdgeAdjacentCorner, "adjacent corner reply to edge", FamilyEdge}, top, topLeft},
+	{Opening{OpeningEdgeFarCorner, "far corner reply to edge", FamilyEdge}, top, bottomLeft},
+	{Opening{OpeningEdgeOppositeEdge, "opposite edge reply to edge", FamilyEdge}, top, bottom},
+	{Opening{OpeningEdgeAdjacentEdge, "adjacent edge reply to edge", FamilyEdge}, top, left},
+	{Opening{OpeningCenterCorner, "corner reply to center", FamilyCenter}, middle, topLeft},
+	{Opening{OpeningCenterEdge, "edge reply to center", FamilyCenter}, middle, top},
+}
+
+// openingsByCode maps the canonical code of the position after the reply to its opening
+var openingsByCode = buildOpenings()
+
+func buildOpenings() map[uint16]Opening {
+	openings := make(map[uint16]Opening)
+	for _, opening := range openingPositions {
+		b := Bitboard{}.Move(int(GetIndex(opening.First)), true).Move(int(GetIndex(opening.Reply)), false)
+		canonical, _ := b.Canonical()
+		openings[canonical.Encode()] = opening.Opening
+	}
+	return openings
+}
+
+// GetOpening returns the opening by its id.
+func GetOpening(id int32) (Opening, bool) {
+	for _, opening := range openingPositions {
+		if opening.ID == id {
+			return opening.Opening, true
+		}
+	}
+	return Opening{}, false
+}
+
+// ClassifyOpening names the opening of a game from the board after x's first move and o's reply. Boards
+// with any other number of marks have no opening.
+func ClassifyOpening(board Board) (Opening, bool) {
+	b := FromBoard(board)
+	if bits.OnesCount16(b.X) != 1 || bits.OnesCount16(b.O) != 1 {
+		return Opening{}, false
+	}
+	canonical, _ := b.Canonical()
+	opening, ok := openingsByCode[canonical.Encode()]
+	return opening, ok
+}
@@ -0,0 +1,75 @@
+package tictactoe
+
+import "errors"
+
+const (
+	PuzzleSolving int32 = 0
+	PuzzleSolved  int32 = 1
+	PuzzleFailed  int32 = 2
+)
+
+var ErrNoForcedWin = errors.New("side to move has no forced win within the puzzle depth")
+
+// PuzzleStep is the state of a puzzle attempt after the solver's move and the defender's reply.
+type PuzzleStep struct {
+	Board     Board
+	XTurn     bool
+	MovesLeft int32
+	Status    int32
+	Reply     *Tile
+}
+
+// winFor returns the result of a win by the side to move.
+func winFor(xTurn bool) int32 {
+	if xTurn {
+		return XWon
+	}
+	return OWon
+}
+
+// ValidatePuzzle checks that the side to move in a legal position can force a win within depth moves.
+func ValidatePuzzle(board Board, xTurn bool, depth int32) error {
+	if err := ValidatePosition(board, xTurn); err != nil {
+		return err
+	}
+	if GetResult(board) != Playing {
+		return ErrGameOver
+	}
+	eval := Solve(board, xTurn).Evaluation
+	if eval.Result != winFor(xTurn) || eval.Distance > 2*depth-1 {
+		return ErrNoForcedWin
+	}
+	return nil
+}
+
+// PlayPuzzleMove applies the solver's move and, while the puzzle is still going, the defender's best reply.
+// A move fails the puzzle unless it keeps a forced win within the moves left.
+func PlayPuzzleMove(board Board, xTurn bool, movesLeft int32, tile Tile) (PuzzleStep, error) {
+	if GetResult(board) != Playing {
+		return PuzzleStep{}, ErrGameOver
+	}
+	next, turn, err := MoveBoard(board, xTurn, tile.Row, tile.Col, TileValue(xTurn))
+	if err != nil {
+		return PuzzleStep{}, err
+	}
+
+	step := PuzzleStep{Board: next, XTurn: turn, MovesLeft: movesLeft - 1}
+	won := winFor(xTurn)
+	if GetResult(next) == won {
+		step.Status = PuzzleSolved
+		return step, nil
+	}
+
+	// the defender moves next, so finishing within the moves left takes at most two plies per move
+	solution := Solve(next, turn)
+	if solution.Result != won || solution.Distance > 2*step.MovesLeft {
+		step.Status = PuzzleFailed
+		return step, nil
+	}
+
+	reply := solution.Moves[0]
+	step.Board[reply.Row][reply.Col] = TileValue(turn)
+	step.XTurn = xTurn
+	step.Reply = &reply
+	return step, nil
+}
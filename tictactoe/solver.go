@@ -0,0 +1,152 @@
+package tictactoe
+
+import (
+	"math/bits"
+)
+
+// Evaluation is the game-theoretic value of a position: the result reached under
+// perfect play from both sides, and the number of plies until that result.
+type Evaluation struct {
+	Result   int32
+	Distance int32
+}
+
+// Solution is the evaluation of a position together with every move that achieves it.
+type Solution struct {
+	Evaluation
+	Moves []Tile
+}
+
+// entry is the compact form of a Solution stored in the transposition table. Moves is a
+// bitmask over tile indices of the canonical board.
+type entry struct {
+	result   uint8
+	distance uint8
+	moves    uint16
+}
+
+var (
+	// table holds every reachable position, keyed by the code of its canonical board
+	table = make(map[uint16]entry)
+	// positionCount is the number of reachable positions before symmetry reduction
+	positionCount int
+)
+
+func init() {
+	seen := make(map[uint16]bool)
+	enumerate(Bitboard{}, true, seen)
+	positionCount = len(seen)
+}
+
+// enumerate walks every position reachable from the board, solving each canonical position once.
+func enumerate(b Bitboard, xTurn bool, seen map[uint16]bool) {
+	code := b.Encode()
+	if seen[code] {
+		return
+	}
+	seen[code] = true
+	lookup(b, xTurn)
+
+	if b.Result() != Playing {
+		return
+	}
+	for empty := b.Empty(); empty != 0; empty &= empty - 1 {
+		enumerate(b.Move(bits.TrailingZeros16(empty), xTurn), !xTurn, seen)
+	}
+}
+
+// lookup returns the evaluation of a position from the table, solving and storing its canonical
+// position if it has not been seen yet.
+func lookup(b Bitboard, xTurn bool) Evaluation {
+	canonical, _ := b.Canonical()
+	code := canonical.Encode()
+	if e, ok := table[code]; ok {
+		return Evaluation{Result: int32(e.result), Distance: int32(e.distance)}
+	}
+
+	eval, moves := solveWith(canonical, xTurn, lookup)
+	table[code] = entry{result: uint8(eval.Result), distance: uint8(eval.Distance), moves: moves}
+
+	return eval
+}
+
+// search evaluates a position without the table, for positions that cannot be reached in play.
+func search(b Bitboard, xTurn bool) Evaluation {
+	eval, _ := solveWith(b, xTurn, search)
+	return eval
+}
+
+// solveWith evaluates every move from the position and returns the best evaluation along with
+// the mask of moves that achieve it.
+func solveWith(b Bitboard, xTurn bool, evaluate func(Bitboard, bool) Evaluation) (Evaluation, uint16) {
+	result := b.Result()
+	if result != Playing {
+		return Evaluation{Result: result}, 0
+	}
+
+	var best Evaluation
+	var moves uint16
+	bestScore := 0
+	for empty := b.Empty(); empty != 0; empty &= empty - 1 {
+		index := bits.TrailingZeros16(empty)
+
+		child := evaluate(b.Move(index, xTurn), !xTurn)
+		child.Distance++
+
+		score := Score(child, xTurn)
+		if moves == 0 || score > bestScore {
+			bestScore = score
+			best, moves = child, 1<<index
+		} else if score == bestScore {
+			moves |= 1 << index
+		}
+	}
+	return best, moves
+}
+
+// Solve evaluates a position with perfect play. The winning side prefers the fastest
+// win and the losing side prefers the slowest loss.
+func Solve(board Board, xTurn bool) Solution {
+	b := FromBoard(board)
+	canonical, symmetry := b.Canonical()
+
+	e, ok := table[canonical.Encode()]
+	eval, moves := Evaluation{Result: int32(e.result), Distance: int32(e.distance)}, e.moves
+	if ok && b.Turn() == xTurn {
+		// best moves are stored against the canonical board, so map them back onto the original
+		moves = symmetryMasks[symmetry.Inverse()][moves]
+	} else {
+		eval, moves = solveWith(b, xTurn, search)
+	}
+
+	solution := Solution{Evaluation: eval}
+	for ; moves != 0; moves &= moves - 1 {
+		solution.Moves = append(solution.Moves, IndexTile(bits.TrailingZeros16(moves)))
+	}
+	return solution
+}
+
+// Score orders evaluations from the perspective of the side to move, higher is better.
+func Score(eval Evaluation, xTurn bool) int {
+	switch {
+	case eval.Result == XWon && xTurn || eval.Result == OWon && !xTurn:
+		return 100 - int(eval.Distance)
+	case eval.Result == XWon || eval.Result == OWon:
+		return -100 + int(eval.Distance)
+	default:
+		return 0
+	}
+}
+
+// TileValue returns the mark placed by the side to move.
+func TileValue(xTurn bool) uint8 {
+	if xTurn {
+		return X
+	}
+	return O
+}
+
+// ImpliedTurn returns the side to move for a position reached by alternating moves with x first.
+func ImpliedTurn(board Board) bool {
+	return FromBoard(board).Turn()
+}
@@ -0,0 +1,107 @@
+package tictactoe
+
+// Symmetry is one of the 8 rotations and reflections of the board.
+type Symmetry uint8
+
+const (
+	Identity Symmetry = iota
+	Rotate90
+	Rotate180
+	Rotate270
+	FlipRows
+	FlipCols
+	Transpose
+	AntiTranspose
+)
+
+var symmetries = []Symmetry{Identity, Rotate90, Rotate180, Rotate270, FlipRows, FlipCols, Transpose, AntiTranspose}
+
+// symmetryMasks maps every 9 bit mask to its image under each symmetry
+var symmetryMasks = buildSymmetryMasks()
+
+func buildSymmetryMasks() [8][512]uint16 {
+	var masks [8][512]uint16
+	for _, s := range symmetries {
+		for mask := range masks[s] {
+			for i := range 9 {
+				if mask&(1<<i) != 0 {
+					masks[s][mask] |= 1 << GetIndex(s.Tile(IndexTile(i)))
+				}
+			}
+		}
+	}
+	return masks
+}
+
+// Tile maps a tile to its position under the symmetry.
+func (s Symmetry) Tile(tile Tile) Tile {
+	switch s {
+	case Rotate90:
+		return Tile{Row: tile.Col, Col: 2 - tile.Row}
+	case Rotate180:
+		return Tile{Row: 2 - tile.Row, Col: 2 - tile.Col}
+	case Rotate270:
+		return Tile{Row: 2 - tile.Col, Col: tile.Row}
+	case FlipRows:
+		return Tile{Row: 2 - tile.Row, Col: tile.Col}
+	case FlipCols:
+		return Tile{Row: tile.Row, Col: 2 - tile.Col}
+	case Transpose:
+		return Tile{Row: tile.Col, Col: tile.Row}
+	case AntiTranspose:
+		return Tile{Row: 2 - tile.Col, Col: 2 - tile.Row}
+	default:
+		return tile
+	}
+}
+
+// Inverse returns the symmetry that undoes this one.
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	default:
+		return s
+	}
+}
+
+// Board maps every tile of the board to its position under the symmetry.
+func (s Symmetry) Board(board Board) Board {
+	var out Board
+	for _, tile := range allLines {
+		mapped := s.Tile(tile)
+		out[mapped.Row][mapped.Col] = board[tile.Row][tile.Col]
+	}
+	return out
+}
+
+// Bitboard maps both sides' masks to their positions under the symmetry.
+func (s Symmetry) Bitboard(b Bitboard) Bitboard {
+	return Bitboard{X: symmetryMasks[s][b.X], O: symmetryMasks[s][b.O]}
+}
+
+// Encode packs a board into a base 3 number, reading tiles in row-major order.
+func Encode(board Board) uint16 {
+	return FromBoard(board).Encode()
+}
+
+// Canonical returns the representative of the board under the 8 symmetries, the one with the
+// smallest encoding, and the symmetry that maps the board onto it.
+func Canonical(board Board) (Board, Symmetry) {
+	canonical, symmetry := FromBoard(board).Canonical()
+	return canonical.Board(), symmetry
+}
+
+func (b Bitboard) Canonical() (Bitboard, Symmetry) {
+	canonical, symmetry := b, Identity
+	code := b.Encode()
+	for _, s := range symmetries[1:] {
+		mapped := s.Bitboard(b)
+		if mappedCode := mapped.Encode(); mappedCode < code {
+			canonical, symmetry, code = mapped, s, mappedCode
+		}
+	}
+	return canonical, symmetry
+}
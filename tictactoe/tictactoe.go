@@ -66,7 +66,7 @@ func GetIndex(tile Tile) int32 {
 
 func MoveBoard(board Board, turn bool, row int32, col int32, value uint8) (Board, bool, error) {
 	tile := Tile{Row: row, Col: col}
-	if tile.Row < 0 || tile.Col < 0 || tile.Row > 2 && tile.Col > 2 {
+	if tile.Row < 0 || tile.Col < 0 || tile.Row > 2 || tile.Col > 2 {
 		return board, turn, ErrTile
 	}
 
@@ -80,30 +80,7 @@ func MoveBoard(board Board, turn bool, row int32, col int32, value uint8) (Board
 }
 
 func GetResult(board Board) int32 {
-	for _, line := range winLines {
-		value0 := board[line[0].Row][line[0].Col]
-		value1 := board[line[1].Row][line[1].Col]
-		value2 := board[line[2].Row][line[2].Col]
-		if value0 != E && value0 == value1 && value1 == value2 {
-			switch value0 {
-			case X:
-				return XWon
-			case O:
-				return OWon
-			}
-		}
-	}
-
-	isDraw := true
-	for _, tile := range allLines {
-		value := board[tile.Row][tile.Col]
-		isDraw = isDraw && value != E
-	}
-	if isDraw {
-		return Draw
-	}
-
-	return Playing
+	return FromBoard(board).Result()
 }
 
 func FmtBoard(board Board) string {
@@ -100,3 +100,322 @@ func TestFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestSolve(t *testing.T) {
+	type Test struct {
+		boardStr string
+		xTurn    bool
+		expEval  Evaluation
+		expMoves []Tile
+	}
+
+	tests := []Test{
+		{
+			boardStr: "_________",
+			xTurn:    true,
+			expEval:  Evaluation{Result: Draw, Distance: 9},
+			expMoves: allLines,
+		},
+		{
+			boardStr: "xx_oo____",
+			xTurn:    true,
+			expEval:  Evaluation{Result: XWon, Distance: 1},
+			expMoves: []Tile{topRight},
+		},
+		{
+			boardStr: "xx_oo___x",
+			xTurn:    false,
+			expEval:  Evaluation{Result: OWon, Distance: 1},
+			expMoves: []Tile{right},
+		},
+		{
+			boardStr: "xxxoo____",
+			xTurn:    false,
+			expEval:  Evaluation{Result: XWon, Distance: 0},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			board, err := ParseBoard(test.boardStr)
+			if err != nil {
+				t.Fatalf("failed to parse board: %v", err)
+			}
+
+			solution := Solve(board, test.xTurn)
+			assert.Equal(t, test.expEval, solution.Evaluation)
+			assert.Equal(t, test.expMoves, solution.Moves)
+		})
+	}
+}
+
+func TestAnalyzeMoves(t *testing.T) {
+	board, turn := NewGame()
+	tiles := []Tile{topLeft, left, top, middle, topRight}
+
+	moves, err := AnalyzeMoves(board, turn, tiles)
+	if err != nil {
+		t.Fatalf("failed to analyze moves: %v", err)
+	}
+
+	expMoves := []MoveAnalysis{
+		{Tile: topLeft, XMoved: true, Best: Evaluation{Draw, 9}, Played: Evaluation{Draw, 9}, Annotation: BestMove},
+		{Tile: left, XMoved: false, Best: Evaluation{Draw, 8}, Played: Evaluation{XWon, 6}, Annotation: Blunder},
+		{Tile: top, XMoved: true, Best: Evaluation{XWon, 5}, Played: Evaluation{XWon, 5}, Annotation: BestMove},
+		{Tile: middle, XMoved: false, Best: Evaluation{XWon, 4}, Played: Evaluation{XWon, 2}, Annotation: Inaccuracy},
+		{Tile: topRight, XMoved: true, Best: Evaluation{XWon, 1}, Played: Evaluation{XWon, 1}, Annotation: BestMove},
+	}
+	assert.Equal(t, expMoves, moves)
+	assert.Equal(t, float32(100), Accuracy(moves, true))
+	assert.Equal(t, float32(0), Accuracy(moves, false))
+
+	_, err = AnalyzeMoves(board, turn, []Tile{topLeft, topLeft})
+	assert.Equal(t, ErrOccupied, err)
+}
+
+func TestTableCounts(t *testing.T) {
+	assert.Equal(t, 5478, positionCount)
+	assert.Equal(t, 765, len(table))
+}
+
+func TestCanonical(t *testing.T) {
+	type Test struct {
+		boardStr    string
+		expBoardStr string
+	}
+
+	tests := []Test{
+		{boardStr: "_________", expBoardStr: "_________"},
+		{boardStr: "x________", expBoardStr: "________x"},
+		{boardStr: "__x______", expBoardStr: "________x"},
+		{boardStr: "______x__", expBoardStr: "________x"},
+		{boardStr: "_x_______", expBoardStr: "_______x_"},
+		{boardStr: "x___o____", expBoardStr: "____o___x"},
+		{boardStr: "xo_______", expBoardStr: "_______ox"},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			board, err := ParseBoard(test.boardStr)
+			if err != nil {
+				t.Fatalf("failed to parse board: %v", err)
+			}
+
+			canonical, symmetry := Canonical(board)
+			assert.Equal(t, test.expBoardStr, BoardToString(canonical))
+			assert.Equal(t, board, symmetry.Inverse().Board(canonical))
+		})
+	}
+}
+
+func TestSolveMatchesSearch(t *testing.T) {
+	for _, b := range reachable() {
+		xTurn := b.Turn()
+
+		eval, moves := solveWith(b, xTurn, search)
+		solution := Solve(b.Board(), xTurn)
+
+		assert.Equal(t, eval, solution.Evaluation, "board: %s", b)
+		assert.Equal(t, moves, movesMask(solution.Moves), "board: %s", b)
+	}
+}
+
+func movesMask(tiles []Tile) uint16 {
+	var mask uint16
+	for _, tile := range tiles {
+		mask |= 1 << GetIndex(tile)
+	}
+	return mask
+}
+
+// reachable lists every position reachable from the empty board.
+func reachable() []Bitboard {
+	seen := make(map[uint16]bool)
+	enumerate(Bitboard{}, true, seen)
+
+	var positions []Bitboard
+	for code := range seen {
+		positions = append(positions, Decode(code))
+	}
+	return positions
+}
+
+func TestValidatePosition(t *testing.T) {
+	type Test struct {
+		boardStr string
+		xTurn    bool
+		expErr   error
+	}
+
+	tests := []Test{
+		{boardStr: "_________", xTurn: true},
+		{boardStr: "x_o______", xTurn: true},
+		{boardStr: "xxxoo____", xTurn: false},
+		{boardStr: "xoxxoxoxo", xTurn: false},
+		{boardStr: "o________", xTurn: true, expErr: ErrMarkCount},
+		{boardStr: "xx_______", xTurn: false, expErr: ErrMarkCount},
+		{boardStr: "x________", xTurn: true, expErr: ErrTurn},
+		{boardStr: "xxxooo___", xTurn: true, expErr: ErrBothWon},
+		{boardStr: "xxxoo_o__", xTurn: true, expErr: ErrMoveAfterWin},
+		{boardStr: "ooox_xx_x", xTurn: false, expErr: ErrMoveAfterWin},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			board, err := ParseBoard(test.boardStr)
+			if err != nil {
+				t.Fatalf("failed to parse board: %v", err)
+			}
+
+			assert.Equal(t, test.expErr, ValidatePosition(board, test.xTurn))
+		})
+	}
+}
+
+func TestValidatePositionMatchesReachable(t *testing.T) {
+	reachableCodes := make(map[uint16]bool)
+	for _, b := range reachable() {
+		reachableCodes[b.Encode()] = true
+	}
+
+	// every assignment of marks to tiles, with either side to move
+	for code := range uint16(19683) {
+		b := Decode(code)
+
+		for _, xTurn := range []bool{true, false} {
+			valid := ValidatePosition(b.Board(), xTurn) == nil
+			assert.Equal(t, reachableCodes[code] && b.Turn() == xTurn, valid, "board: %s, xTurn: %v", b, xTurn)
+		}
+	}
+}
+
+func TestPlayPuzzleMove(t *testing.T) {
+	board, err := ParseBoard("xo_______")
+	if err != nil {
+		t.Fatalf("failed to parse board: %v", err)
+	}
+	assert.Nil(t, ValidatePuzzle(board, true, 3))
+	assert.Equal(t, ErrNoForcedWin, ValidatePuzzle(board, true, 2))
+
+	type Test struct {
+		tile        Tile
+		expBoardStr string
+		expStatus   int32
+		expReply    *Tile
+	}
+
+	// the solver keeps a forced win on every move and the defender replies with its slowest loss
+	tests := []Test{
+		{tile: middle, expBoardStr: "xo__x___o", expStatus: PuzzleSolving, expReply: &bottomRight},
+		{tile: left, expBoardStr: "xooxx___o", expStatus: PuzzleSolving, expReply: &topRight},
+		{tile: right, expBoardStr: "xooxxx__o", expStatus: PuzzleSolved},
+	}
+
+	xTurn, movesLeft := true, int32(3)
+	for i, test := range tests {
+		step, err := PlayPuzzleMove(board, xTurn, movesLeft, test.tile)
+		if err != nil {
+			t.Fatalf("failed to play puzzle move %d: %v", i, err)
+		}
+
+		assert.Equal(t, test.expBoardStr, BoardToString(step.Board))
+		assert.Equal(t, test.expStatus, step.Status)
+		assert.Equal(t, test.expReply, step.Reply)
+		assert.Equal(t, movesLeft-1, step.MovesLeft)
+
+		board, xTurn, movesLeft = step.Board, step.XTurn, step.MovesLeft
+	}
+
+	board, _ = ParseBoard("xo_______")
+	step, err := PlayPuzzleMove(board, true, 3, bottomRight)
+	assert.Nil(t, err)
+	assert.Equal(t, PuzzleFailed, step.Status)
+}
+
+func TestGeneratePuzzles(t *testing.T) {
+	type Test struct {
+		depth    int32
+		expCount int
+	}
+
+	tests := []Test{
+		{depth: 1, expCount: 264},
+		{depth: 2, expCount: 42},
+		{depth: 3, expCount: 9},
+		{depth: 4, expCount: 0},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			puzzles := GeneratePuzzles(test.depth)
+			assert.Equal(t, test.expCount, len(puzzles))
+
+			for _, puzzle := range puzzles {
+				assert.Nil(t, ValidatePuzzle(puzzle.Board, puzzle.XTurn, test.depth))
+				assert.Equal(t, ErrNoForcedWin, ValidatePuzzle(puzzle.Board, puzzle.XTurn, test.depth-1))
+
+				solution := Solve(puzzle.Board, puzzle.XTurn)
+				assert.Equal(t, []Tile{puzzle.Solution}, solution.Moves)
+				assert.Contains(t, []int32{DifficultyEasy, DifficultyMedium, DifficultyHard}, puzzle.Difficulty)
+			}
+		})
+	}
+}
+
+func TestClassifyOpening(t *testing.T) {
+	type Test struct {
+		boardStr  string
+		expId     int32
+		expFamily string
+	}
+
+	tests := []Test{
+		{boardStr: "x___o____", expId: OpeningCornerCenter, expFamily: FamilyCorner},
+		{boardStr: "____o___x", expId: OpeningCornerCenter, expFamily: FamilyCorner},
+		{boardStr: "__o___x__", expId: OpeningCornerOppositeCorner, expFamily: FamilyCorner},
+		{boardStr: "x_____o__", expId: OpeningCornerAdjacentCorner, expFamily: FamilyCorner},
+		{boardStr: "x__o_____", expId: OpeningCornerAdjacentEdge, expFamily: FamilyCorner},
+		{boardStr: "x____o___", expId: OpeningCornerFarEdge, expFamily: FamilyCorner},
+		{boardStr: "___xo____", expId: OpeningEdgeCenter, expFamily: FamilyEdge},
+		{boardStr: "_____x__o", expId: OpeningEdgeAdjacentCorner, expFamily: FamilyEdge},
+		{boardStr: "o______x_", expId: OpeningEdgeFarCorner, expFamily: FamilyEdge},
+		{boardStr: "_o_____x_", expId: OpeningEdgeOppositeEdge, expFamily: FamilyEdge},
+		{boardStr: "_x___o___", expId: OpeningEdgeAdjacentEdge, expFamily: FamilyEdge},
+		{boardStr: "____x___o", expId: OpeningCenterCorner, expFamily: FamilyCenter},
+		{boardStr: "___ox____", expId: OpeningCenterEdge, expFamily: FamilyCenter},
+		{boardStr: "____x____", expId: OpeningNone},
+		{boardStr: "x___o___x", expId: OpeningNone},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			board, err := ParseBoard(test.boardStr)
+			if err != nil {
+				t.Fatalf("failed to parse board: %v", err)
+			}
+
+			opening, ok := ClassifyOpening(board)
+			assert.Equal(t, test.expId != OpeningNone, ok)
+			assert.Equal(t, test.expId, opening.ID)
+			assert.Equal(t, test.expFamily, opening.Family)
+		})
+	}
+}
+
+func TestOpeningsCoverReplies(t *testing.T) {
+	counts := make(map[int32]int)
+	for first := range 9 {
+		for reply := range 9 {
+			if first == reply {
+				continue
+			}
+			board := Bitboard{}.Move(first, true).Move(reply, false).Board()
+			opening, ok := ClassifyOpening(board)
+			if !ok {
+				t.Fatalf("expected opening for board %s", BoardToString(board))
+			}
+			counts[opening.ID]++
+		}
+	}
+	assert.Equal(t, len(openingPositions), len(counts))
+}
@@ -0,0 +1,34 @@
+package tictactoe
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrMarkCount = errors.New("mark counts cannot be reached by alternating moves with x first")
+var ErrTurn = errors.New("side to move does not match the mark counts")
+var ErrBothWon = errors.New("both sides have three in a row")
+var ErrMoveAfterWin = errors.New("a move was made after the game was won")
+
+// ValidatePosition checks that a position can be reached from the empty board by alternating moves
+// with x first, and that the side to move is the one that follows from it.
+func ValidatePosition(board Board, xTurn bool) error {
+	b := FromBoard(board)
+	xs, os := bits.OnesCount16(b.X), bits.OnesCount16(b.O)
+	if xs != os && xs != os+1 {
+		return ErrMarkCount
+	}
+	if b.Turn() != xTurn {
+		return ErrTurn
+	}
+
+	xWon, oWon := wonMasks[b.X], wonMasks[b.O]
+	if xWon && oWon {
+		return ErrBothWon
+	}
+	// the winning mark must have been the last one placed
+	if xWon && xs == os || oWon && xs != os {
+		return ErrMoveAfterWin
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ func NewConfig() Config {
 	contentStr := string(content)
 
 	for _, line := range strings.Split(contentStr, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
 		index := strings.Index(line, "=")
 		m[line[0:index]] = strings.TrimRight(line[index+1:], "\n\r\t")
 	}
@@ -38,3 +41,15 @@ func (c Config) Get(key string) string {
 	}
 	return v
 }
+
+// GetOr reads an optional config key, returning the fallback when it is not set.
+func (c Config) GetOr(key string, fallback string) string {
+	v, ok := c.m[key]
+	if !ok {
+		v, ok = os.LookupEnv(key)
+		if !ok {
+			return fallback
+		}
+	}
+	return v
+}